@@ -0,0 +1,174 @@
+// File: add.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: interactive staging (`gits add`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// entryCode returns the two-letter short-status code of e ("M ", "??", ...).
+func entryCode(e FileEntry) string {
+	return strings.ReplaceAll(e.Index+e.Worktree, ".", " ")
+}
+
+// entryStyle picks the color for e from its worktree state.
+func entryStyle(e FileEntry, c ColorConfig) string {
+	switch {
+	case e.Kind == "untracked":
+		return Bold + resolveColor(c.Untracked)
+	case e.Kind == "unmerged" || e.Worktree == "D":
+		return Bold + resolveColor(c.Deleted)
+	case e.Kind == "renamed":
+		return Bold + resolveColor(c.Renamed)
+	}
+	return Bold + resolveColor(c.Modified)
+}
+
+// entryLabel is the path of e as shown in lists ("old → new" for renames).
+func entryLabel(e FileEntry) string {
+	if e.OrigPath != "" {
+		return e.OrigPath + " → " + e.Path
+	}
+	return e.Path
+}
+
+// stagePaths runs `git add` for paths relative to the repository root.  The
+// paths are passed NUL-separated on stdin so no name is taken as a pattern.
+func stagePaths(root string, paths []string) error {
+	cmd := gitCmd(root, "add", "--pathspec-from-file=-", "--pathspec-file-nul")
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\x00"))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git add: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// runAddPatch implements `gits add --patch`: go through the modified files
+// one at a time and stage the lines picked in the hunk picker.
+func runAddPatch(status *Status, root string, rs *RepoStatus) {
+	c := status.cfg.Colors
+	var files []string
+	for _, e := range rs.Entries {
+		if e.Unstaged() && e.Worktree != "D" {
+			files = append(files, e.Path)
+		}
+	}
+	if len(files) == 0 {
+		fmt.Printf("%s %sNothing to stage line by line%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+		return
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		exitWithError(errNotTerminal, c)
+	}
+	restore, err := rawTerminal(fd, "\x1b[?25h\x1b[?1049l")
+	if err != nil {
+		exitWithError(err, c)
+	}
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	staged := 0
+	var skipped []string
+	for _, f := range files {
+		hp, err := newHunkPicker(root, f, false, c)
+		if err != nil {
+			skipped = append(skipped, err.Error())
+			continue
+		}
+		applied, quit, _ := hp.run()
+		if applied {
+			staged++
+		}
+		if quit {
+			break
+		}
+	}
+	restore()
+
+	for _, s := range skipped {
+		fmt.Printf("%s %s%s%s\n", Icons.INFO, Dim, s, Reset)
+	}
+	fmt.Printf("%s %sStaged lines in %d file(s)%s\n\n", Icons.SUCCESS, resolveColor(c.UpToDate), staged, Reset)
+	status.ColorizeGitStatus(root, "")
+}
+
+// runAdd implements `gits add [--all] [--patch] [path]`: pick changed and untracked
+// files from a checkbox list and stage the selection.
+func runAdd(status *Status, args []string) {
+	c := status.cfg.Colors
+	dir := "."
+	preselect, patch := false, false
+	for _, a := range args {
+		switch a {
+		case "-A", "--all":
+			preselect = true
+		case "-p", "--patch":
+			patch = true
+		default:
+			dir = a
+		}
+	}
+
+	root, err := repoRoot(dir)
+	if err != nil {
+		exitWithError(err, c)
+	}
+	rs := CollectStatus(root)
+	if rs.Err != "" {
+		exitWithError(fmt.Errorf("%s", rs.Err), c)
+	}
+	if patch {
+		runAddPatch(status, root, rs)
+		return
+	}
+
+	var entries []FileEntry
+	var items []pickItem
+	for _, e := range rs.Entries {
+		if e.Kind == "untracked" || e.Kind == "unmerged" || e.Unstaged() {
+			entries = append(entries, e)
+			items = append(items, pickItem{
+				Tag:      entryCode(e),
+				TagStyle: entryStyle(e, c),
+				Label:    entryLabel(e),
+				Checked:  preselect,
+			})
+		}
+	}
+	if len(items) == 0 {
+		fmt.Printf("%s %sNothing to stage%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+		return
+	}
+
+	checked, ok, err := runPicker("Stage changes in "+displayPath(root), items, c)
+	if err != nil {
+		exitWithError(err, c)
+	}
+	if !ok {
+		fmt.Printf("%s Cancelled, nothing staged\n", Icons.INFO)
+		return
+	}
+
+	var paths []string
+	for i, v := range checked {
+		if v {
+			paths = append(paths, entries[i].Path)
+		}
+	}
+	if len(paths) == 0 {
+		fmt.Printf("%s Nothing selected\n", Icons.INFO)
+		return
+	}
+	if err := stagePaths(root, paths); err != nil {
+		exitWithError(err, c)
+	}
+	fmt.Printf("%s %sStaged %d file(s)%s\n\n", Icons.SUCCESS, resolveColor(c.UpToDate), len(paths), Reset)
+	status.ColorizeGitStatus(root, "")
+}
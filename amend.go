@@ -0,0 +1,120 @@
+// File: amend.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: amend the last commit with a preview (`gits amend`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// emptyTree is the object name of git's empty tree, the "parent" of a root
+// commit when diffing.
+const emptyTree = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// runAmend implements `gits amend [--no-edit] [--add PATH...] [--yes]
+// [git commit args...]`.
+func runAmend(status *Status, args []string) {
+	c := status.cfg.Colors
+	yes := false
+	var add, gitArgs []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-y", "--yes":
+			yes = true
+		case "--add":
+			// Everything up to the next flag is a path to stage.
+			for i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				i++
+				add = append(add, args[i])
+			}
+		default:
+			gitArgs = append(gitArgs, args[i])
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	out, err := gitOutput(root, "log", "-1", "--format=%H%x00%h%x00%s")
+	if err != nil {
+		fail(fmt.Errorf("no commit to amend"))
+	}
+	f := strings.SplitN(out, "\x00", 3)
+	if len(f) < 3 {
+		fail(fmt.Errorf("cannot read HEAD"))
+	}
+	sha, short, subject := f[0], f[1], f[2]
+
+	if len(add) > 0 {
+		prefix, _ := gitOutput(".", "rev-parse", "--show-prefix")
+		for i, p := range add {
+			add[i] = filepath.ToSlash(filepath.Clean(filepath.Join(prefix, p)))
+		}
+		if err := stagePaths(root, add); err != nil {
+			fail(err)
+		}
+	}
+
+	fmt.Printf("%s %sAmending%s %s%s%s %s\n", Icons.INFO, Bold+resolveColor(c.Header), Reset,
+		Bold+resolveColor(c.AheadBehind), short, Reset, subject)
+	if numstat, err := gitOutput(root, "show", "--numstat", "--format=", "HEAD"); err == nil && numstat != "" {
+		fmt.Printf("   %soriginal commit%s\n", Dim, Reset)
+		fmt.Print(renderNumstat(numstat, c, "    "))
+	}
+	staged, _ := gitOutput(root, "diff", "--cached", "--numstat")
+	if staged != "" {
+		fmt.Printf("   %snewly staged%s\n", Dim, Reset)
+		fmt.Print(renderNumstat(staged, c, "    "))
+
+		// The amended commit is the parent (or nothing) plus the index.
+		parent := "HEAD~1"
+		if _, err := gitOutput(root, "rev-parse", "--verify", "--quiet", "HEAD~1"); err != nil {
+			parent = emptyTree
+		}
+		if result, err := gitOutput(root, "diff", "--cached", "--numstat", parent); err == nil {
+			fmt.Printf("   %samended commit%s\n", Dim, Reset)
+			fmt.Print(renderNumstat(result, c, "    "))
+		}
+	} else {
+		fmt.Printf("   %snothing newly staged; only the message changes%s\n", Dim, Reset)
+	}
+
+	if commitPushed(root, sha) {
+		fmt.Printf("%s %s%s is already on a remote; after amending, the branch needs a force push%s\n",
+			Icons.WARNING, resolveColor(c.AheadBehind), short, Reset)
+	}
+	if !yes {
+		ok, err := confirm("Amend this commit?")
+		if err != nil {
+			fail(fmt.Errorf("%v (use --yes)", err))
+		}
+		if !ok {
+			fmt.Printf("%s Amend aborted\n", Icons.INFO)
+			return
+		}
+	}
+
+	// Attached to the terminal for the editor, unless --no-edit.
+	cmd := gitCmd(root, append([]string{"commit", "--amend", "--quiet"}, gitArgs...)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		fail(fmt.Errorf("git commit --amend failed"))
+	}
+	out, err = gitOutput(root, "log", "-1", "--format=%h%x00%s")
+	if err != nil {
+		return
+	}
+	newShort, newSubject, _ := strings.Cut(out, "\x00")
+	fmt.Printf("\n%s %sAmended%s %s%s → %s%s %s\n", Icons.SUCCESS, Bold+resolveColor(c.UpToDate), Reset,
+		Bold+resolveColor(c.AheadBehind), short, newShort, Reset, newSubject)
+}
@@ -0,0 +1,703 @@
+// File: backend.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: status backends: the git CLI or a pure-Go reader (go-git)
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// statusBackend is how the status is read: "cli" runs `git status`,
+// "native" reads the repository with go-git.  Set from the config and
+// --backend.
+var statusBackend = "cli"
+
+// fastStatus skips the untracked scan and the worktrees of submodules
+// (--fast), for worktrees so large that walking them dominates.
+var fastStatus bool
+
+// fastArgs are the `git status` options --fast adds.
+func fastArgs() []string {
+	if !fastStatus {
+		return nil
+	}
+	return []string{"--untracked-files=no", "--ignore-submodules=dirty"}
+}
+
+// gitAvailable reports whether a git binary is on PATH.
+var gitAvailable = sync.OnceValue(func() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+})
+
+// useNative reports whether the native backend reads the status: when it
+// is selected, or when there is no git binary to fall back on.
+func useNative() bool {
+	return statusBackend == "native" || !gitAvailable()
+}
+
+// openNative opens the repository containing dir and returns it with the
+// top of its worktree.
+func openNative(dir string) (*git.Repository, string, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true, EnableDotGitCommonDir: true})
+	if err != nil {
+		return nil, "", fmt.Errorf("native: %s: %w", dir, err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, "", fmt.Errorf("native: %s: %w", dir, err)
+	}
+	return repo, wt.Filesystem.Root(), nil
+}
+
+// nativeExcludes are the ignore rules from outside the worktree that git
+// applies: core.excludesFile from the user and system config, or else
+// $XDG_CONFIG_HOME/git/ignore.
+func nativeExcludes() []gitignore.Pattern {
+	root := osfs.New("/")
+	ps, _ := gitignore.LoadSystemPatterns(root)
+	global, _ := gitignore.LoadGlobalPatterns(root)
+	if global == nil {
+		dir := os.Getenv("XDG_CONFIG_HOME")
+		if dir == "" {
+			home, _ := os.UserHomeDir()
+			dir = filepath.Join(home, ".config")
+		}
+		if f, err := os.Open(filepath.Join(dir, "git", "ignore")); err == nil {
+			sc := bufio.NewScanner(f)
+			for sc.Scan() {
+				if line := sc.Text(); !strings.HasPrefix(line, "#") && strings.TrimSpace(line) != "" {
+					global = append(global, gitignore.ParsePattern(line, nil))
+				}
+			}
+			f.Close()
+		}
+	}
+	return append(ps, global...)
+}
+
+// nativeCodes maps go-git status codes to porcelain letters.
+var nativeCodes = map[git.StatusCode]string{
+	git.Unmodified: ".",
+	git.Modified:   "M",
+	git.Added:      "A",
+	git.Deleted:    "D",
+	git.Renamed:    "R",
+	git.Copied:     "C",
+	git.Untracked:  "?",
+}
+
+// unmergedCodes spells the porcelain XY of an unmerged path from the index
+// stages it has (1 base, 2 ours, 3 theirs).
+var unmergedCodes = map[string]string{
+	"123": "UU", "23": "AA", "12": "UD", "13": "DU", "2": "AU", "3": "UA", "1": "DD",
+}
+
+// nativeStatus reads the status of the repository containing dir with
+// go-git, in the shape CollectStatus gives.  Untracked directories are
+// collapsed like `git status` does, and renames are only detected when the
+// content is unchanged.  status.showUntrackedFiles and status.renames (or
+// diff.renames) are honored as git honors them.
+func nativeStatus(dir string) (*RepoStatus, error) {
+	rs := &RepoStatus{Path: dir, Entries: []FileEntry{}}
+	repo, _, err := openNative(dir)
+	if err != nil {
+		return rs, err
+	}
+	settings := nativeStatusSettings(repo)
+	wt, _ := repo.Worktree()
+	wt.Excludes = nativeExcludes()
+	st, err := wt.Status()
+	if err != nil {
+		return rs, fmt.Errorf("native: status: %w", err)
+	}
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return rs, fmt.Errorf("native: index: %w", err)
+	}
+
+	// Conflicted paths are in the index once per stage.  (go-git's
+	// index.Merged constant is 1, but merged entries decode as stage 0.)
+	stages := map[string]string{}
+	trackedDirs := map[string]bool{}
+	for _, e := range idx.Entries {
+		if e.Stage != 0 {
+			stages[e.Name] += fmt.Sprint(int(e.Stage))
+		}
+		for d := filepath.ToSlash(filepath.Dir(e.Name)); d != "."; d = filepath.ToSlash(filepath.Dir(d)) {
+			trackedDirs[d] = true
+		}
+	}
+
+	var head *object.Commit
+	if ref, err := repo.Head(); err == nil {
+		head, _ = repo.CommitObject(ref.Hash())
+	}
+	var added, deleted []string
+	untracked := map[string]bool{}
+	var entries []FileEntry
+	for path, fs := range st {
+		if _, conflicted := stages[path]; conflicted {
+			continue
+		}
+		e := FileEntry{Path: path, Index: nativeCodes[fs.Staging], Worktree: nativeCodes[fs.Worktree], Kind: "changed"}
+		switch {
+		case fs.Staging == git.Untracked && (fastStatus || settings.Untracked == "no"):
+			// go-git has walked the worktree anyway; only the listing is
+			// skipped.
+			continue
+		case fs.Staging == git.Untracked:
+			// Shown as the topmost directory holding no tracked file,
+			// unless status.showUntrackedFiles is "all".
+			for d := filepath.ToSlash(filepath.Dir(path)); d != "." && settings.Untracked != "all"; d = filepath.ToSlash(filepath.Dir(d)) {
+				if !trackedDirs[d] {
+					e.Path = d + "/"
+				}
+			}
+			if !untracked[e.Path] {
+				untracked[e.Path] = true
+				entries = append(entries, FileEntry{Path: e.Path, Index: "?", Worktree: "?", Kind: "untracked"})
+			}
+			continue
+		case e.Index == "." && e.Worktree == ".":
+			continue
+		case fs.Staging == git.Added:
+			added = append(added, path)
+		case fs.Staging == git.Deleted:
+			deleted = append(deleted, path)
+		}
+		entries = append(entries, e)
+	}
+	for path, s := range stages {
+		xy, ok := unmergedCodes[s]
+		if !ok {
+			xy = "UU"
+		}
+		entries = append(entries, FileEntry{Path: path, Index: xy[:1], Worktree: xy[1:], Kind: "unmerged"})
+	}
+	if settings.Renames {
+		entries = nativeRenames(entries, head, idx, added, deleted)
+	}
+	rs.UntrackedSkipped = fastStatus || settings.Untracked == "no"
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	for _, e := range entries {
+		rs.addEntry(e)
+	}
+	nativeBranch(repo, rs)
+	return rs, nil
+}
+
+// nativeRenames pairs staged deletions with staged additions of the same
+// content, as `git status` reports them as renames.
+func nativeRenames(entries []FileEntry, head *object.Commit, idx *index.Index, added, deleted []string) []FileEntry {
+	if head == nil || len(added) == 0 || len(deleted) == 0 {
+		return entries
+	}
+	tree, err := head.Tree()
+	if err != nil {
+		return entries
+	}
+	gone := map[plumbing.Hash]string{}
+	sort.Strings(deleted)
+	for _, path := range deleted {
+		if f, err := tree.File(path); err == nil {
+			if _, dup := gone[f.Hash]; !dup {
+				gone[f.Hash] = path
+			}
+		}
+	}
+	renamed := map[string]string{} // new path → old path
+	sort.Strings(added)
+	for _, path := range added {
+		if e, err := idx.Entry(path); err == nil {
+			if from, ok := gone[e.Hash]; ok {
+				renamed[path] = from
+				delete(gone, e.Hash)
+			}
+		}
+	}
+	from := map[string]bool{}
+	for _, old := range renamed {
+		from[old] = true
+	}
+	out := entries[:0]
+	for _, e := range entries {
+		switch {
+		case from[e.Path] && e.Index == "D":
+			// Folded into the rename, unless it was also recreated.
+			if e.Worktree == "." {
+				continue
+			}
+		case renamed[e.Path] != "":
+			e.Kind, e.Index, e.OrigPath = "renamed", "R", renamed[e.Path]
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// nativeBranch fills the branch, upstream, ahead/behind and stash count.
+func nativeBranch(repo *git.Repository, rs *RepoStatus) {
+	head, err := repo.Head()
+	if err != nil {
+		// Unborn: HEAD names a branch with no commit yet.
+		if ref, err := repo.Storer.Reference(plumbing.HEAD); err == nil {
+			rs.Branch = ref.Target().Short()
+		}
+	} else {
+		rs.Oid = head.Hash().String()
+		if head.Name().IsBranch() {
+			rs.Branch = head.Name().Short()
+		} else {
+			rs.Branch, rs.Detached = "(detached)", true
+		}
+	}
+
+	if cfg, err := repo.Config(); err == nil && !rs.Detached {
+		if b := cfg.Branches[rs.Branch]; b != nil && b.Remote != "" && b.Merge != "" {
+			upstream := plumbing.NewRemoteReferenceName(b.Remote, b.Merge.Short())
+			if b.Remote == "." {
+				upstream = b.Merge
+			}
+			rs.Upstream = upstream.Short()
+			// As with git, a gone upstream has no ahead/behind.
+			if up, err := repo.Reference(upstream, true); err != nil {
+				rs.Gone = rs.Oid != ""
+			} else if rs.Oid != "" {
+				rs.Ahead, rs.Behind = nativeAheadBehind(repo, head.Hash(), up.Hash())
+			}
+		}
+	}
+
+	if s, ok := repo.Storer.(*filesystem.Storage); ok {
+		if data, err := os.ReadFile(filepath.Join(s.Filesystem().Root(), "logs", "refs", "stash")); err == nil {
+			rs.Stashes = strings.Count(string(data), "\n")
+		}
+	}
+}
+
+// nativeAheadBehind counts the commits only reachable from a, and only
+// from b.
+func nativeAheadBehind(repo *git.Repository, a, b plumbing.Hash) (ahead, behind int) {
+	if a == b {
+		return 0, 0
+	}
+	ca, err1 := repo.CommitObject(a)
+	cb, err2 := repo.CommitObject(b)
+	if err1 != nil || err2 != nil {
+		return 0, 0
+	}
+	bases, err := ca.MergeBase(cb)
+	if err != nil {
+		return 0, 0
+	}
+	shared := map[plumbing.Hash]bool{}
+	for _, base := range bases {
+		object.NewCommitPreorderIter(base, shared, nil).ForEach(func(c *object.Commit) error {
+			shared[c.Hash] = true
+			return nil
+		})
+	}
+	count := func(c *object.Commit) int {
+		n := 0
+		object.NewCommitPreorderIter(c, shared, nil).ForEach(func(*object.Commit) error {
+			n++
+			return nil
+		})
+		return n
+	}
+	return count(ca), count(cb)
+}
+
+// readStatus reads the status of dir for the long status Report draws:
+// natively, or else with one `git status --porcelain=v2` that also hands
+// back what git wrote to stderr while succeeding.  st are the settings of
+// the repository, read beforehand.
+func readStatus(dir string, st statusSettings) (rs *RepoStatus, stderr string) {
+	if useNative() {
+		start := time.Now()
+		rs, err := nativeStatus(dir)
+		timings.phase("parse", start)
+		if err == nil {
+			return rs, ""
+		}
+		if !gitAvailable() {
+			rs.Err = err.Error()
+			return rs, ""
+		}
+		if debugMode {
+			fmt.Fprintf(os.Stderr, "native backend failed, using git: %v\n", err)
+		}
+	}
+	rs, stderr = porcelainStatus(dir)
+	if rs.Err == "" {
+		rs.UntrackedSkipped = fastStatus || st.Untracked == "no"
+	}
+	return rs, stderr
+}
+
+// longStatusNames are the labels of the long status format.
+var longStatusNames = map[string]string{
+	"M": "modified:   ",
+	"T": "typechange: ",
+	"A": "new file:   ",
+	"D": "deleted:    ",
+	"R": "renamed:    ",
+	"C": "copied:     ",
+}
+
+// unmergedNames label unmerged paths in the long status format.
+var unmergedNames = map[string]string{
+	"UU": "both modified:   ", "AA": "both added:      ", "UD": "deleted by them: ", "DU": "deleted by us:   ",
+	"AU": "added by us:     ", "UA": "added by them:   ", "DD": "both deleted:    ",
+}
+
+// longStatus renders rs like `git status` would for a shell in cwd, with
+// ws the operation in progress, which is what ColorizeGitStatus parses.
+func longStatus(rs *RepoStatus, ws *repoState, root, cwd string) string {
+	var sb strings.Builder
+	rel := func(p string) string {
+		full := filepath.Join(root, filepath.FromSlash(p))
+		if r, err := relPath(cwd, full); err == nil {
+			full = filepath.ToSlash(r)
+		}
+		if strings.HasSuffix(p, "/") {
+			full += "/"
+		}
+		return quoteGitPath(full)
+	}
+	plural := func(n int) string {
+		if n == 1 {
+			return "commit"
+		}
+		return "commits"
+	}
+
+	switch {
+	case !rs.Detached:
+		fmt.Fprintf(&sb, "On branch %s\n", rs.Branch)
+	case ws.Rebase && ws.Interactive:
+		fmt.Fprintf(&sb, "interactive rebase in progress; onto %s\n", ws.Onto)
+	case ws.Rebase:
+		fmt.Fprintf(&sb, "rebase in progress; onto %s\n", ws.Onto)
+	case ws.DetachedFrom != "" && ws.DetachedAt:
+		fmt.Fprintf(&sb, "HEAD detached at %s\n", ws.DetachedFrom)
+	case ws.DetachedFrom != "":
+		fmt.Fprintf(&sb, "HEAD detached from %s\n", ws.DetachedFrom)
+	default:
+		sb.WriteString("Not currently on any branch.\n")
+	}
+	switch {
+	case rs.Oid == "" || rs.Upstream == "":
+	case rs.Gone:
+		fmt.Fprintf(&sb, "Your branch is based on '%s', but the upstream is gone.\n  (use \"git branch --unset-upstream\" to fixup)\n", rs.Upstream)
+	case rs.Ahead > 0 && rs.Behind > 0:
+		fmt.Fprintf(&sb, "Your branch and '%s' have diverged,\nand have %d and %d different commits each, respectively.\n",
+			rs.Upstream, rs.Ahead, rs.Behind)
+		// git 2.41 reworded the advice.
+		if v := gitVersion(); v[0] > 2 || v[0] == 2 && v[1] >= 41 {
+			sb.WriteString("  (use \"git pull\" if you want to integrate the remote branch with yours)\n")
+		} else {
+			sb.WriteString("  (use \"git pull\" to merge the remote branch into yours)\n")
+		}
+	case rs.Ahead > 0:
+		fmt.Fprintf(&sb, "Your branch is ahead of '%s' by %d %s.\n  (use \"git push\" to publish your local commits)\n",
+			rs.Upstream, rs.Ahead, plural(rs.Ahead))
+	case rs.Behind > 0:
+		fmt.Fprintf(&sb, "Your branch is behind '%s' by %d %s, and can be fast-forwarded.\n  (use \"git pull\" to update your local branch)\n",
+			rs.Upstream, rs.Behind, plural(rs.Behind))
+	default:
+		fmt.Fprintf(&sb, "Your branch is up to date with '%s'.\n", rs.Upstream)
+	}
+	if rs.Oid != "" && rs.Upstream != "" {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(ws.long(rs.Conflicts > 0))
+	if rs.Oid == "" {
+		sb.WriteString("\nNo commits yet\n\n")
+	}
+
+	section := func(title string, hints []string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "%s\n", title)
+		for _, h := range hints {
+			fmt.Fprintf(&sb, "  (%s)\n", h)
+		}
+		for _, l := range lines {
+			fmt.Fprintf(&sb, "\t%s\n", l)
+		}
+		sb.WriteString("\n")
+	}
+	var staged, unmerged, unstaged, untracked []string
+	// Deletions turn git's "add" hints into "add/rm".
+	deleted, bothDeleted, deleteConflict, kept := false, false, false, false
+	for _, e := range rs.Entries {
+		switch e.Kind {
+		case "untracked":
+			untracked = append(untracked, rel(e.Path))
+		case "unmerged":
+			unmerged = append(unmerged, unmergedNames[e.Index+e.Worktree]+rel(e.Path))
+			switch e.Index + e.Worktree {
+			case "DD":
+				bothDeleted = true
+			case "UD", "DU":
+				deleteConflict = true
+			default:
+				kept = true
+			}
+		case "changed", "renamed":
+			if e.Staged() {
+				path := rel(e.Path)
+				if e.OrigPath != "" {
+					path = rel(e.OrigPath) + " -> " + path
+				}
+				staged = append(staged, longStatusNames[e.Index]+path)
+			}
+			if e.Unstaged() {
+				deleted = deleted || e.Worktree == "D"
+				unstaged = append(unstaged, longStatusNames[e.Worktree]+rel(e.Path))
+			}
+		}
+	}
+	// Concluding a merge or a cherry-pick, git has no advice on unstaging.
+	var unstage []string
+	switch {
+	case ws.Merge || ws.PickHead:
+	case rs.Oid == "":
+		unstage = []string{`use "git rm --cached <file>..." to unstage`}
+	default:
+		unstage = []string{`use "git restore --staged <file>..." to unstage`}
+	}
+	section("Changes to be committed:", unstage, staged)
+	resolve := `use "git add <file>..." to mark resolution`
+	switch {
+	case bothDeleted && !deleteConflict && !kept:
+		resolve = `use "git rm <file>..." to mark resolution`
+	case bothDeleted || deleteConflict:
+		resolve = `use "git add/rm <file>..." as appropriate to mark resolution`
+	}
+	section("Unmerged paths:", append(unstage, resolve), unmerged)
+	add := `use "git add <file>..." to update what will be committed`
+	if deleted {
+		add = `use "git add/rm <file>..." to update what will be committed`
+	}
+	section("Changes not staged for commit:", []string{add,
+		`use "git restore <file>..." to discard changes in working directory`}, unstaged)
+	section("Untracked files:", []string{`use "git add <file>..." to include in what will be committed`}, untracked)
+
+	switch {
+	case rs.UntrackedSkipped && len(staged) > 0:
+		sb.WriteString("Untracked files not listed (use -u option to show untracked files)\n")
+	case rs.UntrackedSkipped && len(unstaged)+len(unmerged) == 0:
+		sb.WriteString("nothing to commit (use -u to show untracked files)\n")
+	case len(staged) > 0:
+	case len(unstaged)+len(unmerged) > 0:
+		sb.WriteString("no changes added to commit (use \"git add\" and/or \"git commit -a\")\n")
+	case len(untracked) > 0:
+		sb.WriteString("nothing added to commit but untracked files present (use \"git add\" to track)\n")
+	case rs.Oid == "":
+		sb.WriteString("nothing to commit (create/copy files and use \"git add\" to track)\n")
+	default:
+		sb.WriteString("nothing to commit, working tree clean\n")
+	}
+	switch {
+	case rs.Stashes == 1:
+		sb.WriteString("Your stash currently has 1 entry\n")
+	case rs.Stashes > 1:
+		fmt.Fprintf(&sb, "Your stash currently has %d entries\n", rs.Stashes)
+	}
+	return sb.String()
+}
+
+// nativeLastCommit is lastCommit read with go-git.
+func nativeLastCommit(dir string) (headCommit, error) {
+	repo, _, err := openNative(dir)
+	if err != nil {
+		return headCommit{}, err
+	}
+	ref, err := repo.Head()
+	if err != nil {
+		return headCommit{}, err
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return headCommit{}, err
+	}
+	subject, _, _ := strings.Cut(commit.Message, "\n")
+	return headCommit{Hash: shortOid(commit.Hash.String()), Subject: subject, Author: commit.Author.Name, Time: commit.Committer.When}, nil
+}
+
+// cliStatus is CollectStatus through `git status --porcelain=v2`.
+func cliStatus(dir string) *RepoStatus {
+	// git applies status.showUntrackedFiles itself; it is read alongside
+	// only to tell "none" from "not listed".
+	hidden := make(chan bool, 1)
+	go func() { hidden <- !fastStatus && readStatusSettings(dir).Untracked == "no" }()
+	rs, _ := porcelainStatus(dir)
+	if rs.Err == "" {
+		rs.UntrackedSkipped = fastStatus || <-hidden
+	}
+	return rs
+}
+
+// porcelainStatus runs `git status --porcelain=v2` in dir and parses it,
+// returning as well the warnings and hints git wrote to stderr.
+func porcelainStatus(dir string) (*RepoStatus, string) {
+	rs := &RepoStatus{Path: dir, Entries: []FileEntry{}}
+	start := time.Now()
+	out, stderr, err := gitRawStderr(dir, append([]string{"status", "--porcelain=v2", "--branch", "--show-stash", "-z"}, fastArgs()...)...)
+	debugStatusAccel(dir, time.Since(start))
+	if err != nil {
+		rs.Err = err.Error()
+		return rs, ""
+	}
+	defer timings.phase("parse", time.Now())
+	parsePorcelainV2(out, rs)
+	return rs, stderr
+}
+
+// entryKey identifies an entry for comparing backends.
+func entryKey(e FileEntry) string {
+	key := e.Index + e.Worktree + " " + e.Path
+	if e.OrigPath != "" {
+		key += " <- " + e.OrigPath
+	}
+	return key
+}
+
+// compareBackends lists how the native status of dir differs from git's.
+func compareBackends(dir string) (diffs []string, cliTime, nativeTime time.Duration, err error) {
+	start := time.Now()
+	cli := cliStatus(dir)
+	cliTime = time.Since(start)
+	if cli.Err != "" {
+		return nil, 0, 0, fmt.Errorf("%s", cli.Err)
+	}
+	start = time.Now()
+	native, err := nativeStatus(dir)
+	nativeTime = time.Since(start)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	field := func(name string, a, b any) {
+		if fmt.Sprint(a) != fmt.Sprint(b) {
+			diffs = append(diffs, fmt.Sprintf("%s: git %v, native %v", name, a, b))
+		}
+	}
+	field("branch", cli.Branch, native.Branch)
+	field("oid", cli.Oid, native.Oid)
+	field("upstream", cli.Upstream, native.Upstream)
+	field("upstream gone", cli.Gone, native.Gone)
+	field("ahead", cli.Ahead, native.Ahead)
+	field("behind", cli.Behind, native.Behind)
+	field("stashes", cli.Stashes, native.Stashes)
+
+	seen := map[string]int{}
+	for _, e := range cli.Entries {
+		seen[entryKey(e)]++
+	}
+	for _, e := range native.Entries {
+		seen[entryKey(e)]--
+	}
+	var keys []string
+	for k, n := range seen {
+		if n != 0 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if seen[k] > 0 {
+			diffs = append(diffs, "only git:    "+k)
+		} else {
+			diffs = append(diffs, "only native: "+k)
+		}
+	}
+	return diffs, cliTime, nativeTime, nil
+}
+
+// runBackend implements `gits backend [check] [DIR...]`: which backend
+// reads the status, and with check, whether both agree on each DIR.
+func runBackend(status *Status, args []string) {
+	c := status.cfg.Colors
+	check := len(args) > 0 && args[0] == "check"
+	if check {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	if !check {
+		active := statusBackend
+		if !gitAvailable() && active == "cli" {
+			active = "native (no git binary on PATH)"
+		}
+		fmt.Printf("%s backend: %s%s%s\n", Icons.INFO, Bold+resolveColor(c.Branch), active, Reset)
+		if gitAvailable() {
+			version, _ := gitOutput("", "--version")
+			path, _ := exec.LookPath("git")
+			fmt.Printf("    %scli%s     %s (%s)\n", Bold, Reset, version, path)
+		} else {
+			fmt.Printf("    %scli%s     %sgit not found%s\n", Bold, Reset, resolveColor(c.Deleted), Reset)
+		}
+		for _, dir := range args {
+			if _, root, err := openNative(dir); err != nil {
+				fmt.Printf("    %snative%s  %s%v%s\n", Bold, Reset, resolveColor(c.AheadBehind), err, Reset)
+			} else {
+				fmt.Printf("    %snative%s  go-git reads %s\n", Bold, Reset, root)
+			}
+		}
+		fmt.Printf("%s(gits backend check [DIR...] compares both)%s\n", Dim, Reset)
+		return
+	}
+
+	if !gitAvailable() {
+		fmt.Printf("%s %sgits backend check needs the git binary to compare against%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), Reset)
+		exit(1)
+	}
+	failed := false
+	for _, dir := range args {
+		diffs, cliTime, nativeTime, err := compareBackends(dir)
+		timing := fmt.Sprintf("%s(git %s, native %s)%s", Dim, cliTime.Round(time.Millisecond), nativeTime.Round(time.Millisecond), Reset)
+		switch {
+		case err != nil:
+			failed = true
+			fmt.Printf("%s %s%s: %v%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), dir, err, Reset)
+		case len(diffs) == 0:
+			fmt.Printf("%s %s%s: backends agree%s %s\n", Icons.SUCCESS, resolveColor(c.UpToDate), dir, Reset, timing)
+		default:
+			failed = true
+			fmt.Printf("%s %s%s: %d difference(s)%s %s\n", Icons.WARNING, resolveColor(c.AheadBehind), dir, len(diffs), Reset, timing)
+			for _, d := range diffs {
+				fmt.Printf("    %s\n", d)
+			}
+		}
+	}
+	if failed {
+		exit(1)
+	}
+}
@@ -0,0 +1,166 @@
+// File: backend_test.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: the native and cli backends compared on generated repositories, and timed
+// License: MIT
+
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testRepo generates a repository of the shape spec, as `gits bench`
+// does, in a temporary directory away from the user's git config, and
+// returns it.  It skips the test without git.
+func testRepo(tb testing.TB, spec benchRepoSpec) string {
+	tb.Helper()
+	if !gitAvailable() {
+		tb.Skip("git is not installed")
+	}
+	home := tb.TempDir()
+	tb.Setenv("HOME", home)
+	tb.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	tb.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	tb.Setenv("GIT_EDITOR", "true")
+	dir := tb.TempDir()
+	if err := generateBenchRepo(dir, spec); err != nil {
+		tb.Fatal(err)
+	}
+	return dir
+}
+
+// testSpecs are the repositories the backends are compared on.
+var testSpecs = []struct {
+	name string
+	spec benchRepoSpec
+}{
+	{"clean", benchRepoSpec{Files: 20}},
+	{"modified", benchRepoSpec{Files: 200, Modified: 0.1}},
+	{"renamed", benchRepoSpec{Files: 200, Renames: 0.05}},
+	{"untracked", benchRepoSpec{Files: 50, Untracked: 30}},
+	{"mixed", benchRepoSpec{Files: 500, Renames: 0.02, Modified: 0.05, Untracked: 50}},
+}
+
+func TestBackendsAgree(t *testing.T) {
+	for _, tc := range testSpecs {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := testRepo(t, tc.spec)
+			diffs, _, _, err := compareBackends(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range diffs {
+				t.Error(d)
+			}
+		})
+	}
+}
+
+// testStates set up a repository of two commits on main, and a third
+// on side made from the first, in the middle of something.  A git
+// command that stops on a conflict fails, and the failure is ignored.
+var testStates = []struct {
+	name string
+	git  [][]string
+}{
+	{"upstream gone", [][]string{
+		{"remote", "add", "origin", "."},
+		{"branch", "--set-upstream-to=side"},
+		{"config", "branch.main.remote", "origin"},
+	}},
+	{"diverged", [][]string{
+		{"remote", "add", "origin", "."},
+		{"update-ref", "refs/remotes/origin/main", "side"},
+		{"branch", "--set-upstream-to=origin/main"},
+	}},
+	{"ahead", [][]string{
+		{"remote", "add", "origin", "."},
+		{"update-ref", "refs/remotes/origin/main", "main~1"},
+		{"branch", "--set-upstream-to=origin/main"},
+	}},
+	{"merge conflict", [][]string{{"merge", "side"}}},
+	{"merge concluding", [][]string{{"merge", "side"}, {"add", "-A"}}},
+	{"rebase conflict", [][]string{{"checkout", "-q", "side"}, {"rebase", "main"}}},
+	{"rebase resolved", [][]string{{"checkout", "-q", "side"}, {"rebase", "main"}, {"add", "-A"}}},
+	{"rebase apply", [][]string{{"checkout", "-q", "side"}, {"rebase", "--apply", "main"}}},
+	{"rebase edit", [][]string{{"-c", "sequence.editor=sed -i 1s/pick/edit/", "rebase", "-i", "main~1"}}},
+	{"cherry-pick", [][]string{{"cherry-pick", "side"}}},
+	{"revert", [][]string{{"revert", "--no-edit", "main~1"}}},
+	{"bisect", [][]string{{"bisect", "start"}, {"bisect", "bad"}, {"bisect", "good", "main~1"}}},
+	{"detached at tag", [][]string{{"tag", "v1", "main~1"}, {"checkout", "-q", "v1"}}},
+	{"detached from tag", [][]string{{"tag", "v1", "main~1"}, {"checkout", "-q", "v1"}, {"commit", "-q", "--allow-empty", "-m", "on v1"}}},
+	{"detached at commit", [][]string{{"checkout", "-q", "main~1"}}},
+}
+
+// TestLongStatusMatchesGit checks that the long status drawn from the
+// porcelain output reads as git's own, word for word.
+func TestLongStatusMatchesGit(t *testing.T) {
+	check := func(t *testing.T, dir string) {
+		want, err := gitRaw(dir, "-c", "color.status=never", "status")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rep := Collector{}.Report(dir)
+		if rep.Err != nil {
+			t.Fatal(rep.Err)
+		}
+		if rep.Text != want {
+			t.Errorf("long status differs from git's:\n--- gits\n%s--- git\n%s", rep.Text, want)
+		}
+	}
+	for _, tc := range testSpecs {
+		t.Run(tc.name, func(t *testing.T) {
+			check(t, testRepo(t, tc.spec))
+		})
+	}
+	for _, tc := range testStates {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := testRepo(t, benchRepoSpec{Files: 1})
+			commit := func(branch string) {
+				if _, err := benchGit(dir, "checkout", "-q", branch); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, benchPath(0)), []byte(branch+"\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := benchGit(dir, "commit", "-q", "-am", branch); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := benchGit(dir, "branch", "side"); err != nil {
+				t.Fatal(err)
+			}
+			commit("side")
+			commit("main")
+			for _, step := range tc.git {
+				benchGit(dir, step...)
+			}
+			check(t, dir)
+		})
+	}
+}
+
+// BenchmarkStatus times `gits` with each backend on the repository `gits
+// bench` generates by default, rendering without printing.
+func BenchmarkStatus(b *testing.B) {
+	dir := testRepo(b, benchRepoSpec{Files: 10000, Renames: 0.01, Modified: 0.05, Untracked: 1000})
+	for _, backend := range []string{"cli", "native"} {
+		b.Run(backend, func(b *testing.B) {
+			saved := statusBackend
+			statusBackend = backend
+			defer func() { statusBackend = saved }()
+			r := newANSIRenderer(io.Discard, DefaultConfig())
+			for b.Loop() {
+				rep := Collector{}.Report(dir)
+				if rep.Err != nil {
+					b.Fatal(rep.Err)
+				}
+				r.RenderStatus(rep)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+// File: benchrepo.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: `gits bench`: generate a synthetic repository and time the status of both backends on it
+// License: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// benchRepoSpec is the shape of a generated repository.
+type benchRepoSpec struct {
+	Files     int     `json:"files"`     // committed files
+	Renames   float64 `json:"renames"`   // share of them renamed and staged
+	Modified  float64 `json:"modified"`  // share of them changed and not staged
+	Untracked int     `json:"untracked"` // files never added
+}
+
+// benchLatency is how long the status of the generated repository took with
+// one backend, over all runs.
+type benchLatency struct {
+	MinMS    float64 `json:"min_ms"`
+	MedianMS float64 `json:"median_ms"`
+	MaxMS    float64 `json:"max_ms"`
+}
+
+// benchRepoResult is what `gits bench --json` prints and --baseline
+// reads back.
+type benchRepoResult struct {
+	Spec     benchRepoSpec           `json:"spec"`
+	Runs     int                     `json:"runs"`
+	Git      string                  `json:"git"`
+	Backends map[string]benchLatency `json:"backends"`
+	Diffs    []string                `json:"diffs,omitempty"` // where the backends disagree
+}
+
+// benchPath is the name of the i-th generated file, a hundred to a
+// directory.
+func benchPath(i int) string {
+	return filepath.Join("pkg"+strconv.Itoa(i/100), "file"+strconv.Itoa(i)+".txt")
+}
+
+// benchGit runs git in dir as the bench's own user, without hooks or
+// signing, whatever the user's config says.
+func benchGit(dir string, args ...string) (string, error) {
+	base := []string{"-c", "user.name=gits bench", "-c", "user.email=bench@gits.invalid",
+		"-c", "commit.gpgsign=false", "-c", "core.hooksPath=" + os.DevNull}
+	return gitOutput(dir, append(base, args...)...)
+}
+
+// generateBenchRepo creates a repository in dir with spec.Files committed
+// files, then renames and stages a share of them, changes another share
+// without staging it and adds spec.Untracked untracked files.
+func generateBenchRepo(dir string, spec benchRepoSpec) error {
+	write := func(name, content string) error {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(content), 0o644)
+	}
+	git := func(args ...string) error {
+		_, err := benchGit(dir, args...)
+		return err
+	}
+
+	if err := git("init", "-q", "-b", "main"); err != nil {
+		return err
+	}
+	for i := 0; i < spec.Files; i++ {
+		if err := write(benchPath(i), fmt.Sprintf("file %d\nsome content that stays the same\n", i)); err != nil {
+			return err
+		}
+	}
+	if err := git("add", "-A"); err != nil {
+		return err
+	}
+	if err := git("commit", "-q", "--allow-empty", "-m", "synthetic tree"); err != nil {
+		return err
+	}
+
+	// Spread the renamed and modified files over the whole tree.
+	renames := int(float64(spec.Files) * spec.Renames)
+	for n := 0; n < renames; n++ {
+		i := n * spec.Files / renames
+		to := filepath.Join(dir, "moved", benchPath(i))
+		if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+			return err
+		}
+		if err := os.Rename(filepath.Join(dir, benchPath(i)), to); err != nil {
+			return err
+		}
+	}
+	if renames > 0 {
+		if err := git("add", "-A"); err != nil {
+			return err
+		}
+	}
+	modified := int(float64(spec.Files) * spec.Modified)
+	for n := 0; n < modified; n++ {
+		// Offset by one so renamed files are left alone.
+		i := n*spec.Files/modified + 1
+		if i >= spec.Files || IsFile(filepath.Join(dir, "moved", benchPath(i))) {
+			continue
+		}
+		if err := write(benchPath(i), fmt.Sprintf("file %d\nchanged\n", i)); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < spec.Untracked; i++ {
+		if err := write(filepath.Join("noise"+strconv.Itoa(i/100), "untracked"+strconv.Itoa(i)+".tmp"), "noise\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// timeBackend renders the status of dir with backend the way `gits` does,
+// without printing it, once to warm up and then runs times.
+func timeBackend(status *Status, dir, backend string, runs int) (benchLatency, error) {
+	saved := statusBackend
+	statusBackend = backend
+	defer func() { statusBackend = saved }()
+
+	var took []time.Duration
+	for n := 0; n <= runs; n++ {
+		start := time.Now()
+		rep := Collector{}.Report(dir)
+		if rep.Err != nil {
+			return benchLatency{}, rep.Err
+		}
+		newANSIRenderer(io.Discard, status.cfg).RenderStatus(rep)
+		if n > 0 {
+			took = append(took, time.Since(start))
+		}
+	}
+	slices.Sort(took)
+	ms := func(d time.Duration) float64 { return float64(d.Microseconds()) / 1000 }
+	return benchLatency{MinMS: ms(took[0]), MedianMS: ms(took[len(took)/2]), MaxMS: ms(took[len(took)-1])}, nil
+}
+
+// runBench implements `gits bench [--files N] [--renames R] [--modified R]
+// [--untracked N] [--runs K] [--keep DIR] [--json] [--baseline FILE
+// [--tolerance PCT]]`: it generates a repository, times
+// `gits` on it with each backend and, given the JSON of an earlier run,
+// fails when a backend has become slower than the tolerance allows.
+func runBench(status *Status, args []string) {
+	c := status.cfg.Colors
+	spec := benchRepoSpec{Files: 10000, Renames: 0.01, Modified: 0.05, Untracked: -1}
+	runs, tolerance := 5, 25.0
+	keep, baseline := "", ""
+	asJSON := false
+	for i := 0; i < len(args); i++ {
+		next := func() string {
+			if i+1 < len(args) {
+				i++
+				return args[i]
+			}
+			return ""
+		}
+		switch args[i] {
+		case "--files":
+			spec.Files, _ = strconv.Atoi(next())
+		case "--renames":
+			spec.Renames, _ = strconv.ParseFloat(next(), 64)
+		case "--modified":
+			spec.Modified, _ = strconv.ParseFloat(next(), 64)
+		case "--untracked":
+			spec.Untracked, _ = strconv.Atoi(next())
+		case "--runs":
+			runs, _ = strconv.Atoi(next())
+		case "--keep":
+			keep = next()
+		case "--baseline":
+			baseline = next()
+		case "--tolerance":
+			tolerance, _ = strconv.ParseFloat(next(), 64)
+		case "--json":
+			asJSON = true
+		}
+	}
+	spec.Files = max(spec.Files, 1)
+	spec.Renames = min(max(spec.Renames, 0), 1)
+	spec.Modified = min(max(spec.Modified, 0), 1)
+	if spec.Untracked < 0 {
+		spec.Untracked = spec.Files / 10
+	}
+	runs = max(runs, 1)
+	tmp := ""
+	fail := func(err error) {
+		if tmp != "" {
+			os.RemoveAll(tmp)
+		}
+		exitWithError(err, c)
+	}
+	if !gitAvailable() {
+		fail(fmt.Errorf("gits bench needs the git binary to build the repository"))
+	}
+
+	var base *benchRepoResult
+	if baseline != "" {
+		data, err := os.ReadFile(baseline)
+		if err != nil {
+			fail(err)
+		}
+		base = &benchRepoResult{}
+		if err := json.Unmarshal(data, base); err != nil {
+			fail(fmt.Errorf("%s: %w", baseline, err))
+		}
+	}
+
+	dir := keep
+	if dir == "" {
+		var err error
+		if tmp, err = os.MkdirTemp("", "gits-bench-"); err != nil {
+			fail(err)
+		}
+		defer os.RemoveAll(tmp)
+		dir = tmp
+	} else if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		fail(fmt.Errorf("%s is not empty", dir))
+	} else if err := os.MkdirAll(dir, 0o755); err != nil {
+		fail(err)
+	}
+
+	if !asJSON {
+		fmt.Printf("%s generating %s%d files%s (%.0f%% renamed, %.0f%% modified, %d untracked) in %s\n", Icons.INFO,
+			Bold, spec.Files, Reset, spec.Renames*100, spec.Modified*100, spec.Untracked, dir)
+	}
+	start := time.Now()
+	if err := generateBenchRepo(dir, spec); err != nil {
+		fail(err)
+	}
+	if !asJSON {
+		fmt.Printf("    %sgenerated in %s%s\n", Dim, time.Since(start).Round(time.Millisecond), Reset)
+	}
+
+	version, _ := gitOutput("", "--version")
+	result := benchRepoResult{Spec: spec, Runs: runs, Git: version, Backends: map[string]benchLatency{}}
+	for _, backend := range []string{"cli", "native"} {
+		lat, err := timeBackend(status, dir, backend, runs)
+		if err != nil {
+			fail(fmt.Errorf("%s: %w", backend, err))
+		}
+		result.Backends[backend] = lat
+	}
+	if diffs, _, _, err := compareBackends(dir); err == nil {
+		result.Diffs = diffs
+	}
+
+	// Regressions against the baseline, when its repository had the same
+	// shape.
+	var slower []string
+	if base != nil {
+		if base.Spec != spec {
+			fail(fmt.Errorf("%s was measured on a different repository (%+v)", baseline, base.Spec))
+		}
+		for _, backend := range []string{"cli", "native"} {
+			was, ok := base.Backends[backend]
+			now := result.Backends[backend]
+			if ok && now.MedianMS > was.MedianMS*(1+tolerance/100) {
+				slower = append(slower, fmt.Sprintf("%s: median %.1fms, was %.1fms", backend, now.MedianMS, was.MedianMS))
+			}
+		}
+	}
+
+	if asJSON {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		for _, backend := range []string{"cli", "native"} {
+			lat := result.Backends[backend]
+			fmt.Printf("    %s%-6s%s  median %7.1fms  min %7.1fms  max %7.1fms  %s(%d runs)%s\n", Bold, backend, Reset,
+				lat.MedianMS, lat.MinMS, lat.MaxMS, Dim, runs, Reset)
+		}
+		if len(result.Diffs) > 0 {
+			fmt.Printf("%s %sthe backends disagree on %d path(s) or field(s)%s\n", Icons.WARNING, resolveColor(c.AheadBehind), len(result.Diffs), Reset)
+		}
+		for _, s := range slower {
+			fmt.Printf("%s %s%s, over the %.0f%% tolerance%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), s, tolerance, Reset)
+		}
+		if base != nil && len(slower) == 0 {
+			fmt.Printf("%s %swithin %.0f%% of %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), tolerance, baseline, Reset)
+		}
+	}
+	if len(slower) > 0 {
+		if tmp != "" {
+			os.RemoveAll(tmp)
+		}
+		exit(1)
+	}
+}
@@ -0,0 +1,185 @@
+// File: bisect.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: guided bisect with progress and culprit summary (`gits bisect`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// bisectProgress matches git's "Bisecting: N revisions left to test after
+// this (roughly M steps)" line.
+var bisectProgress = regexp.MustCompile(`Bisecting: (\d+) revisions? left to test after this \(roughly (\d+) steps?\)`)
+
+// bisectCulprit matches "<sha> is the first bad commit".
+var bisectCulprit = regexp.MustCompile(`(?m)^([0-9a-f]{7,}) is the first bad commit`)
+
+// inBisect reports whether a bisect session is running in root.
+func inBisect(root string) bool {
+	path, err := gitOutput(root, "rev-parse", "--git-path", "BISECT_START")
+	if err != nil {
+		return false
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	return IsFile(path)
+}
+
+// bisectStep runs one `git bisect` subcommand and reports where the search
+// stands.  It returns the culprit commit once git has found it.
+func bisectStep(status *Status, root string, args ...string) (culprit string) {
+	c := status.cfg.Colors
+	out, err := gitCmd(root, append([]string{"bisect"}, args...)...).CombinedOutput()
+	text := string(out)
+	if m := bisectCulprit.FindStringSubmatch(text); m != nil {
+		return m[1]
+	}
+	if err != nil {
+		fmt.Printf("%s %sgit bisect %s: %s%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), args[0], strings.TrimSpace(text), Reset)
+		exit(1)
+	}
+	if m := bisectProgress.FindStringSubmatch(text); m != nil {
+		fmt.Printf("%s %sTesting%s %s%s revision(s) left, about %s step(s)%s\n", Icons.GIT, Bold+resolveColor(c.Header), Reset,
+			resolveColor(c.AheadBehind), m[1], m[2], Reset)
+		printBisectCommit(root, c)
+	} else if strings.Contains(text, "There are only 'skip'ped commits left") {
+		fmt.Printf("%s %sOnly skipped commits are left; the first bad commit is one of:%s\n", Icons.WARNING, resolveColor(c.AheadBehind), Reset)
+		fmt.Println(strings.TrimSpace(text))
+		exit(1)
+	} else if s := strings.TrimSpace(text); s != "" {
+		fmt.Printf("   %s%s%s\n", Dim, s, Reset)
+	}
+	return ""
+}
+
+// printBisectCommit shows the commit currently checked out for testing.
+func printBisectCommit(root string, c ColorConfig) {
+	out, err := gitOutput(root, "log", "-1", "--format=%h%x00%an%x00%ar%x00%s")
+	if err != nil {
+		return
+	}
+	f := strings.SplitN(out, "\x00", 4)
+	if len(f) < 4 {
+		return
+	}
+	fmt.Printf("   %s%s%s %s%s%s %s(%s)%s %s\n", Bold+resolveColor(c.AheadBehind), f[0], Reset,
+		authorColor(f[1], lanePalette(c)), f[1], Reset, Dim, f[2], Reset, f[3])
+}
+
+// printCulprit summarizes the first bad commit.
+func printCulprit(status *Status, sha string) {
+	c := status.cfg.Colors
+	fmt.Printf("\n%s %sFirst bad commit found%s\n\n", Icons.SUCCESS, Bold+resolveColor(c.Deleted), Reset)
+	runShow(status, []string{sha, "--stat"})
+	fmt.Printf("\n%s Finish with %sgits bisect reset%s\n", Icons.INFO, Bold, Reset)
+}
+
+// runBisect implements `gits bisect start BAD GOOD... [--run CMD]`,
+// `gits bisect good|bad|skip [REV]`, `gits bisect run CMD` and
+// `gits bisect reset`.
+func runBisect(status *Status, args []string) {
+	c := status.cfg.Colors
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	if len(args) == 0 {
+		if !inBisect(root) {
+			fail(fmt.Errorf("usage: gits bisect start BAD GOOD... [--run CMD] | good | bad | skip | run CMD | reset"))
+		}
+		log, _ := gitOutput(root, "bisect", "log")
+		good, bad := strings.Count(log, "\n# good:"), strings.Count(log, "\n# bad:")
+		fmt.Printf("%s %sBisect in progress%s %s(%d good, %d bad marked)%s\n", Icons.GIT, Bold+resolveColor(c.Header), Reset, Dim, good, bad, Reset)
+		printBisectCommit(root, c)
+		return
+	}
+
+	action, rest := args[0], args[1:]
+	var runCmd string
+	for i, a := range rest {
+		if a == "--run" {
+			runCmd = strings.Join(rest[i+1:], " ")
+			rest = rest[:i]
+			break
+		}
+	}
+	if action == "run" {
+		runCmd, rest = strings.Join(rest, " "), nil
+	}
+
+	var culprit string
+	switch action {
+	case "start":
+		if len(rest) < 2 {
+			fail(fmt.Errorf("usage: gits bisect start BAD GOOD... [--run CMD]"))
+		}
+		culprit = bisectStep(status, root, append([]string{"start"}, rest...)...)
+	case "good", "bad", "skip", "old", "new":
+		if !inBisect(root) {
+			fail(fmt.Errorf("no bisect in progress (gits bisect start BAD GOOD)"))
+		}
+		culprit = bisectStep(status, root, append([]string{action}, rest...)...)
+	case "run":
+		if runCmd == "" {
+			fail(fmt.Errorf("usage: gits bisect run CMD"))
+		}
+		if !inBisect(root) {
+			fail(fmt.Errorf("no bisect in progress (gits bisect start BAD GOOD)"))
+		}
+	case "reset":
+		out, err := gitCmd(root, append([]string{"bisect", "reset"}, rest...)...).CombinedOutput()
+		if err != nil {
+			fail(fmt.Errorf("git bisect reset: %s", strings.TrimSpace(string(out))))
+		}
+		lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+		fmt.Printf("%s %sBisect finished%s %s%s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset, Dim, lines[len(lines)-1], Reset)
+		return
+	default:
+		fail(fmt.Errorf("unknown bisect action %q (start, good, bad, skip, run, reset)", action))
+	}
+
+	// Automation: like `git bisect run`, exit 0 is good, 125 skips, 1–127
+	// is bad and anything else stops, but each step is reported as it goes.
+	for culprit == "" && runCmd != "" {
+		fmt.Printf("   %s$ %s%s\n", Dim, runCmd, Reset)
+		cmd := exec.Command("sh", "-c", runCmd)
+		cmd.Dir = root
+		cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+		verdict := "good"
+		if err := cmd.Run(); err != nil {
+			code := -1
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				code = exitErr.ExitCode()
+			}
+			switch {
+			case code == 125:
+				verdict = "skip"
+			case code > 0 && code < 128:
+				verdict = "bad"
+			default:
+				fail(fmt.Errorf("%s stopped the bisect (%v); continue by hand or gits bisect reset", runCmd, err))
+			}
+		}
+		style := resolveColor(c.UpToDate)
+		if verdict != "good" {
+			style = Bold + resolveColor(c.Deleted)
+		}
+		fmt.Printf("   %s→ %s%s\n", style, verdict, Reset)
+		culprit = bisectStep(status, root, verdict)
+	}
+	if culprit != "" {
+		printCulprit(status, culprit)
+	}
+}
@@ -0,0 +1,136 @@
+// File: blame.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: aligned, age-colored blame (`gits blame`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// blameHeat runs from the most recent change (hot) to the oldest (cold).
+var blameHeat = []string{"#ff4444", "#ff8844", "#ffbb44", "#eedd55", "#aadd66", "#66cc99", "#55aacc", "#5577bb", "#777799"}
+
+// blameLine is one line of `git blame --line-porcelain`.
+type blameLine struct {
+	Sha     string
+	Author  string
+	Time    time.Time
+	Summary string
+	Line    int
+	Text    string
+}
+
+// parseBlame parses `git blame --line-porcelain` output.
+func parseBlame(out string) []blameLine {
+	var lines []blameLine
+	var cur blameLine
+	expectHeader := true
+	for _, l := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(l, "\t"):
+			cur.Text = l[1:]
+			lines = append(lines, cur)
+			expectHeader = true
+		case expectHeader:
+			f := strings.Fields(l)
+			if len(f) >= 3 {
+				cur = blameLine{Sha: f[0]}
+				cur.Line, _ = strconv.Atoi(f[2])
+				expectHeader = false
+			}
+		case strings.HasPrefix(l, "author "):
+			cur.Author = l[len("author "):]
+		case strings.HasPrefix(l, "author-time "):
+			if ts, err := strconv.ParseInt(l[len("author-time "):], 10, 64); err == nil {
+				cur.Time = time.Unix(ts, 0)
+			}
+		case strings.HasPrefix(l, "summary "):
+			cur.Summary = l[len("summary "):]
+		}
+	}
+	return lines
+}
+
+// runBlame implements `gits blame [-L START,END] FILE`.
+func runBlame(status *Status, args []string) {
+	c := status.cfg.Colors
+	var file, lineRange string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "-L" && i+1 < len(args):
+			i++
+			lineRange = args[i]
+		case strings.HasPrefix(args[i], "-L"):
+			lineRange = args[i][2:]
+		default:
+			file = args[i]
+		}
+	}
+	if file == "" {
+		fmt.Printf("%s %susage: gits blame [-L START,END] FILE%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), Reset)
+		exit(1)
+	}
+
+	gitArgs := []string{"blame", "--line-porcelain"}
+	if lineRange != "" {
+		gitArgs = append(gitArgs, "-L", lineRange)
+	}
+	out, err := gitRaw(".", append(gitArgs, "--", file)...)
+	if err != nil {
+		exitWithError(err, c)
+	}
+	lines := parseBlame(out)
+	if len(lines) == 0 {
+		return
+	}
+
+	// Heat is relative to the age span of the shown lines.
+	oldest, newest := lines[0].Time, lines[0].Time
+	authorWidth, numWidth := 0, len(strconv.Itoa(lines[len(lines)-1].Line))
+	for _, l := range lines {
+		if l.Time.Before(oldest) {
+			oldest = l.Time
+		}
+		if l.Time.After(newest) {
+			newest = l.Time
+		}
+		authorWidth = max(authorWidth, min(stringCells(l.Author), 18))
+	}
+	heat := func(t time.Time) string {
+		span := newest.Sub(oldest)
+		if span <= 0 {
+			return resolveColor(blameHeat[0])
+		}
+		i := int(float64(newest.Sub(t)) / float64(span) * float64(len(blameHeat)-1))
+		return resolveColor(blameHeat[i])
+	}
+
+	web := repoWebURL(".")
+	var sb strings.Builder
+	prev := ""
+	for _, l := range lines {
+		uncommitted := strings.Trim(l.Sha, "0") == ""
+		meta := strings.Repeat(" ", 8+2+authorWidth+2+10)
+		if l.Sha != prev {
+			if uncommitted {
+				meta = fmt.Sprintf("%s%-*s%s", Bold+resolveColor(c.Modified), 8+2+authorWidth+2+10, "Not committed yet", Reset)
+			} else {
+				style := heat(l.Time)
+				author := truncateCells(l.Author, authorWidth)
+				meta = fmt.Sprintf("%s%s%s  %s%s%s  %s%s%s",
+					Bold+style, hyperlink(commitWebURL(web, l.Sha), l.Sha[:8]), Reset,
+					style, padCells(author, authorWidth, false), Reset,
+					Dim, l.Time.Format("2006-01-02"), Reset)
+			}
+		}
+		prev = l.Sha
+		fmt.Fprintf(&sb, "%s %s%*d%s │ %s\n", meta, Dim, numWidth, l.Line, Reset, l.Text)
+	}
+	fmt.Print(sb.String())
+}
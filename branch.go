@@ -0,0 +1,296 @@
+// File: branch.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: branch overview and merged-branch cleanup (`gits branch`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// branchInfo describes one local or remote-tracking branch.
+type branchInfo struct {
+	Name       string
+	Remote     bool
+	Current    bool
+	Upstream   string
+	Ahead      int
+	Behind     int
+	Gone       bool // upstream configured but deleted on the remote
+	LastCommit time.Time
+	Subject    string
+	Merged     bool
+}
+
+// parseTrack parses %(upstream:track,nobracket): "ahead 1, behind 2" or "gone".
+func parseTrack(track string) (ahead, behind int, gone bool) {
+	if track == "gone" {
+		return 0, 0, true
+	}
+	for _, part := range strings.Split(track, ", ") {
+		if n, ok := strings.CutPrefix(part, "ahead "); ok {
+			ahead, _ = strconv.Atoi(n)
+		} else if n, ok := strings.CutPrefix(part, "behind "); ok {
+			behind, _ = strconv.Atoi(n)
+		}
+	}
+	return ahead, behind, false
+}
+
+// mergeBase returns the ref branches are checked against for "merged": the
+// remote's default branch when known (origin/HEAD), otherwise HEAD.
+func mergeBase(dir string) string {
+	if ref, err := gitOutput(dir, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD"); err == nil && ref != "" {
+		return ref
+	}
+	return "HEAD"
+}
+
+// listBranches returns local and remote-tracking branches, most recently
+// committed first, with merged flags relative to base.
+func listBranches(dir, base string) ([]branchInfo, error) {
+	format := strings.Join([]string{
+		"%(refname)", "%(refname:short)", "%(HEAD)", "%(upstream:short)",
+		"%(upstream:track,nobracket)", "%(committerdate:unix)", "%(subject)",
+	}, "%00")
+	out, err := gitOutput(dir, "for-each-ref", "--sort=-committerdate", "--format="+format, "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, err
+	}
+	merged := map[string]bool{}
+	if list, err := gitOutput(dir, "for-each-ref", "--merged="+base, "--format=%(refname)", "refs/heads", "refs/remotes"); err == nil {
+		for _, ref := range strings.Split(list, "\n") {
+			merged[ref] = true
+		}
+	}
+
+	var branches []branchInfo
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.Split(line, "\x00")
+		if len(f) < 7 || strings.HasSuffix(f[0], "/HEAD") {
+			continue
+		}
+		b := branchInfo{
+			Name:     f[1],
+			Remote:   strings.HasPrefix(f[0], "refs/remotes/"),
+			Current:  f[2] == "*",
+			Upstream: f[3],
+			Subject:  f[6],
+			Merged:   merged[f[0]],
+		}
+		b.Ahead, b.Behind, b.Gone = parseTrack(f[4])
+		if ts, err := strconv.ParseInt(f[5], 10, 64); err == nil {
+			b.LastCommit = time.Unix(ts, 0)
+		}
+		branches = append(branches, b)
+	}
+	return branches, nil
+}
+
+// isProtectedBranch reports whether name is never deleted by --delete-merged.
+func isProtectedBranch(name, base string) bool {
+	switch name {
+	case "main", "master", "develop", "trunk":
+		return true
+	}
+	return strings.HasSuffix(base, "/"+name) || name == base
+}
+
+// runBranch implements `gits branch [--local] [--stale DAYS] [--plain]
+// [--delete-merged [--dry-run] [--yes]] [path]`, also run as `gits
+// branches`.
+func runBranch(status *Status, args []string) {
+	c := status.cfg.Colors
+	dir := "."
+	localOnly, deleteMerged, dryRun, yes, plain := false, false, false, false, false
+	staleDays := 90
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--local", "-l":
+			localOnly = true
+		case "--delete-merged":
+			deleteMerged = true
+		case "--dry-run":
+			dryRun = true
+		case "--yes", "-y":
+			yes = true
+		case "--plain":
+			plain = true
+		case "--stale":
+			if i+1 < len(args) {
+				i++
+				if n, err := strconv.Atoi(args[i]); err == nil {
+					staleDays = n
+				}
+			}
+		default:
+			dir = args[i]
+		}
+	}
+
+	base := mergeBase(dir)
+	branches, err := listBranches(dir, base)
+	if err != nil {
+		exitWithError(err, c)
+	}
+
+	if deleteMerged {
+		deleteMergedBranches(status, dir, base, branches, dryRun, yes)
+		return
+	}
+
+	// --plain: names only, for fzf and scripts.
+	if plain {
+		var sb strings.Builder
+		for _, remote := range []bool{false, true} {
+			for _, b := range branches {
+				if b.Remote == remote && (!remote || !localOnly) {
+					sb.WriteString(b.Name + "\n")
+				}
+			}
+		}
+		fmt.Print(sb.String())
+		return
+	}
+
+	staleBefore := time.Now().AddDate(0, 0, -staleDays)
+	printed := false
+	render := func(title string, remote bool) {
+		var rows [][]dashCell
+		for _, b := range branches {
+			if b.Remote != remote {
+				continue
+			}
+			row := make([]dashCell, 6)
+			row[0] = dashCell{" ", ""}
+			if b.Current {
+				row[0] = dashCell{"*", Bold + resolveColor(c.Staged)}
+			}
+			row[1] = dashCell{b.Name, Bold + resolveColor(c.Branch)}
+			if remote {
+				row[1].style = resolveColor(c.RemoteURL)
+			}
+
+			switch {
+			case b.Gone:
+				row[2] = dashCell{b.Upstream + " (gone)", resolveColor(c.Deleted)}
+			case b.Upstream != "":
+				row[2] = dashCell{b.Upstream, Dim}
+			default:
+				row[2] = dashCell{"", ""}
+			}
+			ab := ""
+			if b.Ahead > 0 {
+				ab += "↑" + strconv.Itoa(b.Ahead)
+			}
+			if b.Behind > 0 {
+				ab += "↓" + strconv.Itoa(b.Behind)
+			}
+			if ab == "" && b.Upstream != "" && !b.Gone {
+				row[3] = dashCell{"=", Dim}
+			} else {
+				row[3] = dashCell{ab, Bold + resolveColor(c.AheadBehind)}
+			}
+
+			var marks []string
+			if b.Merged && !b.Current {
+				marks = append(marks, Dim+"merged"+Reset)
+			}
+			if !b.LastCommit.IsZero() && b.LastCommit.Before(staleBefore) {
+				marks = append(marks, Bold+resolveColor(c.Deleted)+"stale"+Reset)
+			}
+			row[4] = dashCell{shortAge(b.LastCommit), Dim}
+			subject := b.Subject
+			if len(marks) > 0 {
+				subject = "[" + strings.Join(marks, ", ") + "] " + subject
+			}
+			row[5] = dashCell{subject, ""}
+			rows = append(rows, row)
+		}
+		if len(rows) == 0 {
+			return
+		}
+		if printed {
+			fmt.Println()
+		}
+		printed = true
+
+		fmt.Printf("%s %s%s%s\n", Icons.GIT, Bold+resolveColor(c.Header), title, Reset)
+		widths := make([]int, 5)
+		for _, row := range rows {
+			for i := 0; i < 5; i++ {
+				widths[i] = max(widths[i], stringCells(row[i].text))
+			}
+		}
+		for _, row := range rows {
+			var sb strings.Builder
+			sb.WriteString("  ")
+			for i := 0; i < 5; i++ {
+				sb.WriteString(padCell(row[i], widths[i], i == 3))
+				sb.WriteString("  ")
+			}
+			sb.WriteString(row[5].text)
+			fmt.Println(strings.TrimRight(sb.String(), " "))
+		}
+	}
+
+	render("Local branches", false)
+	if !localOnly {
+		render("Remote branches", true)
+	}
+	fmt.Printf("\n%s merged = contained in %s%s%s; stale = no commit for %d days\n",
+		Icons.INFO, Bold+resolveColor(c.Branch), base, Reset, staleDays)
+}
+
+// deleteMergedBranches removes local branches already merged into base,
+// sparing the current branch and the usual mainline names.
+func deleteMergedBranches(status *Status, dir, base string, branches []branchInfo, dryRun, yes bool) {
+	c := status.cfg.Colors
+	var victims []string
+	for _, b := range branches {
+		if !b.Remote && b.Merged && !b.Current && !isProtectedBranch(b.Name, base) {
+			victims = append(victims, b.Name)
+		}
+	}
+	if len(victims) == 0 {
+		fmt.Printf("%s %sNo merged branches to delete%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+		return
+	}
+
+	fmt.Printf("%s Branches merged into %s%s%s:\n", Icons.INFO, Bold+resolveColor(c.Branch), base, Reset)
+	for _, name := range victims {
+		fmt.Printf("    %s%s%s\n", resolveColor(c.Deleted), name, Reset)
+	}
+	if dryRun {
+		return
+	}
+	if !yes {
+		ok, err := confirm(fmt.Sprintf("Delete %d branch(es)?", len(victims)))
+		if err != nil {
+			fmt.Printf("%s %s%v (use --yes)%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), err, Reset)
+			exit(1)
+		}
+		if !ok {
+			return
+		}
+	}
+
+	failed := false
+	for _, name := range victims {
+		// -d (not -D): git refuses if the branch is somehow not merged after all.
+		if _, err := gitOutput(dir, "branch", "-d", name); err != nil {
+			failed = true
+			fmt.Printf("%s %s%s: %v%s\n", Icons.ERROR, resolveColor(c.Deleted), name, err, Reset)
+			continue
+		}
+		fmt.Printf("%s deleted %s\n", Icons.SUCCESS, name)
+	}
+	if failed {
+		exit(1)
+	}
+}
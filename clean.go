@@ -0,0 +1,224 @@
+// File: clean.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: previewed, confirmable `git clean` with optional trash (`gits clean`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cleanEntry is one path `git clean` would remove.
+type cleanEntry struct {
+	Path    string // relative to the current directory; directories end in /
+	Size    int64
+	Ignored bool
+}
+
+// humanSize formats a byte count ("512 B", "1.4 MiB").
+func humanSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// pathSize returns the total size of the files under p.
+func pathSize(p string) int64 {
+	var total int64
+	filepath.WalkDir(p, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total
+}
+
+// cleanCandidates asks git which paths a clean would remove.  ignoredToo
+// mirrors -x; ignored paths are flagged so they can be grouped.
+func cleanCandidates(ignoredToo bool) ([]cleanEntry, error) {
+	list := func(flag string) ([]string, error) {
+		out, err := gitOutput(".", "-c", "core.quotePath=false", "clean", flag)
+		if err != nil {
+			return nil, err
+		}
+		var paths []string
+		for _, line := range strings.Split(out, "\n") {
+			p, ok := strings.CutPrefix(line, "Would remove ")
+			if !ok {
+				continue
+			}
+			paths = append(paths, unquoteGitPath(p))
+		}
+		return paths, nil
+	}
+
+	untracked, err := list("-nd")
+	if err != nil {
+		return nil, err
+	}
+	var entries []cleanEntry
+	for _, p := range untracked {
+		entries = append(entries, cleanEntry{Path: p})
+	}
+	if ignoredToo {
+		ignored, err := list("-ndX")
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range ignored {
+			entries = append(entries, cleanEntry{Path: p, Ignored: true})
+		}
+	}
+	parallelEach(len(entries), func(i int) {
+		entries[i].Size = pathSize(entries[i].Path)
+	})
+	return entries, nil
+}
+
+// moveToTrash moves the entries into dir, keeping their relative layout.
+func moveToTrash(entries []cleanEntry, dir string) []error {
+	var errs []error
+	for _, e := range entries {
+		rel := strings.TrimSuffix(e.Path, "/")
+		dst := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := os.Rename(rel, dst); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", rel, err))
+		}
+	}
+	return errs
+}
+
+// runClean implements `gits clean [--untracked-only] [--force] [--trash [DIR]]`.
+func runClean(status *Status, args []string) {
+	c := status.cfg.Colors
+	force, ignoredToo, useTrash := false, true, false
+	trashDir := ""
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-f", "--force":
+			force = true
+		case "--untracked-only":
+			ignoredToo = false
+		case "--trash":
+			useTrash = true
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				i++
+				trashDir = expandHome(args[i])
+			}
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	entries, err := cleanCandidates(ignoredToo)
+	if err != nil {
+		fail(err)
+	}
+	if len(entries) == 0 {
+		fmt.Printf("%s %sNothing to clean%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+		return
+	}
+
+	var total int64
+	groups := []struct {
+		title   string
+		ignored bool
+		style   string
+	}{
+		{"Untracked", false, Bold + resolveColor(c.Untracked)},
+		{"Ignored (build output, caches, local config)", true, Dim},
+	}
+	for _, g := range groups {
+		var group []cleanEntry
+		var size int64
+		for _, e := range entries {
+			if e.Ignored == g.ignored {
+				group = append(group, e)
+				size += e.Size
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+		total += size
+		sort.SliceStable(group, func(i, j int) bool { return group[i].Size > group[j].Size })
+		fmt.Printf("%s %s%s%s %s(%d, %s)%s\n", Icons.FOLDER, Bold+resolveColor(c.Header), g.title, Reset,
+			Dim, len(group), humanSize(size), Reset)
+		for _, e := range group {
+			icon := getFileEmoji(e.Path)
+			if strings.HasSuffix(e.Path, "/") {
+				icon = getDirEmoji()
+			}
+			fmt.Printf("    %10s  %s %s%s%s\n", humanSize(e.Size), icon, g.style, e.Path, Reset)
+		}
+	}
+
+	if useTrash && trashDir == "" {
+		gitDir, err := gitOutput(".", "rev-parse", "--absolute-git-dir")
+		if err != nil {
+			fail(err)
+		}
+		// Inside .git: same filesystem (so a rename is enough) and never cleaned.
+		trashDir = filepath.Join(gitDir, "gits-trash", time.Now().Format("20060102-150405"))
+	}
+	verb := "Delete"
+	if useTrash {
+		verb = "Move to " + displayPath(trashDir)
+	}
+	question := fmt.Sprintf("%s %d path(s), %s?", verb, len(entries), humanSize(total))
+	if !force {
+		ok, err := confirm(question)
+		if err != nil {
+			fail(fmt.Errorf("%v (use --force)", err))
+		}
+		if !ok {
+			return
+		}
+	}
+
+	if useTrash {
+		if errs := moveToTrash(entries, trashDir); len(errs) > 0 {
+			for _, err := range errs {
+				fmt.Printf("%s %s%v%s\n", Icons.ERROR, resolveColor(c.Deleted), err, Reset)
+			}
+			exit(1)
+		}
+		fmt.Printf("%s %sMoved %d path(s) to %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), len(entries), trashDir, Reset)
+		return
+	}
+	failed := false
+	for _, e := range entries {
+		if err := os.RemoveAll(strings.TrimSuffix(e.Path, "/")); err != nil {
+			failed = true
+			fmt.Printf("%s %s%v%s\n", Icons.ERROR, resolveColor(c.Deleted), err, Reset)
+		}
+	}
+	if failed {
+		exit(1)
+	}
+	fmt.Printf("%s %sRemoved %d path(s), %s freed%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), len(entries), humanSize(total), Reset)
+}
@@ -0,0 +1,130 @@
+// File: clipboard.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: copying to the system clipboard, with OSC 52 for SSH sessions (`gits --copy`)
+// License: MIT
+
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardTools are the programs tried in order to set the clipboard,
+// with the condition under which each can work.
+var clipboardTools = []struct {
+	name string
+	args []string
+	ok   func() bool
+}{
+	{"pbcopy", nil, func() bool { return runtime.GOOS == "darwin" }},
+	{"clip.exe", nil, func() bool { return runtime.GOOS == "windows" || os.Getenv("WSL_DISTRO_NAME") != "" }},
+	{"wl-copy", nil, func() bool { return os.Getenv("WAYLAND_DISPLAY") != "" }},
+	{"xclip", []string{"-selection", "clipboard"}, func() bool { return os.Getenv("DISPLAY") != "" }},
+	{"xsel", []string{"--clipboard", "--input"}, func() bool { return os.Getenv("DISPLAY") != "" }},
+}
+
+// copyToClipboard puts text on the clipboard and says how.  Over SSH the
+// local tools would fill the remote machine's clipboard, so the terminal is
+// asked to do it with OSC 52 instead; that is also the fallback when no
+// tool works.
+func copyToClipboard(text string) (string, error) {
+	if os.Getenv("SSH_TTY") == "" && os.Getenv("SSH_CONNECTION") == "" {
+		for _, t := range clipboardTools {
+			if !t.ok() {
+				continue
+			}
+			path, err := exec.LookPath(t.name)
+			if err != nil {
+				continue
+			}
+			cmd := exec.Command(path, t.args...)
+			cmd.Stdin = strings.NewReader(text)
+			if err := cmd.Run(); err == nil {
+				return t.name, nil
+			}
+		}
+	}
+	return "OSC 52", writeOSC52(text)
+}
+
+// writeOSC52 sends the OSC 52 "set clipboard" sequence to the terminal,
+// wrapped for tmux when inside it (which needs `set -g allow-passthrough
+// on` or `set -g set-clipboard on`).  Terminals that do not support it
+// ignore the sequence.
+func writeOSC52(text string) error {
+	seq := tmuxPassthrough("\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a")
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		// No controlling terminal (Windows, or detached): stderr is the
+		// next best thing that is not the data on stdout.
+		_, err = fmt.Fprint(os.Stderr, seq)
+		return err
+	}
+	defer tty.Close()
+	_, err = fmt.Fprint(tty, seq)
+	return err
+}
+
+// tmuxPassthrough wraps an escape sequence so tmux hands it on to the
+// terminal outside instead of keeping it; elsewhere seq is unchanged.
+func tmuxPassthrough(seq string) string {
+	if os.Getenv("TMUX") == "" {
+		return seq
+	}
+	return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+}
+
+// runCopy implements `gits --copy staged|modified|untracked|all [path]`:
+// the chosen changed paths, one per line, relative to the current
+// directory, go to the clipboard.
+func runCopy(status *Status, args []string) {
+	c := status.cfg.Colors
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	kind, dir := "all", "."
+	if len(args) > 0 {
+		kind = args[0]
+	}
+	if len(args) > 1 {
+		dir = args[1]
+	}
+	keep := map[string]func(FileEntry) bool{
+		"staged":    FileEntry.Staged,
+		"modified":  FileEntry.Unstaged,
+		"untracked": func(e FileEntry) bool { return e.Kind == "untracked" },
+		"all":       func(FileEntry) bool { return true },
+	}[kind]
+	if keep == nil {
+		fail(fmt.Errorf("usage: gits --copy staged|modified|untracked|all [path]"))
+	}
+
+	files, err := changedFiles(dir, keep)
+	if err != nil {
+		fail(err)
+	}
+	if len(files) == 0 {
+		fmt.Printf("%s %sNo %s files to copy%s\n", Icons.INFO, Dim, kind, Reset)
+		return
+	}
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = quoteGitPath(f.Path)
+	}
+	how, err := copyToClipboard(strings.Join(paths, "\n") + "\n")
+	if err != nil {
+		fail(err)
+	}
+	noun := "paths"
+	if len(paths) == 1 {
+		noun = "path"
+	}
+	fmt.Printf("%s %sCopied %d %s %s to the clipboard%s %s(%s)%s\n", Icons.SUCCESS, resolveColor(c.UpToDate),
+		len(paths), kind, noun, Reset, Dim, how, Reset)
+}
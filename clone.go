@@ -0,0 +1,214 @@
+// File: clone.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: clone with a progress bar and a post-clone summary (`gits clone`)
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// progressPhase matches git's (and git-lfs') progress lines:
+// "Receiving objects:  45% (450/1000), 1.20 MiB | 2.00 MiB/s".
+var progressPhase = regexp.MustCompile(`^(?:remote: )?([A-Za-z][A-Za-z ]*?):\s+(\d+)% \((\d+)/(\d+)\)(.*)$`)
+
+// cloneDirFor derives the directory git clone would create for url.
+func cloneDirFor(url string) string {
+	url = strings.TrimRight(strings.TrimSpace(url), "/")
+	url = strings.TrimSuffix(url, "/.git")
+	if i := strings.LastIndexAny(url, "/:\\"); i >= 0 {
+		url = url[i+1:]
+	}
+	return strings.TrimSuffix(url, ".git")
+}
+
+// drawProgressBar renders git's progress from r on w as one bar per phase
+// (objects, deltas, LFS files); other lines are passed through colored.
+// Without a terminal the lines are passed through unchanged.
+func drawProgressBar(r io.Reader, w *os.File, c ColorConfig) {
+	if !term.IsTerminal(int(w.Fd())) {
+		io.Copy(w, r)
+		return
+	}
+	tp := newTermProgress()
+	defer tp.done()
+	width := 24
+	if cols, _, err := term.GetSize(int(w.Fd())); err == nil && cols > 90 {
+		width = 40
+	}
+	br := bufio.NewReader(r)
+	var line []byte
+	phase := ""
+	emit := func(text string, final bool) {
+		m := progressPhase.FindStringSubmatch(text)
+		if m == nil {
+			if text != "" && !strings.HasPrefix(text, "remote: Enumerating") && !strings.HasPrefix(text, "remote: Counting") {
+				if phase != "" {
+					fmt.Fprint(w, "\n")
+					phase = ""
+				}
+				fmt.Fprint(w, "\r\x1b[K"+colorProgressLine(text, c)+"\n")
+			}
+			return
+		}
+		name := strings.TrimPrefix(m[1], "remote: ")
+		pct, _ := strconv.Atoi(m[2])
+		tp.set(pct)
+		if phase != "" && phase != name {
+			fmt.Fprint(w, "\n")
+		}
+		phase = name
+		filled := width * min(pct, 100) / 100
+		style := resolveColor(c.Hint)
+		if pct >= 100 {
+			style = resolveColor(c.UpToDate)
+		}
+		extra := strings.TrimPrefix(strings.TrimSuffix(strings.TrimSpace(m[5]), ", done."), ", ")
+		fmt.Fprintf(w, "\r\x1b[K%-18s %s%s%s%s%s %3d%% %s(%s/%s)%s %s", name, style, strings.Repeat("█", filled), Dim,
+			strings.Repeat("░", width-filled), Reset, pct, Dim, m[3], m[4], Reset, extra)
+		if final || strings.HasSuffix(m[5], "done.") {
+			fmt.Fprint(w, "\n")
+			phase = ""
+		}
+	}
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			if len(line) > 0 {
+				emit(string(line), true)
+			}
+			if phase != "" {
+				fmt.Fprint(w, "\n")
+			}
+			return
+		}
+		if b == '\r' || b == '\n' {
+			emit(string(line), false)
+			line = line[:0]
+			continue
+		}
+		line = append(line, b)
+	}
+}
+
+// runClone implements `gits clone URL [DIR] [--recursive] [--print-dir]
+// [git clone options...]`.  --print-dir writes only the clone's path to
+// stdout, for `cd "$(gits clone --print-dir URL)"`.
+func runClone(status *Status, args []string) {
+	c := status.cfg.Colors
+	var url, dir string
+	var extra []string
+	printDir, recursive := false, false
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "--print-dir":
+			printDir = true
+		case a == "--recursive" || a == "--recurse-submodules":
+			recursive = true
+		case a == "-b" || a == "--branch" || a == "--depth" || a == "-o" || a == "--origin":
+			extra = append(extra, a)
+			if i+1 < len(args) {
+				i++
+				extra = append(extra, args[i])
+			}
+		case strings.HasPrefix(a, "-"):
+			extra = append(extra, a)
+		case url == "":
+			url = a
+		default:
+			dir = a
+		}
+	}
+	// Messages go to stderr under --print-dir so stdout stays a bare path.
+	out := os.Stdout
+	if printDir {
+		out = os.Stderr
+	}
+	fail := func(err error) {
+		fmt.Fprintf(out, "%s %s%v%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), err, Reset)
+		exit(1)
+	}
+	if url == "" {
+		fail(fmt.Errorf("usage: gits clone URL [DIR] [--recursive] [--print-dir]"))
+	}
+	if dir == "" {
+		dir = cloneDirFor(url)
+	}
+	if Exists(dir) {
+		if entries, err := os.ReadDir(dir); err != nil || len(entries) > 0 {
+			fail(fmt.Errorf("%s already exists and is not empty", dir))
+		}
+	}
+
+	fmt.Fprintf(out, "📥 %sCloning%s %s%s%s into %s%s%s\n", Bold, Reset, resolveColor(c.RemoteURL), url, Reset, Bold, dir, Reset)
+	cloneArgs := append([]string{"clone", "--progress"}, extra...)
+	if recursive {
+		cloneArgs = append(cloneArgs, "--recurse-submodules")
+	}
+	cmd := gitCmd("", append(cloneArgs, "--", url, dir)...)
+	cmd.Stdout = os.Stderr
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		fail(err)
+	}
+	if err := cmd.Start(); err != nil {
+		fail(err)
+	}
+	drawProgressBar(stderr, os.Stderr, c)
+	if err := cmd.Wait(); err != nil {
+		fail(fmt.Errorf("git clone failed"))
+	}
+
+	abs, _ := filepath.Abs(dir)
+	branch, _ := gitOutput(abs, "symbolic-ref", "--short", "-q", "HEAD")
+	if branch == "" {
+		branch = "(detached)"
+	}
+	commits, _ := gitOutput(abs, "rev-list", "--count", "HEAD")
+	tracked, _ := gitRaw(abs, "ls-files", "-z")
+	row := func(label, value string) {
+		fmt.Fprintf(out, "   %s%-11s%s %s\n", Dim, label, Reset, value)
+	}
+	fmt.Fprintf(out, "%s %sCloned%s %s%s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset, Bold, displayPath(abs), Reset)
+	row("branch", Bold+resolveColor(c.Branch)+Icons.GIT+" "+branch+Reset)
+	if commits != "" {
+		row("commits", commits)
+	}
+	row("files", strconv.Itoa(strings.Count(tracked, "\x00")))
+	row("size", fmt.Sprintf("%s %s(.git %s)%s", humanSize(pathSize(abs)), Dim, humanSize(pathSize(filepath.Join(abs, ".git"))), Reset))
+	if subs, err := listSubmodules(abs); err == nil && len(subs) > 0 {
+		initialized := 0
+		for _, s := range subs {
+			if s.Checkout != "" {
+				initialized++
+			}
+		}
+		row("submodules", fmt.Sprintf("%d (%d initialized)", len(subs), initialized))
+		if initialized < len(subs) {
+			fmt.Fprintf(out, "   %sfetch them with `gits submodule --update`%s\n", Dim, Reset)
+		}
+	}
+	if attrs, err := os.ReadFile(filepath.Join(abs, ".gitattributes")); err == nil {
+		if n := strings.Count(string(attrs), "filter=lfs"); n > 0 {
+			row("lfs", fmt.Sprintf("%d pattern(s) in .gitattributes", n))
+		}
+	}
+
+	if printDir {
+		fmt.Println(abs)
+		return
+	}
+	fmt.Printf("   %s$ cd %s%s\n", Dim, shellQuote(dir), Reset)
+}
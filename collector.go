@@ -0,0 +1,196 @@
+// File: collector.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: Collector: reading statuses into plain data, safe across goroutines
+// License: MIT
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Collector reads the status of repositories.  It keeps nothing between
+// calls and prints nothing, so one Collector may serve any number of
+// goroutines: the scan, the daemon, the server and the UI all read
+// through it, and Renderer draws what it returns.
+type Collector struct {
+	// Links works out the remote's web pages, for hyperlinks in a report.
+	Links bool
+	// TrackedIgnored lists the tracked files an ignore rule matches.
+	TrackedIgnored bool
+}
+
+// StatusReport is everything the long status of one directory is drawn
+// from, by any Renderer.  It is plain data, shared with nothing once
+// Report returns.
+type StatusReport struct {
+	Dir     string        // the working directory, logical unless --resolve-symlinks
+	NotRepo bool          // Dir is in no repository
+	Text    string        // the long `git status` output
+	Lines   []StatusLine  // Text read line by line
+	Stderr  string        // warnings and hints git wrote while succeeding
+	Head    headCommit    // the last commit, when HasHead
+	HasHead bool          // false on a branch without commits
+	Links   *repoLinks    // hyperlinks for the branch and paths, or nil
+	Took    time.Duration // how long the status took to read
+	Err     error         // why the status could not be read
+
+	// Profile is the .gitignore profile the status was read under, and
+	// ProfileErr why it could not be put in place.
+	Profile, ProfileErr string
+	// TrackedIgnored are the tracked files an ignore rule matches, when
+	// the Collector was asked for them.
+	TrackedIgnored []ignoredTracked
+	// SuggestAccel is set when the status was slow and git's fsmonitor
+	// or untracked cache is off.
+	SuggestAccel bool
+	// Filter is the --filter query Lines were narrowed by, and Hidden how
+	// many paths it left out.
+	Filter string
+	Hidden int
+}
+
+// Status runs `git status --porcelain=v2` in dir and parses it, or reads
+// the repository natively (see statusBackend), falling back to git when
+// the native reader fails.  Failures are recorded in RepoStatus.Err rather
+// than returned so that fleet scans can report them alongside healthy
+// repositories.
+func (Collector) Status(dir string) *RepoStatus {
+	if useNative() {
+		rs, err := nativeStatus(dir)
+		if err == nil {
+			return rs
+		}
+		if !gitAvailable() {
+			rs.Err = err.Error()
+			return rs
+		}
+		if debugMode {
+			fmt.Fprintf(os.Stderr, "native backend failed, using git: %v\n", err)
+		}
+	}
+	return cliStatus(dir)
+}
+
+// Report reads the long status of dir in two git calls run side by side:
+// `git status --porcelain=v2`, drawn as the long status, and `git log -1`
+// for the last commit.  The settings that shape it come straight from the
+// config files, and the web address for hyperlinks is read alongside.
+func (col Collector) Report(dir string) *StatusReport {
+	rep := &StatusReport{Dir: dir}
+	if dir != "" {
+		rep.Dir = workingDir(dir)
+	}
+	root, gitDir, ok := enclosingRepo(rep.Dir)
+	if !ok {
+		if os.Getenv("GIT_DIR") == "" {
+			rep.NotRepo = true
+			return rep
+		}
+		// $GIT_DIR names a repository enclosingRepo cannot find.
+		var err error
+		if root, err = repoRoot(rep.Dir); err != nil {
+			rep.NotRepo, rep.Err = isNotRepoErr(err), err
+			if rep.NotRepo {
+				rep.Err = nil
+			}
+			return rep
+		}
+		gitDir = gitDirOf(root)
+	}
+	st := fileStatusSettings(root)
+
+	type head struct {
+		hc headCommit
+		ok bool
+	}
+	headCh := make(chan head, 1)
+	go func() {
+		hc, ok := lastCommit(rep.Dir)
+		headCh <- head{hc, ok}
+	}()
+	baseCh := make(chan string, 1)
+	go func() { baseCh <- col.webURL(root) }()
+
+	start := time.Now()
+	rs, stderr := readStatus(rep.Dir, st)
+	rep.Took = time.Since(start)
+	h := <-headCh
+	rep.Head, rep.HasHead = h.hc, h.ok
+	base := <-baseCh
+	if rs.Err != "" {
+		if err := errors.New(rs.Err); isNotRepoErr(err) {
+			rep.NotRepo = true
+		} else {
+			rep.Err = err
+		}
+		return rep
+	}
+	rep.Stderr = stderr
+	col.describe(rep, rs, root, gitDir, st, base)
+	rep.SuggestAccel = !useNative() && statusNeedsAccel(rep.Dir, rep.Took)
+	return rep
+}
+
+// ReportOf draws up the report of rs, a status Status read at the top
+// level of its repository, reading only the last commit on top: a scan
+// shows the repositories it read in full without reading them twice.
+func (col Collector) ReportOf(rs *RepoStatus) *StatusReport {
+	rep := &StatusReport{Dir: rs.Path}
+	if rs.Err != "" {
+		rep.Err = errors.New(rs.Err)
+		return rep
+	}
+	rep.Head, rep.HasHead = lastCommit(rs.Path)
+	gitDir, _, ok := locateGitDir(rs.Path)
+	if !ok {
+		gitDir = gitDirOf(rs.Path)
+	}
+	col.describe(rep, rs, rs.Path, gitDir, fileStatusSettings(rs.Path), col.webURL(rs.Path))
+	return rep
+}
+
+// webURL is the web address of the repository at root that hyperlinks
+// point under, or "" when there are to be none.
+func (col Collector) webURL(root string) string {
+	if !col.Links || links.Disable || !stdoutIsTerminal {
+		return ""
+	}
+	return repoWebURL(root)
+}
+
+// describe fills rep in from rs, the status of the repository at root
+// with its git directory gitDir: the long status as git words it, read back line by line, with the
+// contents of untracked directories, the hyperlinks of base and the
+// tracked files ignore rules match.
+func (col Collector) describe(rep *StatusReport, rs *RepoStatus, root, gitDir string, st statusSettings, base string) {
+	start := time.Now()
+	cwd := rep.Dir
+	if !st.RelativePaths {
+		// status.relativePaths=false: paths from the top level.
+		cwd = root
+	}
+	rep.Text = longStatus(rs, readRepoState(root, gitDir, rs), root, cwd)
+	rep.Lines = parseLongStatus(rep.Text)
+	dirs := untrackedDirs(rep.Lines)
+	timings.phase("parse", start)
+	if len(dirs) > 0 {
+		// The git call is timed as such; what is parsed counts here.
+		contents := untrackedContents(cwd, dirs)
+		start = time.Now()
+		for i := range rep.Lines {
+			if d, ok := contents[rep.Lines[i].Path]; ok && rep.Lines[i].Kind == lineUntracked && len(d.files) > 0 {
+				rep.Lines[i].Dir = d
+			}
+		}
+		timings.phase("parse", start)
+	}
+	rep.Links = newRepoLinks(base, root, cwd, rs)
+	if col.TrackedIgnored {
+		rep.TrackedIgnored, _ = trackedIgnored(root)
+	}
+}
@@ -0,0 +1,113 @@
+// File: commit.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: commit with a preview of what is staged (`gits commit`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// printStagedSummary shows what a commit in root would contain, and what it
+// would leave out.  It returns false when nothing is staged.
+func printStagedSummary(root string, rs *RepoStatus, c ColorConfig) bool {
+	if rs.Staged == 0 {
+		return false
+	}
+	branch := rs.Branch
+	if rs.Detached {
+		branch = "(detached " + shortOid(rs.Oid) + ")"
+	}
+	fmt.Printf("%s %sTo be committed on%s %s%s %s%s\n",
+		Icons.INFO, Bold+resolveColor(c.Header), Reset, Bold+resolveColor(c.Branch), Icons.GIT, branch, Reset)
+
+	for _, e := range rs.Entries {
+		if !e.Staged() {
+			continue
+		}
+		style := Bold + resolveColor(c.Modified)
+		switch e.Index {
+		case "A":
+			style = Bold + resolveColor(c.NewFile)
+		case "D":
+			style = Bold + resolveColor(c.Deleted)
+		case "R", "C":
+			style = Bold + resolveColor(c.Renamed)
+		}
+		fmt.Printf("    %s%s%s %s\n", style, e.Index, Reset, entryLabel(e))
+	}
+	if numstat, err := gitOutput(root, "diff", "--cached", "--numstat"); err == nil {
+		fmt.Print(renderNumstat(numstat, c, "    "))
+	}
+
+	if left := rs.Modified + rs.Untracked + rs.Conflicts; left > 0 {
+		fmt.Printf("%s %sNot included: %d unstaged, %d untracked, %d conflicted%s\n",
+			Icons.WARNING, resolveColor(c.AheadBehind), rs.Modified, rs.Untracked, rs.Conflicts, Reset)
+	}
+	return true
+}
+
+// runCommit implements `gits commit [-m MSG]... [--yes] [git commit args...]`:
+// preview the staged changes, confirm, commit, then report the new commit.
+func runCommit(status *Status, args []string) {
+	c := status.cfg.Colors
+	yes := false
+	var gitArgs []string
+	for _, a := range args {
+		switch a {
+		case "--yes", "-y":
+			yes = true
+		default:
+			gitArgs = append(gitArgs, a)
+		}
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		exitWithError(err, c)
+	}
+	rs := CollectStatus(root)
+	if rs.Err != "" {
+		exitWithError(fmt.Errorf("%s", rs.Err), c)
+	}
+	if !printStagedSummary(root, rs, c) {
+		fmt.Printf("%s %sNothing staged to commit%s %s(use `gits add`)%s\n",
+			Icons.WARNING, resolveColor(c.AheadBehind), Reset, Dim, Reset)
+		exit(1)
+	}
+
+	if !yes {
+		ok, err := confirm("Commit these changes?")
+		if err != nil {
+			fmt.Printf("%s %s%v (use --yes)%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), err, Reset)
+			exit(1)
+		}
+		if !ok {
+			fmt.Printf("%s Commit aborted\n", Icons.INFO)
+			return
+		}
+	}
+
+	// Run attached to the terminal so hooks and the editor (no -m) work.
+	cmd := gitCmd(root, append([]string{"commit", "--quiet"}, gitArgs...)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Printf("%s %sgit commit failed%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), Reset)
+		exit(1)
+	}
+
+	out, err := gitOutput(root, "log", "-1", "--format=%h%x00%s")
+	if err != nil {
+		return
+	}
+	sha, subject, _ := strings.Cut(out, "\x00")
+	fmt.Printf("\n%s %sCommitted%s %s%s%s %s\n", Icons.SUCCESS, Bold+resolveColor(c.UpToDate), Reset,
+		Bold+resolveColor(c.AheadBehind), sha, Reset, subject)
+	if numstat, err := gitOutput(root, "show", "--numstat", "--format=", "HEAD"); err == nil {
+		fmt.Print(renderNumstat(numstat, c, "    "))
+	}
+}
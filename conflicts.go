@@ -0,0 +1,189 @@
+// File: conflicts.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: conflict navigator (`gits conflicts`)
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// conflictMarkers counts "<<<<<<<" conflict hunks in the file at path and
+// returns the line of the first one (0 when there are none).
+func conflictMarkers(path string) (hunks, firstLine int, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if strings.HasPrefix(line, "<<<<<<<") && (len(line) == 7 || line[7] == ' ') {
+			if hunks == 0 {
+				firstLine = n
+			}
+			hunks++
+		}
+	}
+	return hunks, firstLine, sc.Err()
+}
+
+// editorAt returns a shell command that opens path at line in editor,
+// using the line syntax the common editors understand.
+func editorAt(editor, path string, line int) string {
+	return editorCommand(editor, []editTarget{{path, line}})
+}
+
+// editTarget is a file to open, at a line when Line is not 0.
+type editTarget struct {
+	Path string
+	Line int
+}
+
+// editorCommand returns a shell command that opens every target in one
+// editor.  Editors that take a line per file get one for each; the vi
+// family only takes a line for the first file, so a target with a line is
+// moved to the front.
+func editorCommand(editor string, targets []editTarget) string {
+	if strings.TrimSpace(editor) == "" {
+		editor = "vi"
+	}
+	var args []string
+	switch filepath.Base(strings.Fields(editor)[0]) {
+	case "code", "code-insiders", "codium", "cursor":
+		args = append(args, "-g")
+		fallthrough
+	case "subl", "hx", "helix", "zed":
+		for _, t := range targets {
+			if t.Line > 0 {
+				args = append(args, shellQuote(fmt.Sprintf("%s:%d", argPath(t.Path), t.Line)))
+			} else {
+				args = append(args, shellQuote(argPath(t.Path)))
+			}
+		}
+	case "emacs", "emacsclient", "nano", "micro":
+		for _, t := range targets {
+			if t.Line > 0 {
+				args = append(args, fmt.Sprintf("+%d", t.Line))
+			}
+			args = append(args, shellQuote(argPath(t.Path)))
+		}
+	default:
+		first := slices.IndexFunc(targets, func(t editTarget) bool { return t.Line > 0 })
+		if first >= 0 {
+			args = append(args, fmt.Sprintf("+%d", targets[first].Line), shellQuote(argPath(targets[first].Path)))
+		}
+		for i, t := range targets {
+			if i != first {
+				args = append(args, shellQuote(argPath(t.Path)))
+			}
+		}
+	}
+	return editor + " " + strings.Join(args, " ")
+}
+
+// shellQuote quotes s for a POSIX shell when it needs it.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r == '/' || r == '.' || r == '-' || r == '_' || r == ':' || r == '+' ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// runConflicts implements `gits conflicts [--mark-resolved]`.
+func runConflicts(status *Status, args []string) {
+	c := status.cfg.Colors
+	markResolved := false
+	for _, a := range args {
+		if a == "--mark-resolved" || a == "--resolved" {
+			markResolved = true
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	rs := CollectStatus(root)
+	if rs.Err != "" {
+		fail(fmt.Errorf("%s", rs.Err))
+	}
+	var unmerged []FileEntry
+	for _, e := range rs.Entries {
+		if e.Kind == "unmerged" {
+			unmerged = append(unmerged, e)
+		}
+	}
+	if len(unmerged) == 0 {
+		fmt.Printf("%s %sNo conflicts%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+		return
+	}
+
+	editor, _ := gitOutput(root, "var", "GIT_EDITOR")
+	tool, _ := gitOutput(root, "config", "--get", "merge.tool")
+	cwd := workingDir(".")
+	var resolved []string
+	fmt.Printf("%s %s%d conflicted file(s)%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), len(unmerged), Reset)
+	for _, e := range unmerged {
+		full := filepath.Join(root, e.Path)
+		shown := e.Path
+		if rel, err := relPath(cwd, full); err == nil {
+			shown = rel
+		}
+		code := e.Index + e.Worktree
+		hunks, first, err := conflictMarkers(full)
+		switch {
+		case err != nil && os.IsNotExist(err):
+			// Deleted on one side: nothing to edit, only a decision to make.
+			fmt.Printf("    %s%s%s %s %s(deleted on one side: git add or git rm it)%s\n",
+				Bold+resolveColor(c.Deleted), code, Reset, shown, Dim, Reset)
+			continue
+		case err != nil:
+			fmt.Printf("    %s%s%s %s %s(%v)%s\n", Bold+resolveColor(c.Deleted), code, Reset, shown, Dim, err, Reset)
+			continue
+		case hunks == 0:
+			fmt.Printf("    %s%s%s %s %sno markers left%s\n", Bold+resolveColor(c.UpToDate), code, Reset, shown, resolveColor(c.UpToDate), Reset)
+			resolved = append(resolved, e.Path)
+			continue
+		}
+		fmt.Printf("    %s%s%s %s %s%d hunk(s), first at line %d%s\n", Bold+resolveColor(c.Deleted), code, Reset, shown,
+			resolveColor(c.AheadBehind), hunks, first, Reset)
+		fmt.Printf("        %s$ %s%s\n", Dim, editorAt(editor, shown, first), Reset)
+		if tool != "" {
+			fmt.Printf("        %s$ git mergetool --tool=%s -- %s%s\n", Dim, tool, shellQuote(shown), Reset)
+		}
+	}
+
+	if !markResolved {
+		if len(resolved) > 0 {
+			fmt.Printf("%s %d file(s) have no markers left %s(gits conflicts --mark-resolved)%s\n", Icons.INFO, len(resolved), Dim, Reset)
+		}
+		return
+	}
+	if len(resolved) == 0 {
+		fmt.Printf("%s %sNo file is free of conflict markers yet%s\n", Icons.WARNING, resolveColor(c.AheadBehind), Reset)
+		return
+	}
+	if err := stagePaths(root, resolved); err != nil {
+		fail(err)
+	}
+	fmt.Printf("%s %sMarked %d file(s) resolved%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), len(resolved), Reset)
+	if left := len(unmerged) - len(resolved); left > 0 {
+		fmt.Printf("%s %s%d file(s) still conflicted%s\n", Icons.WARNING, resolveColor(c.AheadBehind), left, Reset)
+	}
+}
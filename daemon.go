@@ -0,0 +1,181 @@
+// File: daemon.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: scheduled background scans (`gits daemon`) and the scan cache
+// License: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DaemonConfig controls `gits daemon`.
+type DaemonConfig struct {
+	Schedule    string   `toml:"schedule"`     // cron expression or "@every 15m"
+	Targets     []string `toml:"targets"`      // same syntax as `gits scan`
+	ReportDir   string   `toml:"report_dir"`   // write timestamped JSON reports here ("" = off)
+	KeepReports int      `toml:"keep_reports"` // prune older reports beyond this count (0 = keep all)
+	Webhook     bool     `toml:"webhook"`      // post via [notify] when something needs attention
+}
+
+// scanCachePath is where the daemon leaves its latest report for
+// `gits scan --cached`.
+func scanCachePath() string {
+	return stateFile("scan-cache.json")
+}
+
+// writeScanCache stores report atomically so readers never see a partial file.
+func writeScanCache(report *FleetReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(scanCachePath(), data, 0o644)
+}
+
+// readScanCache loads the most recent report written by the daemon.
+func readScanCache() (*FleetReport, error) {
+	data, err := os.ReadFile(scanCachePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no cached scan yet — start `gits daemon` or run `gits daemon --once`")
+		}
+		return nil, err
+	}
+	var report FleetReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("corrupt scan cache %s: %w", scanCachePath(), err)
+	}
+	return &report, nil
+}
+
+// expandHome replaces a leading ~ with the user's home directory.
+func expandHome(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") || strings.HasPrefix(p, `~\`) {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, p[1:])
+		}
+	}
+	return p
+}
+
+// writeReportFile saves report under dir and prunes old reports beyond keep.
+func writeReportFile(dir string, report *FleetReport, keep int) (string, error) {
+	dir = expandHome(dir)
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	name := filepath.Join(dir, "gits-report-"+report.GeneratedAt.Format("20060102-150405")+".json")
+	if err := writeFileAtomic(name, data, 0o644); err != nil {
+		return "", err
+	}
+	if keep > 0 {
+		old, _ := filepath.Glob(filepath.Join(dir, "gits-report-*.json"))
+		sort.Strings(old)
+		for len(old) > keep {
+			os.Remove(old[0])
+			old = old[1:]
+		}
+	}
+	return name, nil
+}
+
+// daemonRun performs one scheduled scan and publishes the results,
+// telling dn (which may be nil) about every repository.
+func daemonRun(cfg AppConfig, dn *desktopNotifier) {
+	dc := cfg.Daemon
+	logf := func(format string, a ...any) {
+		fmt.Printf("%s %s\n", time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf(format, a...))
+	}
+
+	start := time.Now()
+	repos, err := resolveScanTargets(cfg.Groups, dc.Targets, 6)
+	if err != nil {
+		logf("%s scan failed: %v", Icons.ERROR, err)
+		return
+	}
+	results := collectAll(repos)
+	fillUnpushed(results)
+	report := buildFleetReport(results)
+	for _, rs := range results {
+		dn.observe(rs)
+	}
+
+	if err := writeScanCache(report); err != nil {
+		logf("%s cannot write cache: %v", Icons.ERROR, err)
+	}
+	if dc.ReportDir != "" {
+		if name, err := writeReportFile(dc.ReportDir, report, dc.KeepReports); err != nil {
+			logf("%s cannot write report: %v", Icons.ERROR, err)
+		} else {
+			logf("%s report written to %s", Icons.INFO, name)
+		}
+	}
+	if dc.Webhook && cfg.Notify.Webhook != "" {
+		threshold := parseDurationOr(cfg.Notify.UnpushedOlderThan, 0)
+		if text := notifyMessage(results, threshold); text != "" {
+			if err := postWebhook(cfg.Notify.Webhook, cfg.Notify.Format, text, report); err != nil {
+				logf("%s webhook failed: %v", Icons.ERROR, err)
+			}
+		}
+	}
+
+	agg := report.Aggregate
+	logf("%s scanned %d repos in %s: %d dirty, %d with unpushed work, %d errors",
+		Icons.SUCCESS, agg.TotalRepos, time.Since(start).Round(time.Millisecond),
+		agg.DirtyRepos, agg.ReposWithUnpushed, agg.ErrorRepos)
+}
+
+// runDaemon implements `gits daemon [--once] [--schedule SPEC] [--desktop]`.
+func runDaemon(status *Status, args []string) {
+	c := status.cfg.Colors
+	cfg := status.cfg
+	once := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--once":
+			once = true
+		case "--desktop":
+			cfg.Desktop.Enabled = true
+		case "--schedule":
+			if i+1 < len(args) {
+				i++
+				cfg.Daemon.Schedule = args[i]
+			}
+		}
+	}
+
+	if once {
+		daemonRun(cfg, nil)
+		return
+	}
+	dn := newDesktopNotifier(cfg.Desktop)
+
+	sched, err := parseSchedule(cfg.Daemon.Schedule)
+	if err != nil {
+		exitWithError(err, c)
+	}
+	fmt.Printf("%s %sgits daemon%s schedule %q, cache %s\n",
+		Icons.INFO, Bold+resolveColor(c.Header), Reset, cfg.Daemon.Schedule, scanCachePath())
+
+	// Scan right away so the cache is fresh, then follow the schedule.
+	daemonRun(cfg, dn)
+	for {
+		next := sched.Next(time.Now())
+		if next.IsZero() {
+			fmt.Printf("%s schedule never fires again, exiting\n", Icons.WARNING)
+			return
+		}
+		time.Sleep(time.Until(next))
+		daemonRun(cfg, dn)
+	}
+}
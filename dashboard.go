@@ -0,0 +1,206 @@
+// File: dashboard.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: aligned table view for multi-repository results
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// displayPath shortens p for display by replacing the home directory with ~.
+func displayPath(p string) string {
+	p = nativePath(p)
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return p
+	}
+	home = nativePath(home)
+	if p == home {
+		return "~"
+	}
+	if strings.HasPrefix(p, home+string(filepath.Separator)) {
+		return "~" + p[len(home):]
+	}
+	return p
+}
+
+// dashCell is one table cell: visible text plus the style wrapped around it.
+type dashCell struct {
+	text  string
+	style string
+}
+
+// countCell renders a counter, dimming zeros so non-zero values stand out.
+func countCell(n int, style string) dashCell {
+	if n == 0 {
+		return dashCell{"·", Dim}
+	}
+	return dashCell{strconv.Itoa(n), style}
+}
+
+// renderDashboard prints one aligned row per repository.
+func (s *Status) renderDashboard(results []*RepoStatus) {
+	c := s.cfg.Colors
+	headers := []string{"", "REPO", "BRANCH", "↑↓", "STAGED", "MODIFIED", "UNTRACKED", "STASH"}
+	showAge := false
+	for _, r := range results {
+		if !r.LastCommit.IsZero() {
+			showAge = true
+			break
+		}
+	}
+	if showAge {
+		headers = append(headers, "LAST COMMIT")
+	}
+
+	rows := make([][]dashCell, 0, len(results))
+	for _, r := range results {
+		row := make([]dashCell, len(headers))
+
+		switch {
+		case r.Err != "":
+			row[0] = dashCell{"✖", Bold + resolveColor(c.Deleted)}
+		case r.Conflicts > 0 || r.Dirty():
+			row[0] = dashCell{"●", Bold + resolveColor(c.Modified)}
+		case r.Ahead > 0 || r.Behind > 0:
+			row[0] = dashCell{"●", Bold + resolveColor(c.AheadBehind)}
+		default:
+			row[0] = dashCell{"●", Bold + resolveColor(c.Staged)}
+		}
+		row[1] = dashCell{displayPath(r.Path), Bold + resolveColor(c.CwdPath)}
+
+		if r.Err != "" {
+			row[2] = dashCell{firstLine(r.Err), resolveColor(c.Deleted)}
+			for i := 3; i < len(row); i++ {
+				row[i] = dashCell{"", ""}
+			}
+			rows = append(rows, row)
+			continue
+		}
+
+		branch := r.Branch
+		if r.Detached && r.Oid != "" {
+			branch = "(detached " + shortOid(r.Oid) + ")"
+		}
+		row[2] = dashCell{branch, Bold + resolveColor(c.Branch)}
+
+		switch {
+		case r.Upstream == "":
+			row[3] = dashCell{"—", Dim}
+		case r.Ahead == 0 && r.Behind == 0:
+			row[3] = dashCell{"=", Dim}
+		default:
+			ab := ""
+			if r.Ahead > 0 {
+				ab += "↑" + strconv.Itoa(r.Ahead)
+			}
+			if r.Behind > 0 {
+				ab += "↓" + strconv.Itoa(r.Behind)
+			}
+			row[3] = dashCell{ab, Bold + resolveColor(c.AheadBehind)}
+		}
+
+		row[4] = countCell(r.Staged, Bold+resolveColor(c.Staged))
+		row[5] = countCell(r.Modified+r.Conflicts, Bold+resolveColor(c.Modified))
+		row[6] = countCell(r.Untracked, Bold+resolveColor(c.Untracked))
+		if r.UntrackedSkipped {
+			row[6] = dashCell{"–", Dim}
+		}
+		row[7] = countCell(r.Stashes, Bold+resolveColor(c.Header))
+		if showAge {
+			switch {
+			case r.LastCommit.IsZero():
+				row[8] = dashCell{"", ""}
+			case r.Stale:
+				row[8] = dashCell{shortAge(r.LastCommit) + " stale", Bold + resolveColor(c.Deleted)}
+			default:
+				row[8] = dashCell{shortAge(r.LastCommit), Dim}
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = stringCells(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if w := stringCells(cell.text); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for i, h := range headers {
+		sb.WriteString(padCell(dashCell{h, Bold + resolveColor(c.Header)}, widths[i], i >= 3 && i < 8))
+		sb.WriteString("  ")
+	}
+	fmt.Println(strings.TrimRight(sb.String(), " "))
+
+	for _, row := range rows {
+		sb.Reset()
+		for i, cell := range row {
+			sb.WriteString(padCell(cell, widths[i], i >= 3 && i < 8))
+			sb.WriteString("  ")
+		}
+		fmt.Println(strings.TrimRight(sb.String(), " "))
+	}
+}
+
+// padCell renders a cell padded to width (right-aligned for numeric columns).
+func padCell(cell dashCell, width int, right bool) string {
+	pad := strings.Repeat(" ", max(width-stringCells(cell.text), 0))
+	text := cell.text
+	if cell.style != "" && text != "" {
+		text = cell.style + text + Reset
+	}
+	if right {
+		return pad + text
+	}
+	return text + pad
+}
+
+// shortAge formats the time elapsed since t compactly ("5m", "3h", "12d",
+// "4mo", "2y").
+func shortAge(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return strconv.Itoa(int(d.Minutes())) + "m"
+	case d < 24*time.Hour:
+		return strconv.Itoa(int(d.Hours())) + "h"
+	case d < 60*24*time.Hour:
+		return strconv.Itoa(int(d.Hours()/24)) + "d"
+	case d < 730*24*time.Hour:
+		return strconv.Itoa(int(d.Hours()/24/30)) + "mo"
+	}
+	return strconv.Itoa(int(d.Hours()/24/365)) + "y"
+}
+
+// shortOid abbreviates a full object id for display.
+func shortOid(oid string) string {
+	if len(oid) > 7 {
+		return oid[:7]
+	}
+	return oid
+}
+
+// firstLine returns the first line of a (possibly multi-line) message.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+// File: desktop.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: desktop notifications on status changes for watch and daemon modes
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+// DesktopConfig ([desktop]) turns on desktop notifications from `gits
+// --watch` and `gits daemon`, one flag per kind of change.
+type DesktopConfig struct {
+	Enabled   bool `toml:"enabled"`
+	Behind    bool `toml:"behind"`    // the branch fell behind its upstream
+	Diverged  bool `toml:"diverged"`  // ahead of and behind the upstream at once
+	Conflicts bool `toml:"conflicts"` // conflicted files appeared
+	Dirty     bool `toml:"dirty"`     // a clean worktree got changes
+	Errors    bool `toml:"errors"`    // the status could no longer be read
+}
+
+// desktopEvents lists what changed from prev to cur that dc asks to hear
+// about.  Only transitions count, so a repository that stays behind is
+// announced once; a nil prev (the first look) announces nothing.
+func desktopEvents(prev, cur *RepoStatus, dc DesktopConfig) []string {
+	if prev == nil || cur == nil {
+		return nil
+	}
+	var events []string
+	if dc.Errors && cur.Err != "" && prev.Err == "" {
+		events = append(events, "cannot read status: "+cur.Err)
+	}
+	if cur.Err != "" || prev.Err != "" {
+		return events
+	}
+	diverged := cur.Ahead > 0 && cur.Behind > 0
+	switch {
+	case dc.Diverged && diverged && !(prev.Ahead > 0 && prev.Behind > 0):
+		events = append(events, fmt.Sprintf("%s has diverged from %s (↑%d ↓%d)", cur.Branch, cur.Upstream, cur.Ahead, cur.Behind))
+	case dc.Behind && !diverged && cur.Behind > 0 && prev.Behind == 0:
+		events = append(events, fmt.Sprintf("%s is %d commit(s) behind %s", cur.Branch, cur.Behind, cur.Upstream))
+	}
+	if dc.Conflicts && cur.Conflicts > 0 && prev.Conflicts == 0 {
+		events = append(events, fmt.Sprintf("%d conflicted file(s)", cur.Conflicts))
+	}
+	if dc.Dirty && cur.Dirty() && !prev.Dirty() {
+		events = append(events, fmt.Sprintf("uncommitted changes: %d staged, %d modified, %d untracked",
+			cur.Staged, cur.Modified, cur.Untracked))
+	}
+	return events
+}
+
+// desktopToast is the PowerShell that shows a Windows toast, reading the
+// text from the environment so nothing needs quoting.
+const desktopToast = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$t = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$x = $t.GetElementsByTagName('text')
+$x.Item(0).AppendChild($t.CreateTextNode($env:GITS_TITLE)) > $null
+$x.Item(1).AppendChild($t.CreateTextNode($env:GITS_BODY)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('gits').Show([Windows.UI.Notifications.ToastNotification]::new($t))`
+
+// sendDesktopNotification shows title and body with notify-send, osascript
+// or a PowerShell toast, depending on the system.
+func sendDesktopNotification(title, body string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("osascript", "-e", "on run argv", "-e",
+			"display notification (item 2 of argv) with title (item 1 of argv)", "-e", "end run", title, body)
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", desktopToast)
+		cmd.Env = append(os.Environ(), "GITS_TITLE="+title, "GITS_BODY="+body)
+	default:
+		cmd = exec.Command("notify-send", "--app-name=gits", title, body)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %v %s", filepath.Base(cmd.Path), err, out)
+	}
+	return nil
+}
+
+// desktopNotifier remembers the last status of each repository and
+// notifies about the changes [desktop] asks for.
+type desktopNotifier struct {
+	cfg  DesktopConfig
+	last map[string]*RepoStatus
+}
+
+// newDesktopNotifier returns nil when notifications are off.
+func newDesktopNotifier(dc DesktopConfig) *desktopNotifier {
+	if !dc.Enabled {
+		return nil
+	}
+	return &desktopNotifier{cfg: dc, last: map[string]*RepoStatus{}}
+}
+
+// observe records rs and notifies about what changed since the last time
+// its repository was seen.
+func (n *desktopNotifier) observe(rs *RepoStatus) {
+	if n == nil || rs == nil {
+		return
+	}
+	prev := n.last[rs.Path]
+	n.last[rs.Path] = rs
+	for _, ev := range desktopEvents(prev, rs, n.cfg) {
+		if err := sendDesktopNotification("gits: "+filepath.Base(rs.Path), ev); err != nil && debugMode {
+			fmt.Fprintf(os.Stderr, "desktop notification: %v\n", err)
+		}
+	}
+}
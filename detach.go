@@ -0,0 +1,20 @@
+// File: detach.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: starting processes that outlive gits (Unix)
+// License: MIT
+
+//go:build !windows
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detach puts cmd in a session of its own, so the terminal's hangup or
+// Ctrl-C does not reach it once gits has returned.
+func detach(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
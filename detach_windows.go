@@ -0,0 +1,21 @@
+// File: detach_windows.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: starting processes that outlive gits (Windows)
+// License: MIT
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachedProcess is DETACHED_PROCESS: no console for the child.
+const detachedProcess = 0x00000008
+
+// detach starts cmd without a console and outside the console's process
+// group, so closing the terminal or Ctrl-C does not reach it.
+func detach(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | detachedProcess}
+}
@@ -0,0 +1,340 @@
+// File: diff.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: colorized unified diff and diffstat renderers (`gits diff`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Reverse highlights the words that changed inside a modified line.
+const Reverse = "\033[7m"
+
+// diffFile collects the header lines of one file section of a patch.
+type diffFile struct {
+	oldPath, newPath string
+	status           string // "new file", "deleted", "renamed", "mode change" or ""
+	binary           bool
+}
+
+// title is the path shown in the file header ("old → new" for renames).
+func (f *diffFile) title() string {
+	switch {
+	case f.newPath == "":
+		return f.oldPath
+	case f.oldPath != "" && f.oldPath != f.newPath:
+		return f.oldPath + " → " + f.newPath
+	}
+	return f.newPath
+}
+
+// diffRenderer turns the text of `git diff` into colored output.
+type diffRenderer struct {
+	c       ColorConfig
+	sb      strings.Builder
+	file    *diffFile
+	removed []string // pending "-" lines, paired with "+" lines for word highlighting
+	added   []string
+}
+
+// renderDiff colors a unified diff as produced by `git diff --no-color`.
+func renderDiff(patch string, c ColorConfig) string {
+	r := &diffRenderer{c: c}
+	for _, line := range strings.Split(strings.TrimSuffix(patch, "\n"), "\n") {
+		r.line(line)
+	}
+	r.flushChanges()
+	r.flushHeader()
+	return r.sb.String()
+}
+
+func (r *diffRenderer) line(line string) {
+	c := r.c
+	switch {
+	case strings.HasPrefix(line, "diff --git "):
+		r.flushChanges()
+		r.flushHeader()
+		r.file = &diffFile{}
+		// Fallback paths for sections without ---/+++ lines (e.g. mode changes).
+		if a, b, ok := strings.Cut(strings.TrimPrefix(line, "diff --git "), " b/"); ok {
+			r.file.oldPath = strings.TrimPrefix(a, "a/")
+			r.file.newPath = b
+		}
+		return
+	case r.file != nil && !strings.HasPrefix(line, "@@"):
+		r.header(line)
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(line, "@@"):
+		r.flushChanges()
+		r.flushHeader()
+		// "@@ -1,4 +1,5 @@ func context" → dim ranges, highlighted context.
+		if end := strings.Index(line[2:], "@@"); end >= 0 {
+			ranges := line[:end+4]
+			ctx := strings.TrimSpace(line[end+4:])
+			r.sb.WriteString(Dim + resolveColor(c.Hint) + ranges + Reset)
+			if ctx != "" {
+				r.sb.WriteString(" " + Bold + resolveColor(c.Arrow) + ctx + Reset)
+			}
+			r.sb.WriteString("\n")
+		} else {
+			r.sb.WriteString(Dim + line + Reset + "\n")
+		}
+	case strings.HasPrefix(line, "-"):
+		if len(r.added) > 0 {
+			r.flushChanges()
+		}
+		r.removed = append(r.removed, line[1:])
+	case strings.HasPrefix(line, "+"):
+		r.added = append(r.added, line[1:])
+	case strings.HasPrefix(line, `\`):
+		r.flushChanges()
+		r.sb.WriteString(Dim + line + Reset + "\n")
+	default:
+		r.flushChanges()
+		r.sb.WriteString(line + "\n")
+	}
+}
+
+// header consumes the extended header lines of a file section.
+func (r *diffRenderer) header(line string) {
+	f := r.file
+	switch {
+	case strings.HasPrefix(line, "new file mode"):
+		f.status = "new file"
+	case strings.HasPrefix(line, "deleted file mode"):
+		f.status = "deleted"
+	case strings.HasPrefix(line, "rename from "):
+		f.status = "renamed"
+		f.oldPath = strings.TrimPrefix(line, "rename from ")
+	case strings.HasPrefix(line, "rename to "):
+		f.newPath = strings.TrimPrefix(line, "rename to ")
+	case strings.HasPrefix(line, "old mode") && f.status == "":
+		f.status = "mode change"
+	case strings.HasPrefix(line, "Binary files"):
+		f.binary = true
+	case strings.HasPrefix(line, "--- "):
+		if p := strings.TrimPrefix(line, "--- "); p != "/dev/null" {
+			f.oldPath = strings.TrimPrefix(p, "a/")
+		}
+	case strings.HasPrefix(line, "+++ "):
+		if p := strings.TrimPrefix(line, "+++ "); p != "/dev/null" {
+			f.newPath = strings.TrimPrefix(p, "b/")
+		} else {
+			f.newPath = ""
+		}
+	}
+}
+
+// flushHeader prints the pending file header, if any.
+func (r *diffRenderer) flushHeader() {
+	f := r.file
+	if f == nil {
+		return
+	}
+	r.file = nil
+	c := r.c
+
+	name := f.newPath
+	if name == "" {
+		name = f.oldPath
+	}
+	title := f.title()
+	r.sb.WriteString("\n" + getFileEmoji(name) + " " + Bold + resolveColor(c.Header) + title + Reset)
+	switch f.status {
+	case "new file":
+		r.sb.WriteString("  " + resolveColor(c.NewFile) + "(new file)" + Reset)
+	case "deleted":
+		r.sb.WriteString("  " + resolveColor(c.Deleted) + "(deleted)" + Reset)
+	case "renamed":
+		r.sb.WriteString("  " + resolveColor(c.Renamed) + "(renamed)" + Reset)
+	case "mode change":
+		r.sb.WriteString("  " + Dim + "(mode change)" + Reset)
+	}
+	if f.binary {
+		r.sb.WriteString("  " + Dim + "(binary)" + Reset)
+	}
+	r.sb.WriteString("\n" + Dim + strings.Repeat("─", max(stringCells(title)+3, 40)) + Reset + "\n")
+}
+
+// flushChanges prints the pending -/+ block.  Removed and added lines are
+// paired in order; each pair gets the differing words highlighted.
+func (r *diffRenderer) flushChanges() {
+	c := r.c
+	del := Bold + resolveColor(c.Deleted)
+	add := Bold + resolveColor(c.Added)
+	removed := append([]string(nil), r.removed...)
+	added := append([]string(nil), r.added...)
+	for i := 0; i < len(removed) && i < len(added); i++ {
+		removed[i], added[i] = highlightWords(removed[i], added[i], del, add)
+	}
+	for _, line := range removed {
+		r.sb.WriteString(del + "-" + line + Reset + "\n")
+	}
+	for _, line := range added {
+		r.sb.WriteString(add + "+" + line + Reset + "\n")
+	}
+	r.removed, r.added = r.removed[:0], r.added[:0]
+}
+
+// splitWords tokenizes s into runs of letters/digits, runs of spaces and
+// single punctuation characters.
+func splitWords(s string) []string {
+	var toks []string
+	class := func(r rune) int {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		}
+		return 0
+	}
+	start, prev := 0, -1
+	for i, r := range s {
+		k := class(r)
+		if i > start && (k != prev || k == 0) {
+			toks = append(toks, s[start:i])
+			start = i
+		}
+		prev = k
+	}
+	if start < len(s) {
+		toks = append(toks, s[start:])
+	}
+	return toks
+}
+
+// highlightWords marks the tokens that differ between oldLine and newLine
+// (via the longest common subsequence of their words).  The base colors
+// are restored after each highlighted run.  Very long or completely different
+// lines are returned unhighlighted.
+func highlightWords(oldLine, newLine, delBase, addBase string) (string, string) {
+	a, b := splitWords(oldLine), splitWords(newLine)
+	if len(a) == 0 || len(b) == 0 || len(a)*len(b) > 40000 {
+		return oldLine, newLine
+	}
+
+	// lcs[i][j] = length of the LCS of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	if lcs[0][0] == 0 {
+		return oldLine, newLine
+	}
+
+	keepA := make([]bool, len(a))
+	keepB := make([]bool, len(b))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			keepA[i], keepB[j] = true, true
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	mark := func(toks []string, keep []bool, base string) string {
+		var sb strings.Builder
+		for i, t := range toks {
+			if !keep[i] && strings.TrimSpace(t) != "" {
+				sb.WriteString(Reverse + t + Reset + base)
+			} else {
+				sb.WriteString(t)
+			}
+		}
+		return sb.String()
+	}
+	return mark(a, keepA, delBase), mark(b, keepB, addBase)
+}
+
+// runDiff implements `gits diff [--staged] [git diff args...] [-- paths]`.
+// Arguments are passed through to git diff; the output is rendered with the
+// gits palette.
+func runDiff(status *Status, args []string) {
+	c := status.cfg.Colors
+	gitArgs := append([]string{"diff", "--no-color", "--no-ext-diff"}, args...)
+	out, err := gitRaw(".", gitArgs...)
+	if err != nil {
+		exitWithError(err, c)
+	}
+	if strings.TrimSpace(out) == "" {
+		fmt.Printf("%s %sNo changes%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+		return
+	}
+	fmt.Print(strings.TrimPrefix(renderDiff(out, c), "\n"))
+}
+
+// renderNumstat turns `git diff --numstat` output into a colored diffstat:
+// one line per file with its counts and a +/- bar, then a totals line.
+func renderNumstat(numstat string, c ColorConfig, indent string) string {
+	type stat struct {
+		path        string
+		add, del    int
+		binary      bool
+		changeCount int
+	}
+	var stats []stat
+	width, most := 0, 0
+	for _, line := range strings.Split(strings.TrimSpace(numstat), "\n") {
+		f := strings.SplitN(line, "\t", 3)
+		if len(f) < 3 {
+			continue
+		}
+		s := stat{path: f[2], binary: f[0] == "-"}
+		fmt.Sscanf(f[0], "%d", &s.add)
+		fmt.Sscanf(f[1], "%d", &s.del)
+		s.changeCount = s.add + s.del
+		stats = append(stats, s)
+		width = max(width, stringCells(s.path))
+		most = max(most, s.changeCount)
+	}
+	if len(stats) == 0 {
+		return ""
+	}
+
+	const barWidth = 30
+	var sb strings.Builder
+	totalAdd, totalDel := 0, 0
+	for _, s := range stats {
+		totalAdd += s.add
+		totalDel += s.del
+		pad := strings.Repeat(" ", width-stringCells(s.path))
+		if s.binary {
+			fmt.Fprintf(&sb, "%s%s%s | %sbinary%s\n", indent, s.path, pad, Dim, Reset)
+			continue
+		}
+		add, del := s.add, s.del
+		if most > barWidth {
+			add = (s.add*barWidth + most - 1) / most
+			del = (s.del*barWidth + most - 1) / most
+		}
+		fmt.Fprintf(&sb, "%s%s%s | %5d %s%s%s%s%s%s\n", indent, s.path, pad, s.changeCount,
+			Bold+resolveColor(c.Added), strings.Repeat("+", add), Reset,
+			Bold+resolveColor(c.Deleted), strings.Repeat("-", del), Reset)
+	}
+	fmt.Fprintf(&sb, "%s%s%d file(s) changed, %s%d insertion(s)(+)%s, %s%d deletion(s)(-)%s\n", indent,
+		Dim, len(stats), Reset+resolveColor(c.Added), totalAdd, Reset, resolveColor(c.Deleted), totalDel, Reset)
+	return sb.String()
+}
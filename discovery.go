@@ -0,0 +1,163 @@
+// File: discovery.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: repository discovery with an on-disk cache of walked directories
+// License: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// forceRediscovery makes discovery ignore cached directory listings
+// (`gits scan --refresh`).
+var forceRediscovery bool
+
+// discoveryNode remembers what a directory contained the last time it was
+// read.  A directory's mtime changes whenever an entry is added, removed or
+// renamed in it, so an unchanged mtime means the listing is still valid.
+type discoveryNode struct {
+	Mtime   int64    `json:"m"`
+	Repo    bool     `json:"r,omitempty"`
+	Subdirs []string `json:"s,omitempty"` // children worth descending into
+}
+
+// discoveryCache maps absolute directory paths to their cached listing.
+type discoveryCache struct {
+	Dirs map[string]discoveryNode `json:"dirs"`
+
+	dirty       bool
+	reads, hits int
+	// visitedRoots holds, for each root walked, the directories seen and
+	// whether everything below each was walked too.
+	visitedRoots map[string]map[string]bool
+}
+
+func discoveryCachePath() string {
+	return stateFile("discovery-cache.json")
+}
+
+func loadDiscoveryCache() *discoveryCache {
+	dc := &discoveryCache{Dirs: map[string]discoveryNode{}, visitedRoots: map[string]map[string]bool{}}
+	if forceRediscovery {
+		return dc
+	}
+	if data, err := os.ReadFile(discoveryCachePath()); err == nil {
+		json.Unmarshal(data, dc)
+		if dc.Dirs == nil {
+			dc.Dirs = map[string]discoveryNode{}
+		}
+	}
+	return dc
+}
+
+// save prunes entries below walked roots that were not seen this time
+// (deleted directories) and writes the cache if anything changed.  Entries
+// deeper than a walk went are kept for a deeper one: a directory not seen
+// is only gone when the nearest one above it that was seen was walked
+// below, or no longer lists the way down to it.
+func (dc *discoveryCache) save() {
+	for root, seen := range dc.visitedRoots {
+		prefix := root + string(filepath.Separator)
+		for dir := range dc.Dirs {
+			if _, ok := seen[dir]; ok || !strings.HasPrefix(dir, prefix) {
+				continue
+			}
+			gone := true
+			for next, up := dir, filepath.Dir(dir); len(up) >= len(root); next, up = up, filepath.Dir(up) {
+				if below, ok := seen[up]; ok {
+					gone = below || !slices.Contains(dc.Dirs[up].Subdirs, filepath.Base(next))
+					break
+				}
+			}
+			if gone {
+				delete(dc.Dirs, dir)
+				dc.dirty = true
+			}
+		}
+	}
+	if debugMode {
+		fmt.Fprintf(os.Stderr, "discovery: %d directories read, %d served from cache\n", dc.reads, dc.hits)
+	}
+	if !dc.dirty {
+		return
+	}
+	if data, err := json.Marshal(dc); err == nil {
+		writeFileAtomic(discoveryCachePath(), data, 0o644)
+	}
+}
+
+// readDiscoveryNode lists dir, noting whether it is a repository and which
+// subdirectories may contain more repositories.
+func readDiscoveryNode(dir string, mtime int64) discoveryNode {
+	node := discoveryNode{Mtime: mtime}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return node
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if name == ".git" {
+			node.Repo = true
+			node.Subdirs = nil
+			return node
+		}
+		// Symlinked directories are not followed (IsDir is false for them).
+		if e.IsDir() && !skipScanDirs[name] && !strings.HasPrefix(name, ".") {
+			node.Subdirs = append(node.Subdirs, name)
+		}
+	}
+	return node
+}
+
+// walk visits dir (depth levels below the root) and collects repositories.
+func (dc *discoveryCache) walk(root, dir string, depth, maxDepth int, repos *[]string) {
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return
+	}
+	mtime := info.ModTime().UnixNano()
+	node, ok := dc.Dirs[dir]
+	if ok && node.Mtime == mtime {
+		dc.hits++
+	} else {
+		node = readDiscoveryNode(dir, mtime)
+		dc.Dirs[dir] = node
+		dc.reads++
+		dc.dirty = true
+	}
+	// A repository's contents are not walked, so nothing is kept below it.
+	below := node.Repo || maxDepth <= 0 || depth < maxDepth
+	dc.visitedRoots[root][dir] = dc.visitedRoots[root][dir] || below
+
+	if node.Repo {
+		*repos = append(*repos, dir)
+		return
+	}
+	if maxDepth > 0 && depth >= maxDepth {
+		return
+	}
+	for _, sub := range node.Subdirs {
+		dc.walk(root, filepath.Join(dir, sub), depth+1, maxDepth, repos)
+	}
+}
+
+// discoverRepos finds git working trees under root, up to maxDepth levels
+// deep.  Once a repository is found its contents are not walked.
+func (dc *discoveryCache) discoverRepos(root string, maxDepth int) []string {
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+	if dc.visitedRoots[root] == nil {
+		dc.visitedRoots[root] = map[string]bool{}
+	}
+	var repos []string
+	dc.walk(root, root, 0, maxDepth, &repos)
+	return repos
+}
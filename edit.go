@@ -0,0 +1,108 @@
+// File: edit.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: `gits edit`: open the changed files in the editor
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// runEdit implements `gits edit [--staged|--unstaged|--untracked|--conflicts]
+// [--filter GLOB] [path]`: the changed files that are still on disk open
+// in git's editor, all in one invocation.  Conflicted files open at their
+// first conflict marker.  GLOB is matched against the path and against the
+// file name, so `--filter '*.go'` reaches into subdirectories.
+func runEdit(status *Status, args []string) {
+	c := status.cfg.Colors
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	keep, args := fileFilter(args)
+	var globs []string
+	dir := "."
+	for i := 0; i < len(args); i++ {
+		switch a := args[i]; {
+		case a == "--filter":
+			if i+1 >= len(args) {
+				fail(fmt.Errorf("usage: gits edit [--staged|--unstaged|--untracked|--conflicts] [--filter GLOB] [path]"))
+			}
+			i++
+			globs = append(globs, args[i])
+		case strings.HasPrefix(a, "--filter="):
+			globs = append(globs, strings.TrimPrefix(a, "--filter="))
+		default:
+			dir = a
+		}
+	}
+	for _, g := range globs {
+		if _, err := path.Match(g, ""); err != nil {
+			fail(fmt.Errorf("bad --filter pattern %q: %v", g, err))
+		}
+	}
+	matches := func(p string) bool {
+		if len(globs) == 0 {
+			return true
+		}
+		p = filepath.ToSlash(p)
+		for _, g := range globs {
+			if ok, _ := path.Match(g, p); ok {
+				return true
+			}
+			if ok, _ := path.Match(g, path.Base(p)); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	files, err := changedFiles(dir, keep)
+	if err != nil {
+		fail(err)
+	}
+	var targets []editTarget
+	conflicts := 0
+	for _, f := range files {
+		if !matches(f.Path) || !IsFile(f.Path) {
+			continue
+		}
+		t := editTarget{Path: f.Path}
+		if f.Entry.Kind == "unmerged" {
+			if hunks, first, err := conflictMarkers(f.Path); err == nil && hunks > 0 {
+				t.Line = first
+				conflicts++
+			}
+		}
+		targets = append(targets, t)
+	}
+	if len(targets) == 0 {
+		fmt.Printf("%s %sNo changed files to edit%s\n", Icons.INFO, Dim, Reset)
+		return
+	}
+
+	editor, _ := gitOutput(dir, "var", "GIT_EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	noun := "files"
+	if len(targets) == 1 {
+		noun = "file"
+	}
+	fmt.Printf("%s Opening %d %s in %s", Icons.INFO, len(targets), noun, filepath.Base(strings.Fields(editor)[0]))
+	if conflicts > 0 {
+		fmt.Printf(" %s(%d at their first conflict)%s", Dim, conflicts, Reset)
+	}
+	fmt.Println()
+	cmd := exec.Command("sh", "-c", editorCommand(editor, targets))
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		fail(fmt.Errorf("%s: %v", editor, err))
+	}
+}
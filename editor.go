@@ -0,0 +1,88 @@
+// File: editor.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: `gits --editor-mode`: a stable, line-oriented status for editor plugins
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// editorFormat is the version in the first line of --editor-mode output.
+// It changes only when a line changes meaning; new kinds of lines may be
+// added without it, and readers skip kinds they do not know.
+const editorFormat = 1
+
+// editorPath writes a path as it is unless it holds a tab, a newline, a
+// backslash or a leading double quote, which would break the line; then
+// it is quoted the way Go and C quote strings.
+func editorPath(p string) string {
+	if p == "" || (!strings.ContainsAny(p, "\t\n\r\\") && p[0] != '"') {
+		return p
+	}
+	return strconv.Quote(p)
+}
+
+// runEditorMode implements `gits --editor-mode [DIR]`: the status of the
+// repository holding DIR as tab-separated lines with no colors or icons,
+// meant for sign columns and file explorers.  The lines are:
+//
+//	gits-editor <version>
+//	root <absolute worktree path>
+//	branch <name> <upstream> <ahead> <behind>
+//	<offset> <XY> <staged> <path> <original path>
+//	end <offset> <entries>
+//
+// <offset> is the byte offset at which the line starts in the output, so
+// a reader that gets it in pieces can tell it has every line; the end
+// line's offset is the size of everything before it.
+func runEditorMode(status *Status, args []string) {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	offset := 0
+	line := func(fields ...string) {
+		n, _ := fmt.Fprintln(w, strings.Join(fields, "\t"))
+		offset += n
+	}
+
+	line("gits-editor", strconv.Itoa(editorFormat))
+	root, err := repoRoot(dir)
+	if err != nil {
+		line("error", strings.ReplaceAll(err.Error(), "\n", " "))
+		w.Flush()
+		exit(1)
+	}
+	rs := CollectStatus(root)
+	if rs.Err != "" {
+		line("error", strings.ReplaceAll(rs.Err, "\n", " "))
+		w.Flush()
+		exit(1)
+	}
+	line("root", editorPath(filepath.ToSlash(root)))
+	line("branch", rs.Branch, rs.Upstream, strconv.Itoa(rs.Ahead), strconv.Itoa(rs.Behind))
+
+	entries := 0
+	for _, e := range rs.Entries {
+		if e.Kind == "ignored" {
+			continue
+		}
+		staged := "0"
+		if e.Staged() {
+			staged = "1"
+		}
+		line(strconv.Itoa(offset), e.Index+e.Worktree, staged, editorPath(e.Path), editorPath(e.OrigPath))
+		entries++
+	}
+	line("end", strconv.Itoa(offset), strconv.Itoa(entries))
+}
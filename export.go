@@ -0,0 +1,301 @@
+// File: export.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: source archives from a ref (`gits export`)
+// License: MIT
+
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// archiveWriter is the zip or tar side of an export.
+type archiveWriter interface {
+	add(hdr *tar.Header, body io.Reader) error
+	Close() error
+}
+
+// tarArchive writes a (optionally gzipped) tarball.
+type tarArchive struct {
+	tw *tar.Writer
+	gz *gzip.Writer
+}
+
+func (a *tarArchive) add(hdr *tar.Header, body io.Reader) error {
+	if err := a.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if hdr.Typeflag == tar.TypeReg {
+		_, err := io.Copy(a.tw, body)
+		return err
+	}
+	return nil
+}
+
+func (a *tarArchive) Close() error {
+	err := a.tw.Close()
+	if a.gz != nil {
+		if gzErr := a.gz.Close(); err == nil {
+			err = gzErr
+		}
+	}
+	return err
+}
+
+// zipArchive writes a zip file.
+type zipArchive struct{ zw *zip.Writer }
+
+func (a *zipArchive) add(hdr *tar.Header, body io.Reader) error {
+	fh, err := zip.FileInfoHeader(hdr.FileInfo())
+	if err != nil {
+		return err
+	}
+	fh.Name = hdr.Name
+	fh.Modified = hdr.ModTime
+	if hdr.Typeflag == tar.TypeDir {
+		if !strings.HasSuffix(fh.Name, "/") {
+			fh.Name += "/"
+		}
+		_, err = a.zw.CreateHeader(fh)
+		return err
+	}
+	fh.Method = zip.Deflate
+	w, err := a.zw.CreateHeader(fh)
+	if err != nil {
+		return err
+	}
+	if hdr.Typeflag == tar.TypeSymlink {
+		_, err = io.WriteString(w, hdr.Linkname)
+		return err
+	}
+	_, err = io.Copy(w, body)
+	return err
+}
+
+func (a *zipArchive) Close() error { return a.zw.Close() }
+
+// exportFormat normalizes a format name, guessing from out when empty.
+func exportFormat(format, out string) (string, error) {
+	if format == "" {
+		switch {
+		case strings.HasSuffix(out, ".zip"):
+			return "zip", nil
+		case strings.HasSuffix(out, ".tar"):
+			return "tar", nil
+		}
+		return "tar.gz", nil
+	}
+	switch format {
+	case "zip", "tar":
+		return format, nil
+	case "tar.gz", "tgz", "gz":
+		return "tar.gz", nil
+	}
+	return "", fmt.Errorf("unknown format %q (zip, tar.gz, tar)", format)
+}
+
+// exportProgress redraws "N/M files" on stderr when it is a terminal.
+type exportProgress struct {
+	done, total int
+	last        time.Time
+}
+
+func (p *exportProgress) step(c ColorConfig) {
+	p.done++
+	if !stdoutIsTerminal || time.Since(p.last) < 50*time.Millisecond {
+		return
+	}
+	p.last = time.Now()
+	fmt.Fprintf(os.Stderr, "\r\x1b[K📦 %sArchiving%s %d/%d file(s)", resolveColor(c.Hint), Reset, p.done, max(p.total, p.done))
+}
+
+// runExport implements `gits export [--ref REF] [--format zip|tar.gz|tar]
+// [--untracked] [OUT]`.
+func runExport(status *Status, args []string) {
+	c := status.cfg.Colors
+	ref, format, out := "HEAD", "", ""
+	untracked := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--ref":
+			if i+1 < len(args) {
+				i++
+				ref = args[i]
+			}
+		case "--format":
+			if i+1 < len(args) {
+				i++
+				format = args[i]
+			}
+		case "--untracked":
+			untracked = true
+		default:
+			out = args[i]
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	if _, err := gitOutput(root, "rev-parse", "--verify", "--quiet", ref+"^{tree}"); err != nil {
+		fail(fmt.Errorf("unknown ref %q", ref))
+	}
+	format, err = exportFormat(format, out)
+	if err != nil {
+		fail(err)
+	}
+
+	// Name: <repo>-<ref>, where HEAD becomes its describe name (v1.2.0-3-gabc).
+	label := ref
+	if ref == "HEAD" {
+		label, _ = gitOutput(root, "describe", "--tags", "--always")
+	}
+	label = strings.NewReplacer("/", "-", "\\", "-", ":", "-", " ", "-").Replace(label)
+	name := filepath.Base(root) + "-" + label
+	if out == "" {
+		out = name + "." + format
+	} else {
+		name = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(filepath.Base(out), ".zip"), ".gz"), ".tar")
+	}
+	if Exists(out) {
+		fail(fmt.Errorf("%s already exists", out))
+	}
+
+	var extra []string
+	if untracked {
+		list, err := gitRaw(root, "ls-files", "--others", "--exclude-standard", "-z")
+		if err != nil {
+			fail(err)
+		}
+		for _, p := range strings.Split(list, "\x00") {
+			if p != "" {
+				extra = append(extra, p)
+			}
+		}
+	}
+	progress := exportProgress{}
+	if files, err := gitRaw(root, "ls-tree", "-r", "-z", "--name-only", ref); err == nil {
+		progress.total = strings.Count(files, "\x00") + len(extra)
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		fail(err)
+	}
+	var w archiveWriter
+	switch format {
+	case "zip":
+		w = &zipArchive{zip.NewWriter(f)}
+	case "tar":
+		w = &tarArchive{tw: tar.NewWriter(f)}
+	default:
+		gz := gzip.NewWriter(f)
+		w = &tarArchive{tw: tar.NewWriter(gz), gz: gz}
+	}
+	abort := func(err error) {
+		f.Close()
+		os.Remove(out)
+		if stdoutIsTerminal {
+			fmt.Fprint(os.Stderr, "\r\x1b[K")
+		}
+		fail(err)
+	}
+
+	// git archive honours export-ignore/export-subst; its tar stream is
+	// re-packed so zip and tar.gz share one path with progress.
+	cmd := gitCmd(root, "archive", "--format=tar", "--prefix="+name+"/", ref)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		abort(err)
+	}
+	if err := cmd.Start(); err != nil {
+		abort(err)
+	}
+	tr := tar.NewReader(stdout)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			abort(err)
+		}
+		if hdr.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+		if err := w.add(hdr, tr); err != nil {
+			abort(err)
+		}
+		if hdr.Typeflag != tar.TypeDir {
+			progress.step(c)
+		}
+	}
+	if err := cmd.Wait(); err != nil {
+		abort(fmt.Errorf("git archive failed: %v", err))
+	}
+
+	for _, p := range extra {
+		full := filepath.Join(root, p)
+		info, err := os.Lstat(full)
+		if err != nil || !(info.Mode().IsRegular() || info.Mode()&os.ModeSymlink != 0) {
+			continue
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, _ = os.Readlink(full)
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			abort(err)
+		}
+		hdr.Name = name + "/" + filepath.ToSlash(p)
+		var file *os.File
+		var body io.Reader = strings.NewReader("")
+		if info.Mode().IsRegular() {
+			if file, err = os.Open(full); err != nil {
+				abort(err)
+			}
+			body = file
+		}
+		err = w.add(hdr, body)
+		if file != nil {
+			file.Close()
+		}
+		if err != nil {
+			abort(err)
+		}
+		progress.step(c)
+	}
+	if err := w.Close(); err != nil {
+		abort(err)
+	}
+	if err := f.Close(); err != nil {
+		abort(err)
+	}
+	if stdoutIsTerminal {
+		fmt.Fprint(os.Stderr, "\r\x1b[K")
+	}
+
+	size := int64(0)
+	if info, err := os.Stat(out); err == nil {
+		size = info.Size()
+	}
+	fmt.Printf("%s %sExported%s %s%s%s %s(%s, %d file(s), %s)%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset,
+		Bold, out, Reset, Dim, ref, progress.done, humanSize(size), Reset)
+	if len(extra) > 0 {
+		fmt.Printf("   %sincluding %d untracked file(s)%s\n", Dim, len(extra), Reset)
+	}
+}
@@ -0,0 +1,169 @@
+// File: exporter.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: Prometheus metrics exporter mode (`gits exporter`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExporterConfig controls `gits exporter`.
+type ExporterConfig struct {
+	Listen   string   `toml:"listen"`
+	Interval string   `toml:"interval"`
+	Targets  []string `toml:"targets"` // same syntax as `gits scan` arguments
+}
+
+// metricsStore holds the latest scan results served on /metrics.
+type metricsStore struct {
+	mu       sync.RWMutex
+	results  []*RepoStatus
+	lastScan time.Time
+	duration time.Duration
+	scanErr  string
+}
+
+func (m *metricsStore) update(results []*RepoStatus, took time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastScan = time.Now()
+	m.duration = took
+	m.scanErr = ""
+	if err != nil {
+		m.scanErr = err.Error()
+		return
+	}
+	m.results = results
+}
+
+// promEscape escapes a Prometheus label value.
+func promEscape(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	return strings.ReplaceAll(v, "\n", `\n`)
+}
+
+// writeMetrics renders the store in the Prometheus text exposition format.
+func (m *metricsStore) writeMetrics(w *strings.Builder) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	type gauge struct {
+		name, help string
+		value      func(r *RepoStatus) int
+	}
+	gauges := []gauge{
+		{"gits_repo_up", "1 if git status succeeded for the repository.", func(r *RepoStatus) int {
+			if r.Err != "" {
+				return 0
+			}
+			return 1
+		}},
+		{"gits_dirty_files", "Changed files (staged, modified, untracked, conflicted).", func(r *RepoStatus) int {
+			return r.Staged + r.Modified + r.Untracked + r.Conflicts
+		}},
+		{"gits_staged_files", "Files with staged changes.", func(r *RepoStatus) int { return r.Staged }},
+		{"gits_modified_files", "Files with unstaged changes.", func(r *RepoStatus) int { return r.Modified }},
+		{"gits_untracked_files", "Untracked files.", func(r *RepoStatus) int { return r.Untracked }},
+		{"gits_conflicted_files", "Files with unresolved merge conflicts.", func(r *RepoStatus) int { return r.Conflicts }},
+		{"gits_ahead", "Commits ahead of the upstream branch.", func(r *RepoStatus) int { return r.Ahead }},
+		{"gits_behind", "Commits behind the upstream branch.", func(r *RepoStatus) int { return r.Behind }},
+		{"gits_stash_count", "Entries in the stash.", func(r *RepoStatus) int { return r.Stashes }},
+	}
+
+	for _, g := range gauges {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name)
+		for _, r := range m.results {
+			fmt.Fprintf(w, "%s{repo=\"%s\",branch=\"%s\"} %d\n",
+				g.name, promEscape(r.Path), promEscape(r.Branch), g.value(r))
+		}
+	}
+
+	fmt.Fprintf(w, "# HELP gits_scan_duration_seconds Duration of the last scan.\n# TYPE gits_scan_duration_seconds gauge\n")
+	fmt.Fprintf(w, "gits_scan_duration_seconds %g\n", m.duration.Seconds())
+	fmt.Fprintf(w, "# HELP gits_last_scan_timestamp_seconds Unix time of the last scan.\n# TYPE gits_last_scan_timestamp_seconds gauge\n")
+	fmt.Fprintf(w, "gits_last_scan_timestamp_seconds %d\n", m.lastScan.Unix())
+	scanOK := 1
+	if m.scanErr != "" {
+		scanOK = 0
+	}
+	fmt.Fprintf(w, "# HELP gits_scan_success 1 if the last scan could resolve its targets.\n# TYPE gits_scan_success gauge\n")
+	fmt.Fprintf(w, "gits_scan_success %d\n", scanOK)
+}
+
+// runExporter implements `gits exporter [--listen ADDR] [--interval DUR] [targets...]`.
+func runExporter(status *Status, args []string) {
+	c := status.cfg.Colors
+	ec := status.cfg.Exporter
+	listen := ec.Listen
+	interval := parseDurationOr(ec.Interval, time.Minute)
+	targets := ec.Targets
+
+	var argTargets []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--listen", "-l":
+			if i+1 < len(args) {
+				i++
+				listen = args[i]
+			}
+		case "--interval":
+			if i+1 < len(args) {
+				i++
+				interval = parseDurationOr(args[i], interval)
+			}
+		default:
+			argTargets = append(argTargets, args[i])
+		}
+	}
+	if len(argTargets) > 0 {
+		targets = argTargets
+	}
+	if listen == "" {
+		listen = ":9321"
+	}
+
+	store := &metricsStore{}
+	scan := func() {
+		start := time.Now()
+		repos, err := resolveScanTargets(status.cfg.Groups, targets, 6)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gits exporter: %v\n", err)
+			store.update(nil, time.Since(start), err)
+			return
+		}
+		store.update(collectAll(repos), time.Since(start), nil)
+	}
+	scan()
+	go func() {
+		for range time.Tick(interval) {
+			scan()
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		var sb strings.Builder
+		store.writeMetrics(&sb)
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		fmt.Fprint(w, sb.String())
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<html><body><h1>gits exporter</h1><p><a href=\"/metrics\">Metrics</a></p></body></html>\n")
+	})
+
+	fmt.Printf("%s %sgits exporter%s listening on %s%s%s (scan every %s)\n",
+		Icons.INFO, Bold+resolveColor(c.Header), Reset,
+		Bold+resolveColor(c.RemoteURL), listen, Reset, interval)
+	if err := http.ListenAndServe(listen, mux); err != nil {
+		exitWithError(err, c)
+	}
+}
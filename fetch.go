@@ -0,0 +1,285 @@
+// File: fetch.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: parallel `git fetch` across repositories and remotes (`gits fetch`)
+// License: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// nonInteractiveGitEnv makes git fail fast instead of prompting for
+// credentials, which would hang an unattended fleet fetch.
+func nonInteractiveGitEnv() []string {
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GCM_INTERACTIVE=never")
+	if os.Getenv("GIT_SSH_COMMAND") == "" {
+		env = append(env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes -o ConnectTimeout=10")
+	}
+	return env
+}
+
+// classifyFetchError turns git's stderr into a short, human reason.
+func classifyFetchError(stderr string) string {
+	lower := strings.ToLower(stderr)
+	switch {
+	case strings.Contains(lower, "authentication failed"),
+		strings.Contains(lower, "could not read username"),
+		strings.Contains(lower, "could not read password"),
+		strings.Contains(lower, "permission denied (publickey"),
+		strings.Contains(lower, "terminal prompts disabled"):
+		return "credentials required"
+	case strings.Contains(lower, "host key verification failed"):
+		return "unknown SSH host key"
+	case strings.Contains(lower, "could not resolve host"):
+		return "host not reachable"
+	case strings.Contains(lower, "repository not found"), strings.Contains(lower, "does not appear to be a git repository"):
+		return "remote repository not found"
+	}
+	if line := firstLine(strings.TrimSpace(stderr)); line != "" {
+		return strings.TrimPrefix(line, "fatal: ")
+	}
+	return "fetch failed"
+}
+
+// fetchRepo runs `git fetch --all --prune` in dir, giving up after timeout.
+func fetchRepo(dir string, timeout time.Duration) error {
+	return fetchWithTimeout(dir, timeout, "--all", "--prune", "--quiet")
+}
+
+// fetchWithTimeout runs `git fetch args...` in dir without prompting,
+// killing it after timeout.  Errors carry a classified, short reason.
+func fetchWithTimeout(dir string, timeout time.Duration, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := gitCmd(dir, append([]string{"fetch"}, args...)...)
+	cmd.Env = nonInteractiveGitEnv()
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return errors.New(classifyFetchError(stderr.String()))
+		}
+		return nil
+	case <-ctx.Done():
+		cmd.Process.Kill()
+		<-done
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+}
+
+// fetchAll fetches every repository with at most jobs concurrent fetches,
+// showing progress on stderr.  It returns the error for each repo path.
+func fetchAll(repos []string, jobs int, timeout time.Duration) map[string]error {
+	errs := make([]error, len(repos))
+	var done int32
+	var mu sync.Mutex
+	tp := newTermProgress()
+	progress := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		n := int(atomic.LoadInt32(&done))
+		fmt.Fprintf(os.Stderr, "\r%s fetching %d/%d", Icons.REMOTE, n, len(repos))
+		tp.set(100 * n / max(len(repos), 1))
+	}
+	progress()
+	parallelEachN(len(repos), jobs, func(i int) {
+		errs[i] = fetchRepo(repos[i], timeout)
+		atomic.AddInt32(&done, 1)
+		progress()
+	})
+	tp.done()
+	fmt.Fprint(os.Stderr, "\r\033[K")
+
+	result := map[string]error{}
+	for i, err := range errs {
+		if err != nil {
+			result[repos[i]] = err
+		}
+	}
+	return result
+}
+
+// ---------------------------------------------------------------------------
+// `gits fetch` subcommand
+// ---------------------------------------------------------------------------
+
+// refChange is one remote-tracking ref or tag touched by a fetch.
+type refChange struct {
+	Ref      string // short name, e.g. "origin/main" or "v1.2.0"
+	Old, New string // object ids; empty when created or pruned
+}
+
+// snapshotRefs maps every ref under the given prefixes to its object id.
+func snapshotRefs(dir string, prefixes ...string) map[string]string {
+	out, _ := gitOutput(dir, append([]string{"for-each-ref", "--format=%(refname) %(objectname)"}, prefixes...)...)
+	refs := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		if name, oid, ok := strings.Cut(line, " "); ok {
+			refs[name] = oid
+		}
+	}
+	return refs
+}
+
+// diffRefs returns the refs that differ between two snapshots, sorted.
+func diffRefs(before, after map[string]string) []string {
+	var changed []string
+	for name, oid := range after {
+		if before[name] != oid {
+			changed = append(changed, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// printRefChange renders one moved, new or pruned ref.
+func printRefChange(dir string, ch refChange, c ColorConfig) {
+	name := padCells(ch.Ref, 24, false)
+	switch {
+	case ch.Old == "":
+		fmt.Printf("     %s%s%s %snew%s\n", Bold+resolveColor(c.Branch), name, Reset, resolveColor(c.NewFile), Reset)
+	case ch.New == "":
+		fmt.Printf("     %s%s%s %spruned (deleted on the remote, was %s)%s\n",
+			Bold+resolveColor(c.Branch), name, Reset, resolveColor(c.Deleted), shortOid(ch.Old), Reset)
+	default:
+		rangeSpec := shortOid(ch.Old) + ".." + shortOid(ch.New)
+		detail := ""
+		if _, err := gitOutput(dir, "merge-base", "--is-ancestor", ch.Old, ch.New); err != nil {
+			rangeSpec = shortOid(ch.Old) + "..." + shortOid(ch.New)
+			detail = Bold + resolveColor(c.AheadBehind) + "forced update" + Reset
+		} else if n, err := gitOutput(dir, "rev-list", "--count", ch.Old+".."+ch.New); err == nil {
+			detail = Bold + resolveColor(c.AheadBehind) + "↓" + n + Reset + " new commit(s)"
+		}
+		fmt.Printf("     %s%s%s %s%s%s  %s\n", Bold+resolveColor(c.Branch), name, Reset, Dim, rangeSpec, Reset, detail)
+	}
+}
+
+// runFetch implements `gits fetch [--timeout DUR] [remote...]`: fetch every
+// remote in parallel (with pruning) and report which refs moved.
+func runFetch(status *Status, args []string) {
+	c := status.cfg.Colors
+	timeout := 2 * time.Minute
+	var remotes []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--timeout":
+			if i+1 < len(args) {
+				i++
+				timeout = parseDurationOr(args[i], timeout)
+			}
+		default:
+			remotes = append(remotes, args[i])
+		}
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		exitWithError(err, c)
+	}
+	if len(remotes) == 0 {
+		out, _ := gitOutput(root, "remote")
+		remotes = strings.Fields(out)
+	}
+	if len(remotes) == 0 {
+		fmt.Printf("%s %sNo remotes configured%s\n", Icons.WARNING, resolveColor(c.AheadBehind), Reset)
+		return
+	}
+
+	before := snapshotRefs(root, "refs/remotes", "refs/tags")
+	errs := make([]error, len(remotes))
+	fmt.Fprintf(os.Stderr, "%s fetching %s…", Icons.REMOTE, strings.Join(remotes, ", "))
+	tp := newTermProgress()
+	var mu sync.Mutex
+	fetched := 0
+	// Each fetch skips FETCH_HEAD so the parallel runs do not overwrite it.
+	parallelEach(len(remotes), func(i int) {
+		errs[i] = fetchWithTimeout(root, timeout, "--prune", "--quiet", "--no-write-fetch-head", remotes[i])
+		mu.Lock()
+		fetched++
+		tp.set(100 * fetched / len(remotes))
+		mu.Unlock()
+	})
+	tp.done()
+	fmt.Fprint(os.Stderr, "\r\033[K")
+	after := snapshotRefs(root, "refs/remotes", "refs/tags")
+
+	perRemote := map[string][]refChange{}
+	var tags []refChange
+	for _, ref := range diffRefs(before, after) {
+		ch := refChange{Old: before[ref], New: after[ref]}
+		if t, ok := strings.CutPrefix(ref, "refs/tags/"); ok {
+			ch.Ref = t
+			tags = append(tags, ch)
+			continue
+		}
+		ch.Ref = strings.TrimPrefix(ref, "refs/remotes/")
+		remote, _, _ := strings.Cut(ch.Ref, "/")
+		perRemote[remote] = append(perRemote[remote], ch)
+	}
+
+	failed := false
+	for i, remote := range remotes {
+		url, _ := gitOutput(root, "remote", "get-url", remote)
+		fmt.Printf("%s %s%s%s %s%s%s  ", Icons.REMOTE, Bold+resolveColor(c.Header), remote, Reset,
+			resolveColor(c.RemoteURL), url, Reset)
+		if errs[i] != nil {
+			failed = true
+			fmt.Printf("%s %s%v%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), errs[i], Reset)
+			continue
+		}
+		changes := perRemote[remote]
+		if len(changes) == 0 {
+			fmt.Printf("%s %sup to date%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+			continue
+		}
+		updated, created, pruned := 0, 0, 0
+		for _, ch := range changes {
+			switch {
+			case ch.Old == "":
+				created++
+			case ch.New == "":
+				pruned++
+			default:
+				updated++
+			}
+		}
+		fmt.Printf("%s %d updated, %d new, %d pruned\n", Icons.SUCCESS, updated, created, pruned)
+		for _, ch := range changes {
+			printRefChange(root, ch, c)
+		}
+	}
+	if len(tags) > 0 {
+		fmt.Printf("%s %stags%s  %d changed\n", Icons.INFO, Bold+resolveColor(c.Header), Reset, len(tags))
+		for _, ch := range tags {
+			printRefChange(root, ch, c)
+		}
+	}
+	if failed {
+		exit(1)
+	}
+}
@@ -0,0 +1,228 @@
+// File: files.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: plain file lists for fuzzy finders (`gits files`, `gits pick-file`)
+// License: MIT
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// changedFile is one path of `gits files`, relative to the current
+// directory.
+type changedFile struct {
+	Path  string
+	Entry FileEntry
+}
+
+// changedFiles lists the changed paths of the repository holding dir that
+// keep reports true for, relative to the current directory.  Untracked
+// directories are listed file by file, since a finder picks files.
+func changedFiles(dir string, keep func(FileEntry) bool) ([]changedFile, error) {
+	root, err := repoRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	rs := CollectStatus(root)
+	if rs.Err != "" {
+		return nil, errors.New(rs.Err)
+	}
+	cwd := workingDir(".")
+	rel := func(p string) string {
+		full := filepath.Join(root, filepath.FromSlash(p))
+		if r, err := relPath(cwd, full); err == nil {
+			return r
+		}
+		return full
+	}
+
+	var files []changedFile
+	for _, e := range rs.Entries {
+		if e.Kind == "ignored" || !keep(e) {
+			continue
+		}
+		if e.Kind == "untracked" && strings.HasSuffix(e.Path, "/") {
+			out, err := gitRaw(root, "ls-files", "--others", "--exclude-standard", "-z", "--", e.Path)
+			if err == nil {
+				for _, p := range strings.Split(out, "\x00") {
+					if p != "" {
+						files = append(files, changedFile{rel(p), FileEntry{Path: p, Index: "?", Worktree: "?", Kind: "untracked"}})
+					}
+				}
+				continue
+			}
+		}
+		files = append(files, changedFile{rel(e.Path), e})
+	}
+	return files, nil
+}
+
+// fileFilter turns the --staged, --unstaged, --untracked and --conflicts
+// flags in args into a filter, returning the arguments left over.  With
+// none of them every changed path passes.
+func fileFilter(args []string) (func(FileEntry) bool, []string) {
+	want := map[string]bool{}
+	var rest []string
+	for _, a := range args {
+		switch a {
+		case "--staged", "--unstaged", "--untracked", "--conflicts":
+			want[a[2:]] = true
+		default:
+			rest = append(rest, a)
+		}
+	}
+	if len(want) == 0 {
+		return func(FileEntry) bool { return true }, rest
+	}
+	return func(e FileEntry) bool {
+		return want["staged"] && e.Staged() || want["unstaged"] && e.Unstaged() ||
+			want["untracked"] && e.Kind == "untracked" || want["conflicts"] && e.Kind == "unmerged"
+	}, rest
+}
+
+// runFiles implements `gits files [--print0] [--staged] [--unstaged]
+// [--untracked] [--conflicts] [path]`: the changed files, one per line
+// (NUL-terminated with --print0), for xargs and fuzzy finders.
+func runFiles(status *Status, args []string) {
+	c := status.cfg.Colors
+	keep, args := fileFilter(args)
+	sep := "\n"
+	dir := "."
+	for _, a := range args {
+		switch a {
+		case "--print0", "-z":
+			sep = "\x00"
+		default:
+			dir = a
+		}
+	}
+	files, err := changedFiles(dir, keep)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %s%v%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), err, Reset)
+		exit(1)
+	}
+	var sb strings.Builder
+	for _, f := range files {
+		// One per line, a name with a newline or quote is quoted as git
+		// quotes it; --print0 passes every name as it is.
+		if sep == "\n" {
+			sb.WriteString(quoteGitPath(f.Path))
+		} else {
+			sb.WriteString(f.Path)
+		}
+		sb.WriteString(sep)
+	}
+	fmt.Print(sb.String())
+}
+
+// onTerminal runs fn with stdin and stdout on the controlling terminal, so
+// a picker can be drawn while the real stdout goes to a pipe, as in
+// $(gits pick-file).
+func onTerminal(fn func()) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return errNotTerminal
+	}
+	defer tty.Close()
+	stdin, stdout := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = tty, tty
+	defer func() { os.Stdin, os.Stdout = stdin, stdout }()
+	fn()
+	return nil
+}
+
+// runPickFile implements `gits pick-file [--multi] [filters] [path]`: the
+// changed files go through fzf, or the built-in finder when fzf is not
+// installed, and the ones chosen are printed one per line.  It exits 1 when
+// nothing was chosen.
+func runPickFile(status *Status, args []string) {
+	c := status.cfg.Colors
+	keep, args := fileFilter(args)
+	multi := false
+	dir := "."
+	for _, a := range args {
+		switch a {
+		case "--multi", "-m":
+			multi = true
+		default:
+			dir = a
+		}
+	}
+	fail := func(err error) {
+		fmt.Fprintf(os.Stderr, "%s %s%v%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), err, Reset)
+		exit(1)
+	}
+	files, err := changedFiles(dir, keep)
+	if err != nil {
+		fail(err)
+	}
+	if len(files) == 0 {
+		fmt.Fprintf(os.Stderr, "%s %sNo changed files%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+		exit(1)
+	}
+
+	if fzf, err := exec.LookPath("fzf"); err == nil {
+		var list strings.Builder
+		for _, f := range files {
+			list.WriteString(f.Path + "\n")
+		}
+		fzfArgs := []string{"--prompt", "changed> ", "--preview", "git diff --color=always HEAD -- {} 2>/dev/null"}
+		if multi {
+			fzfArgs = append(fzfArgs, "--multi")
+		}
+		cmd := exec.Command(fzf, fzfArgs...)
+		cmd.Stdin = strings.NewReader(list.String())
+		cmd.Stderr = os.Stderr
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		// fzf exits 1 for no match and 130 when cancelled.
+		if err := cmd.Run(); err != nil || out.Len() == 0 {
+			exit(1)
+		}
+		fmt.Print(out.String())
+		return
+	}
+
+	items := make([]pickItem, len(files))
+	for i, f := range files {
+		code := f.Entry.Index + f.Entry.Worktree
+		items[i] = pickItem{Tag: code, TagStyle: resolveColor(c.Modified), Label: f.Path}
+	}
+	var chosen []string
+	var pickErr error
+	err = onTerminal(func() {
+		if multi {
+			checked, ok, err := runPicker("Pick changed files", items, c)
+			pickErr = err
+			for i := range checked {
+				if ok && checked[i] {
+					chosen = append(chosen, files[i].Path)
+				}
+			}
+			return
+		}
+		i, ok, err := fuzzyFind("Pick a changed file", "", items, c)
+		pickErr = err
+		if ok {
+			chosen = append(chosen, files[i].Path)
+		}
+	})
+	if err == nil {
+		err = pickErr
+	}
+	if err != nil {
+		fail(err)
+	}
+	if len(chosen) == 0 {
+		exit(1)
+	}
+	fmt.Println(strings.Join(chosen, "\n"))
+}
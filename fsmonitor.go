@@ -0,0 +1,345 @@
+// File: fsmonitor.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: detecting and enabling git's fsmonitor and untracked cache
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// slowStatus is how long `git status` may take before gits suggests
+// turning the acceleration on.
+const slowStatus = time.Second
+
+// statusAccel is the state of git's status acceleration in a repository.
+// The config says what is asked for; the index extensions say whether git
+// actually stored a fsmonitor token (FSMN) or an untracked cache (UNTR).
+type statusAccel struct {
+	FSMonitor      string // core.fsmonitor: "", "true" (built-in daemon) or a hook
+	UntrackedCache string // core.untrackedCache: "", "true", "false" or "keep"
+	ManyFiles      bool   // feature.manyFiles turns the untracked cache on
+	HasToken       bool   // the index holds a fsmonitor token
+	HasCache       bool   // the index holds an untracked cache
+	Entries        int    // paths in the index
+	IndexErr       error  // the index could not be read
+}
+
+// fsmonitorOn reports whether fsmonitor is configured.
+func (a statusAccel) fsmonitorOn() bool {
+	return a.FSMonitor != "" && a.FSMonitor != "false"
+}
+
+// untrackedOn reports whether the untracked cache is configured.
+func (a statusAccel) untrackedOn() bool {
+	switch a.UntrackedCache {
+	case "true":
+		return true
+	case "", "keep":
+		return a.ManyFiles || a.HasCache
+	}
+	return false
+}
+
+// unprimed reports whether acceleration is configured but git has not yet
+// written its state to the index.
+func (a statusAccel) unprimed() bool {
+	return a.IndexErr == nil && (a.fsmonitorOn() && !a.HasToken || a.untrackedOn() && !a.HasCache)
+}
+
+// describe is a one-line summary for --debug and `gits accel`.
+func (a statusAccel) describe() string {
+	state := func(on, stored bool, what string) string {
+		switch {
+		case !on:
+			return "off"
+		case stored:
+			return "active (" + what + " in the index)"
+		}
+		return "configured (nothing in the index yet)"
+	}
+	fsm := state(a.fsmonitorOn(), a.HasToken, "token")
+	if a.fsmonitorOn() && a.FSMonitor != "true" {
+		fsm += " via " + a.FSMonitor
+	}
+	return fmt.Sprintf("fsmonitor %s, untracked cache %s", fsm, state(a.untrackedOn(), a.HasCache, "cache"))
+}
+
+// readStatusAccel reads the acceleration settings of the repository at dir
+// and looks at which extensions its index carries.
+func readStatusAccel(dir string) statusAccel {
+	var a statusAccel
+	hashLen := 20
+	out, _ := gitOutput(dir, "config", "--get-regexp", `^(core\.(fsmonitor|untrackedcache)|feature\.manyfiles|extensions\.objectformat)$`)
+	for _, line := range strings.Split(out, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "core.fsmonitor":
+			a.FSMonitor = value
+		case "core.untrackedcache":
+			a.UntrackedCache = strings.ToLower(value)
+		case "feature.manyfiles":
+			a.ManyFiles = value == "true" || value == "1" || value == "yes" || value == "on"
+		case "extensions.objectformat":
+			if value == "sha256" {
+				hashLen = 32
+			}
+		}
+	}
+	path, err := gitOutput(dir, "rev-parse", "--path-format=absolute", "--git-path", "index")
+	if err != nil {
+		a.IndexErr = err
+		return a
+	}
+	exts, entries, err := indexExtensions(path, hashLen)
+	a.Entries, a.IndexErr = entries, err
+	a.HasToken, a.HasCache = exts["FSMN"], exts["UNTR"]
+	return a
+}
+
+// indexExtensions lists the extension signatures of the index file at path
+// and the number of entries it holds.  A missing index (a new repository)
+// is not an error.
+func indexExtensions(path string, hashLen int) (map[string]bool, int, error) {
+	exts := map[string]bool{}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return exts, 0, nil
+	}
+	if err != nil {
+		return exts, 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return exts, 0, err
+	}
+	r := bufio.NewReaderSize(f, 1<<16)
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil || string(header[:4]) != "DIRC" {
+		return exts, 0, fmt.Errorf("%s: not an index file", path)
+	}
+	version := binary.BigEndian.Uint32(header[4:8])
+	count := int(binary.BigEndian.Uint32(header[8:12]))
+	read := int64(12)
+
+	// Entries: stat data and the object id, then the flags and the name.
+	// Up to version 3 names are NUL-padded to a multiple of 8 bytes; in
+	// version 4 they are prefix-compressed and unpadded.
+	fixed := 40 + hashLen + 2
+	buf := make([]byte, fixed+2)
+	for i := 0; i < count; i++ {
+		if _, err := io.ReadFull(r, buf[:fixed]); err != nil {
+			return exts, count, fmt.Errorf("%s: truncated index", path)
+		}
+		size := fixed
+		flags := binary.BigEndian.Uint16(buf[fixed-2 : fixed])
+		if version >= 3 && flags&0x4000 != 0 {
+			if _, err := io.ReadFull(r, buf[fixed:fixed+2]); err != nil {
+				return exts, count, fmt.Errorf("%s: truncated index", path)
+			}
+			size += 2
+		}
+		if version >= 4 {
+			n, err := skipVarint(r)
+			if err != nil {
+				return exts, count, err
+			}
+			size += n
+		}
+		name, err := r.ReadBytes(0)
+		if err != nil {
+			return exts, count, fmt.Errorf("%s: truncated index", path)
+		}
+		size += len(name)
+		if version < 4 {
+			pad := (8 - size%8) % 8
+			if _, err := r.Discard(pad); err != nil {
+				return exts, count, fmt.Errorf("%s: truncated index", path)
+			}
+			size += pad
+		}
+		read += int64(size)
+	}
+
+	// Extensions, up to the trailing checksum.
+	var ext [8]byte
+	for read+8 <= info.Size()-int64(hashLen) {
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			break
+		}
+		exts[string(ext[:4])] = true
+		size := int64(binary.BigEndian.Uint32(ext[4:]))
+		if _, err := r.Discard(int(size)); err != nil {
+			break
+		}
+		read += 8 + size
+	}
+	return exts, count, nil
+}
+
+// skipVarint skips the prefix length git stores before version 4 names and
+// returns how many bytes it took.
+func skipVarint(r *bufio.Reader) (int, error) {
+	for n := 1; ; n++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return n, err
+		}
+		if b&0x80 == 0 {
+			return n, nil
+		}
+	}
+}
+
+// gitVersion is the version of the git binary as [major, minor].
+var gitVersion = sync.OnceValue(func() [2]int {
+	out, _ := gitOutput("", "--version")
+	f := strings.Fields(out)
+	var v [2]int
+	if len(f) >= 3 {
+		parts := strings.SplitN(f[2], ".", 3)
+		for i := 0; i < len(parts) && i < 2; i++ {
+			v[i], _ = strconv.Atoi(parts[i])
+		}
+	}
+	return v
+})
+
+// builtinFSMonitor reports whether git can run its own fsmonitor daemon
+// here: git 2.37 and later, on macOS and Windows only.
+func builtinFSMonitor() bool {
+	v := gitVersion()
+	return (runtime.GOOS == "darwin" || runtime.GOOS == "windows") && (v[0] > 2 || v[0] == 2 && v[1] >= 37)
+}
+
+// primeStatusAccel runs one `git status` that may write the index when the
+// acceleration is configured but not stored yet.  Watchers run git with
+// GIT_OPTIONAL_LOCKS=0, which keeps git from ever saving the fsmonitor
+// token and untracked cache, so every refresh would start from scratch.
+func primeStatusAccel(root string) {
+	a := readStatusAccel(root)
+	if !a.unprimed() {
+		return
+	}
+	cmd := gitCmd(root, "status", "--porcelain")
+	cmd.Env = append(os.Environ(), "GIT_OPTIONAL_LOCKS=1")
+	cmd.Run()
+	if debugMode {
+		fmt.Fprintf(os.Stderr, "status acceleration primed: %s\n", readStatusAccel(root).describe())
+	}
+}
+
+// debugStatusAccel reports under --debug how long a status of dir took and
+// whether the acceleration was in play.
+func debugStatusAccel(dir string, took time.Duration) {
+	if debugMode {
+		fmt.Fprintf(os.Stderr, "git status: %s in %s; %s\n", took.Round(time.Millisecond), dir, readStatusAccel(dir).describe())
+	}
+}
+
+// statusNeedsAccel reports whether a status of dir that took took was
+// slow in a repository with no acceleration turned on, which is when
+// `gits accel enable` is worth suggesting.
+func statusNeedsAccel(dir string, took time.Duration) bool {
+	if took < slowStatus || !gitAvailable() {
+		return false
+	}
+	a := readStatusAccel(dir)
+	return !a.fsmonitorOn() || !a.untrackedOn()
+}
+
+// runAccel implements `gits accel [enable|disable] [DIR]`.
+func runAccel(status *Status, args []string) {
+	c := status.cfg.Colors
+	action := ""
+	if len(args) > 0 && (args[0] == "enable" || args[0] == "disable") {
+		action, args = args[0], args[1:]
+	}
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	root, err := repoRoot(dir)
+	if err != nil {
+		exitWithError(err, c)
+	}
+
+	switch action {
+	case "enable":
+		settings := [][2]string{{"core.untrackedCache", "true"}}
+		if builtinFSMonitor() {
+			settings = append(settings, [2]string{"core.fsmonitor", "true"})
+		} else if _, err := exec.LookPath("watchman"); err == nil {
+			hook := filepath.Join(root, ".git", "hooks", "fsmonitor-watchman")
+			if path, err := gitOutput(root, "rev-parse", "--path-format=absolute", "--git-path", "hooks/fsmonitor-watchman.sample"); err == nil && IsFile(path) {
+				hook = strings.TrimSuffix(path, ".sample")
+				if !IsFile(hook) {
+					if data, err := os.ReadFile(path); err == nil {
+						os.WriteFile(hook, data, 0o755)
+					}
+				}
+			}
+			settings = append(settings, [2]string{"core.fsmonitor", hook})
+		}
+		for _, kv := range settings {
+			if err := uiGit(root, "config", kv[0], kv[1]); err != nil {
+				exitWithError(err, c)
+			}
+			fmt.Printf("%s %s%s = %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), kv[0], kv[1], Reset)
+		}
+		if len(settings) == 1 {
+			fmt.Printf("%s %sno fsmonitor here: git's daemon needs git 2.37+ on macOS or Windows, elsewhere install watchman%s\n",
+				Icons.WARNING, resolveColor(c.AheadBehind), Reset)
+		}
+		primeStatusAccel(root)
+	case "disable":
+		for _, key := range []string{"core.fsmonitor", "core.untrackedCache"} {
+			gitCmd(root, "config", "--unset", key).Run()
+		}
+		if builtinFSMonitor() {
+			gitCmd(root, "fsmonitor--daemon", "stop").Run()
+		}
+		uiGit(root, "update-index", "--no-untracked-cache", "--no-fsmonitor")
+		fmt.Printf("%s %sstatus acceleration turned off in %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), root, Reset)
+	}
+
+	a := readStatusAccel(root)
+	start := time.Now()
+	gitCmd(root, "status", "--porcelain").Run()
+	took := time.Since(start)
+	fmt.Printf("%s %s%s%s %s(%d paths in the index, status %s)%s\n", Icons.INFO, Bold+resolveColor(c.Header), displayPath(root), Reset,
+		Dim, a.Entries, took.Round(time.Millisecond), Reset)
+	fmt.Printf("    %sfsmonitor%s        %s\n", Bold, Reset, accelState(a.fsmonitorOn(), a.HasToken, c))
+	fmt.Printf("    %suntracked cache%s  %s\n", Bold, Reset, accelState(a.untrackedOn(), a.HasCache, c))
+	if a.IndexErr != nil {
+		fmt.Printf("    %s%v%s\n", resolveColor(c.AheadBehind), a.IndexErr, Reset)
+	}
+	if action == "" && !(a.fsmonitorOn() && a.untrackedOn()) {
+		fmt.Printf("%s(gits accel enable turns them on for this repository)%s\n", Dim, Reset)
+	}
+}
+
+// accelState renders one line of `gits accel`.
+func accelState(on, stored bool, c ColorConfig) string {
+	switch {
+	case !on:
+		return Dim + "off" + Reset
+	case stored:
+		return resolveColor(c.UpToDate) + "active" + Reset
+	}
+	return resolveColor(c.AheadBehind) + "configured, not in the index yet" + Reset
+}
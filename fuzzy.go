@@ -0,0 +1,211 @@
+// File: fuzzy.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: incremental fuzzy finder for the terminal
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// fuzzyMatch matches query against s as a case-insensitive subsequence.  It
+// returns the rune positions matched and a score that rewards consecutive
+// runs and matches at word starts ("/", "-", "_", "." boundaries).
+func fuzzyMatch(query, s string) (score int, positions []int, ok bool) {
+	if query == "" {
+		return 0, nil, true
+	}
+	q := []rune(strings.ToLower(query))
+	r := []rune(s)
+	qi, prev := 0, -2
+	for i := 0; i < len(r) && qi < len(q); i++ {
+		if unicode.ToLower(r[i]) != q[qi] {
+			continue
+		}
+		score++
+		if i == prev+1 {
+			score += 3
+		}
+		if i == 0 || strings.ContainsRune("/-_. ", r[i-1]) {
+			score += 2
+		}
+		positions = append(positions, i)
+		prev = i
+		qi++
+	}
+	if qi < len(q) {
+		return 0, nil, false
+	}
+	// Shorter candidates win ties: "main" beats "maintenance" for "main".
+	return score*100 - len(r), positions, true
+}
+
+// fuzzyFilter returns the indexes of items whose label matches query, best
+// first; equal scores keep the original order.
+func fuzzyFilter(query string, items []pickItem) []int {
+	type hit struct{ index, score int }
+	var hits []hit
+	for i, it := range items {
+		if score, _, ok := fuzzyMatch(query, it.Label); ok {
+			hits = append(hits, hit{i, score})
+		}
+	}
+	if query != "" {
+		sort.SliceStable(hits, func(a, b int) bool { return hits[a].score > hits[b].score })
+	}
+	out := make([]int, len(hits))
+	for i, h := range hits {
+		out[i] = h.index
+	}
+	return out
+}
+
+// highlightMatch renders label with the runes at positions emphasized.
+func highlightMatch(label string, positions []int, style string) string {
+	if len(positions) == 0 {
+		return label
+	}
+	var sb strings.Builder
+	pi := 0
+	for i, r := range []rune(label) {
+		if pi < len(positions) && positions[pi] == i {
+			sb.WriteString(style + string(r) + Reset)
+			pi++
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// fuzzyFind lets the user narrow items by typing and pick one with enter.
+// query seeds the search box.  It returns the index into items.
+func fuzzyFind(title, query string, items []pickItem, c ColorConfig) (index int, ok bool, err error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return 0, false, errNotTerminal
+	}
+	restore, err := rawTerminal(fd, "\x1b[?25h")
+	if err != nil {
+		return 0, false, err
+	}
+	defer restore()
+
+	matches := fuzzyFilter(query, items)
+	cursor, top, drawn := 0, 0, 0
+	matchStyle := Bold + resolveColor(c.Arrow)
+
+	draw := func() {
+		height := min(len(items), 15)
+		if _, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil && h > 5 && height > h-4 {
+			height = h - 4
+		}
+		if cursor < top {
+			top = cursor
+		}
+		if cursor >= top+height {
+			top = cursor - height + 1
+		}
+
+		var sb strings.Builder
+		if drawn > 0 {
+			fmt.Fprintf(&sb, "\x1b[%dA", drawn)
+		}
+		sb.WriteString("\r\x1b[J")
+		fmt.Fprintf(&sb, "%s %s%s%s\r\n", Icons.GIT, Bold+resolveColor(c.Header), title, Reset)
+		n := 1
+		for i := top; i < top+height; i++ {
+			if i >= len(matches) {
+				sb.WriteString("\r\n")
+				n++
+				continue
+			}
+			it := items[matches[i]]
+			_, positions, _ := fuzzyMatch(query, it.Label)
+			pointer, label := "  ", highlightMatch(it.Label, positions, matchStyle)
+			if i == cursor {
+				pointer = Bold + resolveColor(c.Arrow) + "❯ " + Reset
+				label = Bold + label + Reset
+			}
+			fmt.Fprintf(&sb, "%s%s%s%s %s\r\n", pointer, it.TagStyle, it.Tag, Reset, label)
+			n++
+		}
+		fmt.Fprintf(&sb, "%s%d/%d · ↑↓ move · enter select · esc cancel%s\r\n", Dim, len(matches), len(items), Reset)
+		n++
+		fmt.Fprintf(&sb, "%s❯%s %s", Bold+resolveColor(c.Arrow), Reset, query)
+		sb.WriteString("\x1b[?25h")
+		fmt.Print(sb.String())
+		drawn = n
+	}
+
+	refilter := func() {
+		matches = fuzzyFilter(query, items)
+		cursor, top = 0, 0
+	}
+
+	for {
+		draw()
+		key, err := readKey()
+		if err != nil {
+			fmt.Print("\r\n")
+			return 0, false, err
+		}
+		switch boundAction("fuzzy", key) {
+		case "up":
+			if cursor > 0 {
+				cursor--
+			}
+		case "down":
+			if cursor < len(matches)-1 {
+				cursor++
+			}
+		case "pgup":
+			cursor = max(cursor-10, 0)
+		case "pgdn":
+			cursor = max(min(cursor+10, len(matches)-1), 0)
+		case "erase":
+			if query != "" {
+				_, size := utf8.DecodeLastRuneInString(query)
+				query = query[:len(query)-size]
+				refilter()
+			}
+		case "clear":
+			query = ""
+			refilter()
+		case "accept":
+			fmt.Print("\r\n")
+			if len(matches) == 0 {
+				return 0, false, nil
+			}
+			return matches[cursor], true, nil
+		case "cancel":
+			fmt.Print("\r\n")
+			return 0, false, nil
+		default:
+			if key != "" && strings.IndexFunc(key, func(r rune) bool { return !unicode.IsPrint(r) }) < 0 {
+				query += key
+				refilter()
+			}
+		}
+	}
+}
+
+// statusLinePath extracts the path from a `git status` file line such as
+// "\tmodified:   main.go" or "\tuntracked.txt".
+func statusLinePath(line string) string {
+	line = strings.TrimSpace(line)
+	i := strings.Index(line, ": ")
+	if i > 0 && strings.Trim(line[:i], "abcdefghijklmnopqrstuvwxyz ") == "" {
+		return unquoteGitPath(strings.TrimSpace(line[i+1:]))
+	}
+	return unquoteGitPath(line)
+}
@@ -0,0 +1,134 @@
+// File: git.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: small helpers for running git subprocesses
+// License: MIT
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gitCmd builds a git command that runs inside dir (when non-empty).
+func gitCmd(dir string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(interrupted, "git", args...)
+	gitProcessGroup(cmd)
+	cmd.Cancel = func() error {
+		stopGit(cmd)
+		select {
+		case gitStops <- struct{}{}:
+		default:
+		}
+		// Wait does not return before Cancel does: holding it keeps the
+		// caller from reporting git's death as an error while
+		// handleInterrupts winds up and exits.
+		select {}
+	}
+	if dir != "" {
+		cmd.Dir = dir
+	}
+	timings.gitStarted(cmd)
+	return cmd
+}
+
+// gitOutput runs git inside dir and returns its stdout with surrounding
+// whitespace trimmed.  On failure the error carries git's stderr message.
+func gitOutput(dir string, args ...string) (string, error) {
+	out, err := gitRaw(dir, args...)
+	return strings.TrimSpace(out), err
+}
+
+// gitRaw is gitOutput without trimming, for NUL-separated (-z) output where
+// leading/trailing whitespace may belong to a path.
+func gitRaw(dir string, args ...string) (string, error) {
+	out, _, err := gitRawStderr(dir, args...)
+	return out, err
+}
+
+// gitRawStderr is gitRaw that also returns what git wrote to stderr when
+// it succeeded: its warnings and hints.
+func gitRawStderr(dir string, args ...string) (out, warnings string, err error) {
+	cmd := gitCmd(dir, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := runTimed(cmd); err != nil {
+		msg := withoutHints(stderr.String())
+		if errors.Is(err, exec.ErrNotFound) {
+			msg = "git is not installed or not on PATH"
+		} else if msg == "" {
+			msg = err.Error()
+		}
+		return "", "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	if slices.Contains(args, "-z") {
+		// NUL-separated: a CR may be part of a path.
+		return stdout.String(), stderr.String(), nil
+	}
+	return normalizeEOL(stdout.String()), stderr.String(), nil
+}
+
+// repoRoot returns the absolute top-level directory of the repository that
+// contains dir.
+func repoRoot(dir string) (string, error) {
+	if !gitAvailable() {
+		_, root, err := openNative(dir)
+		if err != nil {
+			return "", err
+		}
+		return logicalRoot(root, dir), nil
+	}
+	out, err := gitOutput(dir, "rev-parse", "--show-toplevel")
+	if isNotRepoErr(err) {
+		if abs, aerr := filepath.Abs(dir); aerr == nil {
+			dir = abs
+		}
+		return "", fmt.Errorf("%s is %w", displayPath(dir), errNotRepo)
+	}
+	if err != nil {
+		return "", err
+	}
+	return logicalRoot(filepath.FromSlash(out), dir), nil
+}
+
+// isGitDir reports whether dir is the top of a git working tree, i.e. it
+// contains a .git directory (regular repo) or .git file (worktree/submodule).
+func isGitDir(dir string) bool {
+	return Exists(filepath.Join(dir, ".git"))
+}
+
+// headCommit is the commit HEAD points at, as shown in the status header.
+type headCommit struct {
+	Hash    string
+	Subject string
+	Author  string
+	Time    time.Time
+}
+
+// lastCommit reads the commit at HEAD of dir in a single git call (or none
+// with the native backend); ok is false on a branch without commits.
+func lastCommit(dir string) (hc headCommit, ok bool) {
+	if useNative() {
+		if hc, err := nativeLastCommit(dir); err == nil {
+			return hc, true
+		}
+		if !gitAvailable() {
+			return hc, false
+		}
+	}
+	out, err := gitOutput(dir, "log", "-1", "--no-show-signature", "--format=%h%x00%ct%x00%an%x00%s")
+	f := strings.SplitN(out, "\x00", 4)
+	if err != nil || len(f) < 4 {
+		return hc, false
+	}
+	ts, _ := strconv.ParseInt(f[1], 10, 64)
+	return headCommit{Hash: f[0], Time: time.Unix(ts, 0), Author: f[2], Subject: f[3]}, true
+}
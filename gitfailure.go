@@ -0,0 +1,163 @@
+// File: gitfailure.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: telling apart why git failed, with a fix and an exit status for each
+// License: MIT
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+// failureKind is what went wrong, as far as git's message tells.
+type failureKind int
+
+const (
+	failureOther failureKind = iota
+	failureNotRepo
+	failureDubiousOwnership
+	failureIndexLock
+	failurePermission
+	failureCorrupt
+)
+
+// failureMode is how gits reports one kind of failure: a title ahead of
+// git's message, the fix to suggest (%s is the path git named, or the
+// current directory) and the exit status.  Status 3 is taken by
+// exitEnvironment.
+var failureModes = map[failureKind]struct {
+	title, fix string
+	code       int
+}{
+	failureOther:            {"", "", 1},
+	failureNotRepo:          {"Not a git repository", "cd into a repository, or create one here with `gits init`", 4},
+	failureDubiousOwnership: {"Repository owned by someone else", "trust it with `%s`, or run gits again with --trust", 5},
+	failureIndexLock:        {"The index is locked", "wait for the other git to finish; if none is running, remove %s", 6},
+	failurePermission:       {"Permission denied", "check the owner and mode of %s, and that it is not on a read-only mount", 7},
+	failureCorrupt:          {"Corrupt repository", "run `git fsck --full` in %s; if it cannot repair the objects, clone the repository again", 8},
+}
+
+var (
+	dubiousPath   = regexp.MustCompile(`dubious ownership in repository at '([^']+)'`)
+	indexLockPath = regexp.MustCompile(`Unable to create '([^']+\.lock)'`)
+	deniedPath    = regexp.MustCompile(`(?:open|opendir|unlink|create|write|lstat|mkdir)? ?'?([^':]*/[^':]+)'?: Permission denied`)
+)
+
+// corruptMarkers are pieces of the messages git prints for damaged objects,
+// packs and indexes.
+var corruptMarkers = []string{
+	"is corrupt", "corrupt loose object", "bad object", "bad tree object", "object file", "index file corrupt",
+	"index file smaller than expected", "bad signature 0x", "unable to read tree", "invalid sha1 pointer",
+	"packfile", "broken link from", "missing blob", "missing tree", "fatal: loose object",
+}
+
+// classifyGitError works out the kind of err, and the path git's message
+// names when there is one.
+func classifyGitError(err error) (failureKind, string) {
+	if err == nil {
+		return failureOther, ""
+	}
+	msg := err.Error()
+	switch {
+	case isNotRepoErr(err):
+		return failureNotRepo, ""
+	case strings.Contains(msg, "dubious ownership"):
+		if m := dubiousPath.FindStringSubmatch(msg); m != nil {
+			return failureDubiousOwnership, m[1]
+		}
+		return failureDubiousOwnership, ""
+	case strings.Contains(msg, "index.lock") && (strings.Contains(msg, "File exists") || strings.Contains(msg, "Unable to create")):
+		if m := indexLockPath.FindStringSubmatch(msg); m != nil {
+			return failureIndexLock, m[1]
+		}
+		return failureIndexLock, ""
+	case errors.Is(err, os.ErrPermission) || strings.Contains(msg, "Permission denied") || strings.Contains(msg, "insufficient permission"):
+		if m := deniedPath.FindStringSubmatch(msg); m != nil {
+			return failurePermission, m[1]
+		}
+		return failurePermission, ""
+	}
+	for _, marker := range corruptMarkers {
+		if strings.Contains(msg, marker) {
+			return failureCorrupt, ""
+		}
+	}
+	return failureOther, ""
+}
+
+// reportError prints err the way its kind calls for, with the fix to try
+// below it, and returns the exit status for it.
+func reportError(err error, c ColorConfig) int {
+	kind, path := classifyGitError(err)
+	mode := failureModes[kind]
+	if mode.title == "" {
+		fmt.Printf("%s %s%v%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), err, Reset)
+		return mode.code
+	}
+	// git's own advice after the first line is replaced by the fix; its
+	// further errors stay, as a corrupt object shows up in several.
+	msg, rest, _ := strings.Cut(err.Error(), "\n")
+	fmt.Printf("%s %s%s:%s %s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), mode.title, Reset, msg)
+	for _, line := range strings.Split(rest, "\n") {
+		if strings.HasPrefix(line, "fatal: ") || strings.HasPrefix(line, "error: ") {
+			fmt.Printf("   %s\n", line)
+		}
+	}
+	if path == "" {
+		path, _ = os.Getwd()
+	}
+	fix := mode.fix
+	switch {
+	case kind == failureDubiousOwnership:
+		fix = fmt.Sprintf(fix, safeDirectoryCommand(path))
+	case strings.Contains(fix, "%s"):
+		fix = fmt.Sprintf(fix, shellQuote(displayPath(path)))
+	}
+	fmt.Printf("   %s%s%s\n", Dim, fix, Reset)
+	if kind == failureIndexLock && path != "" {
+		explainIndexLock(path, c)
+	}
+	if kind == failureDubiousOwnership && trustRepos && trustDirectory(path, c) {
+		rerunWithout("--trust")
+	}
+	return mode.code
+}
+
+// rerunWithout runs the same gits command again without flag (or
+// flag=...), once a fix for its failure was applied, and exits with the
+// status of the run.
+func rerunWithout(flag string) {
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+	args := slices.DeleteFunc(slices.Clone(os.Args[1:]), func(a string) bool {
+		return a == flag || strings.HasPrefix(a, flag+"=")
+	})
+	cmd := exec.Command(exe, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	// The run again owns Ctrl+C from here; it reaches both, and this
+	// process has nothing left to clean up.
+	signal.Reset(os.Interrupt, syscall.SIGTERM)
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			exit(exitErr.ExitCode())
+		}
+		exit(1)
+	}
+	exit(0)
+}
+
+// exitWithError reports err and exits with the status of its kind.
+func exitWithError(err error, c ColorConfig) {
+	exit(reportError(err, c))
+}
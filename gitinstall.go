@@ -0,0 +1,95 @@
+// File: gitinstall.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: guidance for installing git when it is not on PATH
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// exitEnvironment is the exit status when gits cannot work in this
+// environment at all (no git binary), as opposed to 1 for a command that
+// failed.
+const exitEnvironment = 3
+
+// worksWithoutGit are the commands that read the status natively or need
+// no repository, and so run when git is not installed.
+var worksWithoutGit = map[string]bool{
+	"-h": true, "--help": true, "--dump-config": true, "keys": true, "backend": true,
+	"prompt": true, "--editor-mode": true, "--tree": true, "--no-tree": true,
+	"self-update": true,
+}
+
+// linuxDistro returns the ID and ID_LIKE words of /etc/os-release.
+func linuxDistro() []string {
+	f, err := os.Open("/etc/os-release")
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	var ids []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		key, value, ok := strings.Cut(sc.Text(), "=")
+		if ok && (key == "ID" || key == "ID_LIKE") {
+			ids = append(ids, strings.Fields(strings.Trim(value, `"'`))...)
+		}
+	}
+	return ids
+}
+
+// gitInstallCommand is the usual way to install git on this system, ""
+// when there is no obvious one.
+func gitInstallCommand() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "xcode-select --install   (or: brew install git)"
+	case "windows":
+		return "winget install --id Git.Git -e --source winget"
+	case "freebsd":
+		return "sudo pkg install git"
+	case "linux":
+		for _, id := range linuxDistro() {
+			switch id {
+			case "debian", "ubuntu":
+				return "sudo apt install git"
+			case "fedora", "rhel", "centos":
+				return "sudo dnf install git"
+			case "arch":
+				return "sudo pacman -S git"
+			case "alpine":
+				return "sudo apk add git"
+			case "suse", "opensuse":
+				return "sudo zypper install git"
+			}
+		}
+	}
+	return ""
+}
+
+// requireGit stops with install instructions when cmd needs the git
+// binary and there is none on PATH.
+func requireGit(cmd string, c ColorConfig) {
+	if gitAvailable() || worksWithoutGit[cmd] {
+		return
+	}
+	if _, ok := findPlugin(cmd); ok {
+		// A plugin may well do without git; it has the native status.
+		return
+	}
+	fmt.Printf("%s %sgits %s needs git, which is not installed or not on PATH%s\n",
+		Icons.ERROR, Bold+resolveColor(c.Deleted), cmd, Reset)
+	if install := gitInstallCommand(); install != "" {
+		fmt.Printf("   %sinstall it with:%s %s\n", Dim, Reset, install)
+	}
+	fmt.Printf("   %sdownloads for every system: https://git-scm.com/downloads%s\n", Dim, Reset)
+	fmt.Printf("   %splain `gits` still shows the status without git (native backend)%s\n", Dim, Reset)
+	exit(exitEnvironment)
+}
@@ -0,0 +1,81 @@
+// File: gitmessages.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: warnings and hints git writes to stderr, kept apart from its output
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GitMessagesConfig ([git_messages]) decides whether what git writes to
+// stderr while succeeding is shown under the status.
+type GitMessagesConfig struct {
+	Warnings bool `toml:"warnings"` // "warning: ..." and other notes
+	Hints    bool `toml:"hints"`    // "hint: ..." advice
+}
+
+// gitMessages is the [git_messages] section of the loaded config.
+var gitMessages = GitMessagesConfig{Warnings: true, Hints: true}
+
+// gitMessage is one warning, or one block of consecutive hint lines.
+type gitMessage struct {
+	hint bool
+	text string
+}
+
+// splitGitStderr cuts git's stderr into warnings and hints.  Lines without
+// a known prefix are warnings too: that is how git reports most problems
+// it works around, like an unreadable directory.
+func splitGitStderr(stderr string) []gitMessage {
+	var msgs []gitMessage
+	for _, line := range strings.Split(normalizeEOL(stderr), "\n") {
+		line = strings.TrimRight(line, " ")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if text, ok := strings.CutPrefix(line, "hint:"); ok {
+			text = strings.TrimPrefix(text, " ")
+			if n := len(msgs); n > 0 && msgs[n-1].hint {
+				msgs[n-1].text += "\n" + text
+			} else {
+				msgs = append(msgs, gitMessage{hint: true, text: text})
+			}
+			continue
+		}
+		msgs = append(msgs, gitMessage{text: strings.TrimPrefix(line, "warning: ")})
+	}
+	return msgs
+}
+
+// withoutHints is stderr without git's advice, which follows an error and
+// would otherwise bury it.
+func withoutHints(stderr string) string {
+	var lines []string
+	for _, line := range strings.Split(normalizeEOL(stderr), "\n") {
+		if !strings.HasPrefix(line, "hint:") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// printGitMessages shows the warnings and hints in stderr that
+// [git_messages] lets through: warnings with the warning icon, hints
+// dimmed, so neither reads as part of the status.
+func printGitMessages(w io.Writer, stderr string, c ColorConfig) {
+	for _, m := range splitGitStderr(stderr) {
+		switch {
+		case m.hint && gitMessages.Hints:
+			for _, line := range strings.Split(m.text, "\n") {
+				fmt.Fprintf(w, "   %shint: %s%s\n", Dim, line, Reset)
+			}
+		case !m.hint && gitMessages.Warnings:
+			fmt.Fprintf(w, "%s %sgit: %s%s\n", Icons.WARNING, resolveColor(c.AheadBehind), m.text, Reset)
+		}
+	}
+}
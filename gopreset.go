@@ -0,0 +1,138 @@
+// File: gopreset.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: `gits scan --go` — discover Go projects (GOPATH, go.work, code roots)
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// commonCodeRoots are directories (relative to $HOME) where people usually
+// keep their checkouts.
+var commonCodeRoots = []string{"src", "code", "projects", "dev", "workspace", "git", "repos"}
+
+// goEnv returns `go env <key>`, or "" when the go tool is unavailable.
+func goEnv(key string) string {
+	out, err := exec.Command("go", "env", key).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// goWorkUses returns the directories listed in the `use` directives of the
+// go.work file at path, resolved relative to the file.
+func goWorkUses(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	base := filepath.Dir(path)
+	var dirs []string
+	inBlock := false
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		switch {
+		case line == "":
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case line == "use (":
+			inBlock = true
+			continue
+		case strings.HasPrefix(line, "use "):
+			line = strings.TrimSpace(line[len("use "):])
+		case !inBlock:
+			continue
+		}
+		dir := strings.Trim(line, `"`)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(base, dir)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// findGoWork locates the active go.work file: $GOWORK / `go env GOWORK`,
+// otherwise the nearest go.work above the current directory.
+func findGoWork() string {
+	if w := os.Getenv("GOWORK"); w != "" && w != "off" {
+		return w
+	}
+	if w := goEnv("GOWORK"); w != "" && w != "off" {
+		return w
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		if p := filepath.Join(dir, "go.work"); IsFile(p) {
+			return p
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// goScanTargets returns the scan targets of the --go preset: every
+// $GOPATH/src, the repositories of go.work members, and common code roots
+// under $HOME that exist.
+func goScanTargets() []string {
+	seen := map[string]bool{}
+	var targets []string
+	add := func(dir string) {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		if !seen[dir] && IsDir(dir) {
+			seen[dir] = true
+			targets = append(targets, dir)
+		}
+	}
+
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		gopath = goEnv("GOPATH")
+	}
+	home, _ := os.UserHomeDir()
+	if gopath == "" && home != "" {
+		gopath = filepath.Join(home, "go")
+	}
+	for _, p := range filepath.SplitList(gopath) {
+		add(filepath.Join(p, "src"))
+	}
+
+	if work := findGoWork(); work != "" {
+		for _, mod := range goWorkUses(work) {
+			if root, err := repoRoot(mod); err == nil {
+				add(root)
+			}
+		}
+	}
+
+	if home != "" {
+		for _, r := range commonCodeRoots {
+			add(filepath.Join(home, r))
+		}
+	}
+	return targets
+}
@@ -0,0 +1,354 @@
+// File: hooks.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: git hook inspection, templates and pre-commit checks (`gits hooks`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// HooksConfig controls `gits hooks check`, which the pre-commit hook runs.
+type HooksConfig struct {
+	MaxSize   string   `toml:"max_size"`  // largest file a commit may add, e.g. "5MB" ("" = 5 MiB)
+	Forbidden []string `toml:"forbidden"` // gitignore-style patterns no commit may add, e.g. "*.pem"
+}
+
+// gitsHookMarker identifies hooks written by `gits hooks install`.
+const gitsHookMarker = "# installed by gits hooks"
+
+// knownHooks are the hooks git runs, client side in workflow order first.
+var knownHooks = []string{
+	"applypatch-msg", "pre-applypatch", "post-applypatch",
+	"pre-commit", "pre-merge-commit", "prepare-commit-msg", "commit-msg", "post-commit",
+	"pre-rebase", "post-checkout", "post-merge", "pre-push", "post-rewrite",
+	"pre-auto-gc", "fsmonitor-watchman", "push-to-checkout", "reference-transaction",
+	"sendemail-validate", "post-index-change",
+	// server side
+	"pre-receive", "update", "proc-receive", "post-receive", "post-update",
+}
+
+// hookTemplates are the hooks `gits hooks install` can write.
+var hookTemplates = map[string]string{
+	"pre-commit": `#!/bin/sh
+` + gitsHookMarker + `
+# Conflict markers, whitespace errors, oversized files and files matching
+# the [hooks] forbidden patterns in staged changes.
+exec gits hooks check
+`,
+	"commit-msg": `#!/bin/sh
+` + gitsHookMarker + `
+# Require a subject line of at most 72 characters.
+subject=$(grep -v '^#' "$1" | sed -n '/[^[:space:]]/{p;q;}')
+if [ -z "$subject" ]; then
+	echo "commit-msg: empty commit message" >&2
+	exit 1
+fi
+if [ ${#subject} -gt 72 ]; then
+	echo "commit-msg: subject is ${#subject} characters (max 72): $subject" >&2
+	exit 1
+fi
+`,
+	"pre-push": `#!/bin/sh
+` + gitsHookMarker + `
+# Refuse to push WIP commits made by ` + "`gits wip`" + `.
+zero=0000000000000000000000000000000000000000
+while read -r local_ref local_sha remote_ref remote_sha; do
+	[ "$local_sha" = "$zero" ] && continue # deleting a branch
+	if [ "$remote_sha" = "$zero" ]; then
+		range="$local_sha --not --remotes"
+	else
+		range="$remote_sha..$local_sha"
+	fi
+	wip=$(git log --format='%h %s' $range | grep -E '^[0-9a-f]+ WIP ' | head -n 1)
+	if [ -n "$wip" ]; then
+		echo "pre-push: refusing to push WIP commit $wip (gits unwip, then commit properly)" >&2
+		exit 1
+	fi
+done
+`,
+}
+
+// hooksDir returns the directory git runs hooks from (core.hooksPath or
+// .git/hooks) as an absolute path, and whether core.hooksPath set it.
+func hooksDir(root string) (string, bool, error) {
+	dir, err := gitOutput(root, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", false, err
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	custom, _ := gitOutput(root, "config", "--get", "core.hooksPath")
+	return dir, custom != "", nil
+}
+
+// hookCheckMaxSize is the largest staged file `gits hooks check` accepts.
+const hookCheckMaxSize = 5 << 20
+
+// parseSize reads a size such as "512", "300K", "5MB" or "1.5GiB"; the
+// units are powers of 1024 either way.
+func parseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	num := strings.TrimRight(s, "KMGTIB ")
+	unit := strings.TrimSpace(s[len(num):])
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	shift := map[string]uint{"": 0, "B": 0, "K": 10, "KB": 10, "KIB": 10, "M": 20, "MB": 20, "MIB": 20,
+		"G": 30, "GB": 30, "GIB": 30, "T": 40, "TB": 40, "TIB": 40}
+	sh, ok := shift[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(n * float64(int64(1)<<sh)), nil
+}
+
+// runHookCheck is the pre-commit check: `git diff --cached --check` for
+// conflict markers and whitespace errors, plus staged files over the size
+// limit or matching a forbidden pattern.  It exits non-zero when anything
+// is found.
+func runHookCheck(root string, maxSize int64, forbidden []string, c ColorConfig) {
+	problems := 0
+	// --check exits non-zero when it finds something; the findings are on stdout.
+	raw, _ := gitCmd(root, "diff", "--cached", "--check").Output()
+	if out := strings.TrimSpace(string(raw)); out != "" {
+		fmt.Printf("%s %sStaged changes have conflict markers or whitespace errors:%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), Reset)
+		for _, line := range strings.Split(out, "\n") {
+			if strings.HasPrefix(line, "+") {
+				fmt.Printf("        %s%s%s\n", Dim, line, Reset)
+			} else {
+				fmt.Printf("    %s\n", line)
+			}
+			problems++
+		}
+	}
+	var patterns []gitignore.Pattern
+	for _, p := range forbidden {
+		patterns = append(patterns, gitignore.ParsePattern(p, nil))
+	}
+	if out, err := gitOutput(root, "diff", "--cached", "--name-only", "--diff-filter=ACMR", "-z"); err == nil {
+		for _, path := range strings.Split(out, "\x00") {
+			if path == "" {
+				continue
+			}
+			parts := strings.Split(path, "/")
+			for i, p := range patterns {
+				if p.Match(parts, false) == gitignore.Exclude {
+					fmt.Printf("%s %s%s matches the forbidden pattern %q from [hooks]; unstage it with git restore --staged%s\n",
+						Icons.ERROR, Bold+resolveColor(c.Deleted), path, forbidden[i], Reset)
+					problems++
+					break
+				}
+			}
+			size, err := gitOutput(root, "cat-file", "-s", ":"+path)
+			if err != nil {
+				continue
+			}
+			if n, _ := strconv.ParseInt(size, 10, 64); n > maxSize {
+				fmt.Printf("%s %s%s is %s (limit %s); use Git LFS or unstage it%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted),
+					path, humanSize(n), humanSize(maxSize), Reset)
+				problems++
+			}
+		}
+	}
+	if problems > 0 {
+		fmt.Printf("%s %sCommit blocked by gits hooks check%s %s(bypass with git commit --no-verify)%s\n",
+			Icons.ERROR, Bold+resolveColor(c.Deleted), Reset, Dim, Reset)
+		exit(1)
+	}
+}
+
+// runHooks implements `gits hooks [list|install|enable|disable|check]`.
+func runHooks(status *Status, args []string) {
+	c := status.cfg.Colors
+	action := "list"
+	if len(args) > 0 {
+		action = args[0]
+		args = args[1:]
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	force := false
+	maxSize := int64(hookCheckMaxSize)
+	if hc := status.cfg.Hooks.MaxSize; hc != "" {
+		n, err := parseSize(hc)
+		if err != nil {
+			fail(fmt.Errorf("[hooks] max_size: %w", err))
+		}
+		maxSize = n
+	}
+	var names []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-f", "--force":
+			force = true
+		case "--max-size":
+			if i+1 < len(args) {
+				i++
+				if n, err := parseSize(args[i]); err == nil {
+					maxSize = n
+				}
+			}
+		default:
+			names = append(names, args[i])
+		}
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	if action == "check" {
+		runHookCheck(root, maxSize, status.cfg.Hooks.Forbidden, c)
+		return
+	}
+	dir, custom, err := hooksDir(root)
+	if err != nil {
+		fail(err)
+	}
+	if action != "list" && len(names) == 0 {
+		fail(fmt.Errorf("usage: gits hooks %s HOOK...", action))
+	}
+
+	switch action {
+	case "list", "ls":
+		source := ".git/hooks"
+		if custom {
+			source = "core.hooksPath"
+		}
+		fmt.Printf("%s %sHooks%s in %s%s%s %s(%s)%s\n", Icons.FOLDER, Bold+resolveColor(c.Header), Reset,
+			resolveColor(c.CwdPath), displayPath(dir), Reset, Dim, source, Reset)
+		entries, _ := os.ReadDir(dir)
+		present := map[string]os.FileInfo{}
+		for _, e := range entries {
+			if info, err := e.Info(); err == nil && !e.IsDir() {
+				present[e.Name()] = info
+			}
+		}
+		shown := 0
+		for _, name := range knownHooks {
+			info, active := present[name]
+			disabledInfo, disabled := present[name+".disabled"]
+			_, sample := present[name+".sample"]
+			switch {
+			case active:
+				state := Bold + resolveColor(c.UpToDate) + "active" + Reset
+				if info.Mode()&0o111 == 0 {
+					state = Bold + resolveColor(c.AheadBehind) + "not executable (ignored by git)" + Reset
+				}
+				origin := ""
+				if data, err := os.ReadFile(filepath.Join(dir, name)); err == nil && strings.Contains(string(data), gitsHookMarker) {
+					origin = Dim + " · gits template" + Reset
+				}
+				fmt.Printf("    %s✔%s %-22s %s%s\n", resolveColor(c.UpToDate), Reset, name, state, origin)
+				shown++
+			case disabled:
+				fmt.Printf("    %s✗%s %-22s %sdisabled%s %s(%s)%s\n", Dim, Reset, name, Dim, Reset, Dim, humanSize(disabledInfo.Size()), Reset)
+				shown++
+			case sample:
+				fmt.Printf("    %s·%s %s%-22s sample only%s\n", Dim, Reset, Dim, name, Reset)
+				shown++
+			}
+		}
+		var other []string
+		for name := range present {
+			base := strings.TrimSuffix(strings.TrimSuffix(name, ".sample"), ".disabled")
+			if !containsString(knownHooks, base) {
+				other = append(other, name)
+			}
+		}
+		sort.Strings(other)
+		for _, name := range other {
+			fmt.Printf("    %s?%s %-22s %snot a hook name git runs%s\n", Dim, Reset, name, Dim, Reset)
+			shown++
+		}
+		if shown == 0 {
+			fmt.Printf("    %sno hooks%s\n", Dim, Reset)
+		}
+		var templates []string
+		for name := range hookTemplates {
+			templates = append(templates, name)
+		}
+		sort.Strings(templates)
+		fmt.Printf("%s Templates: %s %s(gits hooks install NAME)%s\n", Icons.INFO, strings.Join(templates, ", "), Dim, Reset)
+
+	case "install":
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			fail(err)
+		}
+		for _, name := range names {
+			body, ok := hookTemplates[name]
+			if !ok {
+				fail(fmt.Errorf("no template for %q", name))
+			}
+			path := filepath.Join(dir, name)
+			if Exists(path) && !force {
+				fail(fmt.Errorf("%s already exists (use --force to replace it)", displayPath(path)))
+			}
+			if err := os.WriteFile(path, []byte(body), 0o755); err != nil {
+				fail(err)
+			}
+			os.Chmod(path, 0o755) // WriteFile keeps the mode of an existing file
+			fmt.Printf("%s %sInstalled %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), name, Reset)
+		}
+
+	case "enable":
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			if Exists(path + ".disabled") {
+				if Exists(path) {
+					fail(fmt.Errorf("both %s and %s.disabled exist", name, name))
+				}
+				if err := os.Rename(path+".disabled", path); err != nil {
+					fail(err)
+				}
+			}
+			if !Exists(path) {
+				fail(fmt.Errorf("no %s hook (gits hooks install %s)", name, name))
+			}
+			if err := os.Chmod(path, 0o755); err != nil {
+				fail(err)
+			}
+			fmt.Printf("%s %sEnabled %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), name, Reset)
+		}
+
+	case "disable":
+		// Renaming (rather than chmod -x) keeps git from warning that the
+		// hook was ignored.
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			if !Exists(path) {
+				fail(fmt.Errorf("no active %s hook", name))
+			}
+			if err := os.Rename(path, path+".disabled"); err != nil {
+				fail(err)
+			}
+			fmt.Printf("%s %sDisabled %s%s %s(gits hooks enable %s)%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), name, Reset, Dim, name, Reset)
+		}
+
+	default:
+		fail(fmt.Errorf("unknown hooks action %q (list, install, enable, disable, check)", action))
+	}
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,405 @@
+// File: hunks.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: hunk- and line-level staging through `git apply --cached`
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// hunkHeader parses "@@ -a,b +c,d @@ context".
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@(.*)$`)
+
+// diffHunk is one hunk of a single-file patch.
+type diffHunk struct {
+	oldStart int
+	newStart int
+	context  string   // text after the closing @@
+	lines    []string // " ", "-", "+" and "\ No newline" lines
+}
+
+// filePatch is the diff of one file split into hunks.  Whole is set for
+// patches git cannot apply in part (new, deleted, renamed or binary files).
+type filePatch struct {
+	header []string
+	hunks  []diffHunk
+	whole  bool
+}
+
+// parseFilePatch splits a single-file `git diff` into its header and hunks.
+func parseFilePatch(patch string) filePatch {
+	var fp filePatch
+	for _, line := range strings.Split(strings.TrimSuffix(patch, "\n"), "\n") {
+		if m := hunkHeader.FindStringSubmatch(line); m != nil {
+			oldStart, _ := strconv.Atoi(m[1])
+			newStart, _ := strconv.Atoi(m[2])
+			fp.hunks = append(fp.hunks, diffHunk{oldStart: oldStart, newStart: newStart, context: m[3]})
+			continue
+		}
+		if len(fp.hunks) == 0 {
+			fp.header = append(fp.header, line)
+			if strings.HasPrefix(line, "new file mode") || strings.HasPrefix(line, "deleted file mode") ||
+				strings.HasPrefix(line, "rename from") || strings.HasPrefix(line, "Binary files") ||
+				strings.HasPrefix(line, "GIT binary patch") {
+				fp.whole = true
+			}
+			continue
+		}
+		h := &fp.hunks[len(fp.hunks)-1]
+		h.lines = append(h.lines, line)
+	}
+	return fp
+}
+
+// isChange reports whether a hunk line adds or removes something.
+func isChange(line string) bool {
+	return strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-")
+}
+
+// buildPatch keeps only the selected change lines of fp, in the form
+// `git apply --cached` wants: forward when staging, and for reverse (-R,
+// unstaging) with the sides swapped.  An unselected line that exists on the
+// side the patch applies to turns into context; the other kind is dropped.
+// selected[h][i] is line i of hunk h.  It returns "" when nothing is
+// selected.
+func buildPatch(fp filePatch, selected map[int]map[int]bool, reverse bool) string {
+	var sb strings.Builder
+	delta := 0 // how far earlier hunks moved the result side
+	for hi, h := range fp.hunks {
+		var out []string
+		oldN, newN, picked := 0, 0, false
+		kept := true // whether the previous line made it into out
+		for i, line := range h.lines {
+			sel := selected[hi][i]
+			switch {
+			case strings.HasPrefix(line, "\\"):
+				if kept {
+					out = append(out, line)
+				}
+				continue
+			case strings.HasPrefix(line, "-") && sel, strings.HasPrefix(line, "+") && sel:
+				picked = true
+				out = append(out, line)
+				if line[0] == '-' {
+					oldN++
+				} else {
+					newN++
+				}
+			case strings.HasPrefix(line, "-") && !reverse, strings.HasPrefix(line, "+") && reverse:
+				out = append(out, " "+line[1:])
+				oldN++
+				newN++
+			case isChange(line):
+				kept = false
+				continue
+			default:
+				out = append(out, line)
+				oldN++
+				newN++
+			}
+			kept = true
+		}
+		if !picked {
+			continue
+		}
+		oldStart, newStart := h.oldStart, h.oldStart+delta
+		if reverse {
+			oldStart, newStart = h.newStart+delta, h.newStart
+			delta += oldN - newN
+		} else {
+			delta += newN - oldN
+		}
+		// A side with no lines starts one line earlier in unified diffs.
+		if oldN == 0 {
+			oldStart = max(oldStart-1, 0)
+		}
+		if newN == 0 {
+			newStart = max(newStart-1, 0)
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@%s\n", oldStart, oldN, newStart, newN, h.context)
+		for _, line := range out {
+			sb.WriteString(line + "\n")
+		}
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	return strings.Join(fp.header, "\n") + "\n" + sb.String()
+}
+
+// applyCached applies patch to the index, in reverse to unstage.
+func applyCached(root, patch string, reverse bool) error {
+	args := []string{"apply", "--cached", "--whitespace=nowarn"}
+	if reverse {
+		args = append(args, "-R")
+	}
+	cmd := gitCmd(root, append(args, "-")...)
+	cmd.Stdin = strings.NewReader(patch)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git apply: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// hunkLine is one row of the hunk picker: a hunk heading (line -1) or a
+// line of a hunk.
+type hunkLine struct {
+	hunk, line int
+}
+
+// hunkPicker selects lines of one file's diff for staging (or unstaging,
+// when the diff is of the index).
+type hunkPicker struct {
+	c        ColorConfig
+	root     string
+	path     string
+	unstage  bool
+	fp       filePatch
+	rows     []hunkLine
+	selected map[int]map[int]bool
+	cursor   int // index into rows, always a change line
+	top      int
+	message  string
+}
+
+// newHunkPicker loads the diff of path: of the worktree against the index,
+// or of the index against HEAD when unstaging.
+func newHunkPicker(root, path string, unstage bool, c ColorConfig) (*hunkPicker, error) {
+	args := []string{"diff", "--no-color", "--no-ext-diff"}
+	if unstage {
+		args = append(args, "--cached")
+	}
+	patch, err := gitRaw(root, append(args, "--", path)...)
+	if err != nil {
+		return nil, err
+	}
+	if patch == "" {
+		return nil, fmt.Errorf("no changes in %s", path)
+	}
+	hp := &hunkPicker{c: c, root: root, path: path, unstage: unstage, fp: parseFilePatch(patch), selected: map[int]map[int]bool{}}
+	if hp.fp.whole || len(hp.fp.hunks) == 0 {
+		return nil, fmt.Errorf("%s can only be staged as a whole file", path)
+	}
+	for hi, h := range hp.fp.hunks {
+		hp.rows = append(hp.rows, hunkLine{hi, -1})
+		hp.selected[hi] = map[int]bool{}
+		for i := range h.lines {
+			hp.rows = append(hp.rows, hunkLine{hi, i})
+		}
+	}
+	hp.cursor = -1
+	hp.step(1)
+	return hp, nil
+}
+
+// step moves the cursor to the next change line in direction dir.
+func (hp *hunkPicker) step(dir int) {
+	for i := hp.cursor + dir; i >= 0 && i < len(hp.rows); i += dir {
+		if r := hp.rows[i]; r.line >= 0 && isChange(hp.fp.hunks[r.hunk].lines[r.line]) {
+			hp.cursor = i
+			return
+		}
+	}
+}
+
+// stepHunk moves the cursor to the first change of the next (dir 1) or
+// previous (dir -1) hunk.
+func (hp *hunkPicker) stepHunk(dir int) {
+	current := hp.rows[hp.cursor].hunk
+	for i := hp.cursor; i >= 0 && i < len(hp.rows); i += dir {
+		if r := hp.rows[i]; r.line == -1 && r.hunk != current {
+			hp.cursor = i
+			hp.step(1)
+			return
+		}
+	}
+}
+
+// hunkState counts the selected and selectable lines of hunk hi.
+func (hp *hunkPicker) hunkState(hi int) (selected, total int) {
+	for i, line := range hp.fp.hunks[hi].lines {
+		if isChange(line) {
+			total++
+			if hp.selected[hi][i] {
+				selected++
+			}
+		}
+	}
+	return selected, total
+}
+
+// setHunk selects or clears every change line of hunk hi.
+func (hp *hunkPicker) setHunk(hi int, on bool) {
+	for i, line := range hp.fp.hunks[hi].lines {
+		if isChange(line) {
+			hp.selected[hi][i] = on
+		}
+	}
+}
+
+// draw renders the picker over the whole screen.
+func (hp *hunkPicker) draw() {
+	width, height := 80, 24
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		width, height = w, h
+	}
+	c := hp.c
+	listH := max(height-2, 1)
+	if hp.cursor < hp.top {
+		hp.top = hp.cursor
+	}
+	if hp.cursor >= hp.top+listH {
+		hp.top = hp.cursor - listH + 1
+	}
+	// Keep the hunk heading in view when its first change is selected.
+	if hp.top > 0 && hp.cursor-hp.top < 3 && hp.rows[hp.top-1].line == -1 {
+		hp.top--
+	}
+
+	verb := "Stage"
+	if hp.unstage {
+		verb = "Unstage"
+	}
+	chosen := 0
+	for hi := range hp.fp.hunks {
+		n, _ := hp.hunkState(hi)
+		chosen += n
+	}
+	lines := []string{fmt.Sprintf("%s %s%s lines of %s%s %s(%d selected)%s", Icons.GIT, Bold+resolveColor(c.Header), verb,
+		hp.path, Reset, Dim, chosen, Reset)}
+	mark := Bold + resolveColor(c.Staged)
+	for i := hp.top; i < hp.top+listH && i < len(hp.rows); i++ {
+		r := hp.rows[i]
+		h := hp.fp.hunks[r.hunk]
+		if r.line == -1 {
+			n, total := hp.hunkState(r.hunk)
+			box := "[ ]"
+			switch {
+			case n == total:
+				box = mark + "[x]" + Reset
+			case n > 0:
+				box = mark + "[~]" + Reset
+			}
+			lines = append(lines, fmt.Sprintf("%s %shunk %d/%d @@ -%d +%d @@%s%s %s(%d/%d)%s", box, Bold+resolveColor(c.Header),
+				r.hunk+1, len(hp.fp.hunks), h.oldStart, h.newStart, h.context, Reset, Dim, n, total, Reset))
+			continue
+		}
+		line := h.lines[r.line]
+		bar, style := "  ", Dim
+		switch {
+		case strings.HasPrefix(line, "+"):
+			style = resolveColor(c.Added)
+		case strings.HasPrefix(line, "-"):
+			style = resolveColor(c.Deleted)
+		}
+		if hp.selected[r.hunk][r.line] {
+			bar = mark + "▌ " + Reset
+			style = Bold + style
+		}
+		pointer := "  "
+		if i == hp.cursor {
+			pointer = Bold + resolveColor(c.Arrow) + "❯ " + Reset
+		}
+		lines = append(lines, pointer+bar+style+line+Reset)
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	footer := Dim + "↑↓ line · [ ] hunk · space line · h hunk · a all · enter " + strings.ToLower(verb) + " selection · q back" + Reset
+	if hp.message != "" {
+		footer = resolveColor(c.AheadBehind) + hp.message + Reset
+	}
+	lines = append(lines, footer)
+
+	var sb strings.Builder
+	sb.WriteString("\x1b[H")
+	for i, l := range lines[:min(len(lines), height)] {
+		if i > 0 {
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString(clipANSI(l, width) + "\x1b[K")
+	}
+	sb.WriteString("\x1b[J")
+	fmt.Print(sb.String())
+}
+
+// run lets the user pick lines until they apply the selection (applied),
+// leave the file (neither), or ask to stop altogether (quit, with q).  The
+// terminal must already be in raw mode.
+func (hp *hunkPicker) run() (applied, quit bool, err error) {
+	for {
+		hp.draw()
+		key, err := readKey()
+		if err != nil {
+			return false, true, err
+		}
+		hp.message = ""
+		action := boundAction("hunks", key)
+		if ev, ok := parseMouse(key); ok {
+			switch ev.Button {
+			case 64:
+				action = "up"
+			case 65:
+				action = "down"
+			}
+		}
+		r := hp.rows[hp.cursor]
+		switch action {
+		case "up":
+			hp.step(-1)
+		case "down":
+			hp.step(1)
+		case "pgup":
+			for range 10 {
+				hp.step(-1)
+			}
+		case "pgdn":
+			for range 10 {
+				hp.step(1)
+			}
+		case "next-hunk":
+			hp.stepHunk(1)
+		case "prev-hunk":
+			hp.stepHunk(-1)
+		case "line":
+			hp.selected[r.hunk][r.line] = !hp.selected[r.hunk][r.line]
+			hp.step(1)
+		case "hunk":
+			n, total := hp.hunkState(r.hunk)
+			hp.setHunk(r.hunk, n < total)
+		case "all":
+			all := true
+			for hi := range hp.fp.hunks {
+				n, total := hp.hunkState(hi)
+				all = all && n == total
+			}
+			for hi := range hp.fp.hunks {
+				hp.setHunk(hi, !all)
+			}
+		case "apply":
+			patch := buildPatch(hp.fp, hp.selected, hp.unstage)
+			if patch == "" {
+				hp.message = "Nothing selected: space picks a line, h a hunk"
+				continue
+			}
+			if err := applyCached(hp.root, patch, hp.unstage); err != nil {
+				hp.message = err.Error()
+				continue
+			}
+			return true, false, nil
+		case "skip":
+			return false, false, nil
+		case "quit":
+			return false, true, nil
+		}
+	}
+}
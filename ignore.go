@@ -0,0 +1,263 @@
+// File: ignore.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: .gitignore management (`gits ignore`)
+// License: MIT
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// defaultIgnoreRemote is the profile used when no remote is named: the
+// current branch's push remote, else "default".
+func defaultIgnoreRemote(root string) string {
+	branch, _ := gitOutput(root, "symbolic-ref", "--quiet", "--short", "HEAD")
+	if remote := pushRemoteFor(root, branch); remote != "" {
+		return remote
+	}
+	return "default"
+}
+
+// runIgnore implements `gits ignore add|suggest|tracked|global|lint|why|backup|restore|list ...`.
+func runIgnore(status *Status, args []string) {
+	c := status.cfg.Colors
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	sub := "list"
+	if len(args) > 0 {
+		sub, args = args[0], args[1:]
+	}
+	// The global file does not need a repository.
+	if sub == "global" {
+		runIgnoreGlobal(status, args)
+		return
+	}
+	m, err := NewGitIgnoreManager(".")
+	if err != nil {
+		fail(err)
+	}
+
+	yes := false
+	var positional []string
+	for _, a := range args {
+		if a == "--yes" || a == "-y" {
+			yes = true
+		} else {
+			positional = append(positional, a)
+		}
+	}
+
+	switch sub {
+	case "list", "ls":
+		remotes := m.Remotes()
+		if len(positional) > 0 {
+			remotes = []string{profile(positional[0])}
+		}
+		current, _ := os.ReadFile(m.Path())
+		shown := 0
+		for _, remote := range remotes {
+			list, err := m.Backups(remote)
+			if err != nil {
+				fail(err)
+			}
+			if len(list) == 0 {
+				continue
+			}
+			shown++
+			fmt.Printf("%s %s%s%s %s(%d backup(s))%s\n", Icons.REMOTE, Bold+resolveColor(c.RemoteURL), remote, Reset, Dim, len(list), Reset)
+			for i, b := range list {
+				data, _ := os.ReadFile(b.Path)
+				mark := ""
+				if bytes.Equal(data, current) {
+					mark = resolveColor(c.UpToDate) + " = current" + Reset
+				}
+				fmt.Printf("   %s%2d%s %s  %s%-8s%s %3d line(s), %s%s\n", Bold+resolveColor(c.AheadBehind), i+1, Reset,
+					b.Time.Format("2006-01-02 15:04:05"), Dim, shortAge(b.Time), Reset, bytes.Count(data, []byte("\n")), humanSize(b.Size), mark)
+			}
+		}
+		if shown == 0 {
+			fmt.Printf("%s No .gitignore backups yet %s(gits ignore backup)%s\n", Icons.INFO, Dim, Reset)
+		}
+		for remote, p := range status.cfg.Ignore.Profiles {
+			if len(positional) > 0 && profile(positional[0]) != profile(remote) {
+				continue
+			}
+			what := fmt.Sprintf("%d extra rule(s)", len(p.Rules))
+			if p.File != "" {
+				what = p.File + ", " + what
+			}
+			fmt.Printf("🙈 %sprofile%s %s%s%s %s(%s; swapped in for push)%s\n", Dim, Reset, Bold+resolveColor(c.RemoteURL), remote, Reset, Dim, what, Reset)
+		}
+
+	case "backup":
+		remote := defaultIgnoreRemote(m.root)
+		if len(positional) > 0 {
+			remote = positional[0]
+		}
+		b, created, err := m.Backup(remote)
+		if err != nil {
+			fail(err)
+		}
+		switch {
+		case b.Path == "":
+			fmt.Printf("%s No .gitignore to back up\n", Icons.INFO)
+		case !created:
+			fmt.Printf("%s .gitignore is unchanged since the %s backup from %s\n", Icons.INFO, profile(remote), relativeAge(b.Time))
+		default:
+			fmt.Printf("%s %sBacked up .gitignore%s for %s%s%s %s(%s)%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset,
+				Bold+resolveColor(c.RemoteURL), profile(remote), Reset, Dim, displayPath(b.Path), Reset)
+		}
+
+	case "restore":
+		remote := defaultIgnoreRemote(m.root)
+		n := 1
+		for _, a := range positional {
+			if i, err := strconv.Atoi(a); err == nil {
+				n = i
+			} else {
+				remote = a
+			}
+		}
+		list, err := m.Backups(remote)
+		if err != nil {
+			fail(err)
+		}
+		if len(list) == 0 {
+			fail(fmt.Errorf("no .gitignore backups for %s", profile(remote)))
+		}
+		if n < 1 || n > len(list) {
+			fail(fmt.Errorf("backup %d does not exist (1-%d, see gits ignore list)", n, len(list)))
+		}
+		b := list[n-1]
+		// Diff with root-relative paths so the header reads .gitignore.
+		current := ".gitignore"
+		if !IsFile(m.Path()) {
+			current = os.DevNull
+		}
+		saved, err := filepath.Rel(m.root, b.Path)
+		if err != nil {
+			saved = b.Path
+		}
+		patch, _ := gitCmd(m.root, "diff", "--no-index", "--no-color", "--", current, saved).Output()
+		if len(patch) == 0 {
+			fmt.Printf("%s .gitignore already matches that backup\n", Icons.INFO)
+			return
+		}
+		fmt.Printf("%s Restoring .gitignore from %s%s%s backup %d %s(%s)%s\n", Icons.INFO, Bold+resolveColor(c.RemoteURL),
+			b.Remote, Reset, n, Dim, relativeAge(b.Time), Reset)
+		fmt.Print(renderDiff(strings.ReplaceAll(string(patch), saved, ".gitignore"), c))
+		if !yes {
+			ok, err := confirm("Replace .gitignore with this backup?")
+			if err != nil {
+				fail(fmt.Errorf("%v (use --yes)", err))
+			}
+			if !ok {
+				return
+			}
+		}
+		// Keep what is being replaced, so a restore can itself be undone.
+		if _, _, err := m.Backup(remote); err != nil {
+			fail(err)
+		}
+		if err := m.Restore(b); err != nil {
+			fail(err)
+		}
+		fmt.Printf("%s %sRestored .gitignore%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+
+	case "add":
+		offline := false
+		var names []string
+		for _, a := range positional {
+			if a == "--offline" {
+				offline = true
+			} else {
+				names = append(names, strings.ToLower(a))
+			}
+		}
+		if len(names) == 0 {
+			all := ignoreTemplateNames()
+			preselect := map[string]bool{}
+			for _, name := range detectIgnoreTemplates(m.root) {
+				preselect[name] = true
+			}
+			items := make([]pickItem, len(all))
+			for i, name := range all {
+				items[i] = pickItem{Tag: "🙈", Label: name, Checked: preselect[name]}
+			}
+			checked, ok, err := runPicker("Add .gitignore templates", items, c)
+			if err != nil {
+				fail(fmt.Errorf("%v (name the templates: gits ignore add go node)", err))
+			}
+			for i, v := range checked {
+				if ok && v {
+					names = append(names, all[i])
+				}
+			}
+			if len(names) == 0 {
+				return
+			}
+		}
+		data, err := os.ReadFile(m.Path())
+		if err != nil && !os.IsNotExist(err) {
+			fail(err)
+		}
+		merged := string(data)
+		total := 0
+		for _, name := range names {
+			// github/gitignore first; the bundled copy when offline or unreachable.
+			body, source := "", "github/gitignore"
+			if !offline {
+				body, err = fetchIgnoreTemplate(name)
+			}
+			if offline || err != nil {
+				bundled, ok := ignoreTemplates[name]
+				if !ok {
+					if err == nil {
+						err = fmt.Errorf("no bundled template %q", name)
+					}
+					fail(fmt.Errorf("%v (bundled: %s)", err, strings.Join(ignoreTemplateNames(), ", ")))
+				}
+				body, source = bundled, "bundled"
+			}
+			var added, skipped int
+			merged, added, skipped = mergeIgnoreRules(merged, name, body)
+			total += added
+			fmt.Printf("   %s%-10s%s %s+%d%s rule(s) %s(%d already present, %s)%s\n", Bold, name, Reset,
+				resolveColor(c.UpToDate), added, Reset, Dim, skipped, source, Reset)
+		}
+		if total == 0 {
+			fmt.Printf("%s .gitignore already has every rule\n", Icons.INFO)
+			return
+		}
+		if _, _, err := m.Backup(defaultIgnoreRemote(m.root)); err != nil {
+			fail(err)
+		}
+		if err := atomicWriteFile(m.Path(), []byte(merged), fileMode(m.Path(), 0o644)); err != nil {
+			fail(err)
+		}
+		fmt.Printf("%s %sAdded %d rule(s) to .gitignore%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), total, Reset)
+
+	case "suggest":
+		runIgnoreSuggest(status, m, yes)
+
+	case "tracked":
+		runIgnoreTracked(status, m, containsString(positional, "--fix"), yes)
+
+	case "lint":
+		runIgnoreLint(status, m, containsString(positional, "--fix"), yes)
+
+	case "why":
+		runIgnoreWhy(status, m, positional)
+
+	default:
+		fail(fmt.Errorf("unknown ignore command %q (add, suggest, tracked, global, lint, why, backup, restore, list)", sub))
+	}
+}
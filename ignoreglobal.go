@@ -0,0 +1,159 @@
+// File: ignoreglobal.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: global excludes file management (`gits ignore global`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// globalExcludesFile returns core.excludesFile, or git's default
+// ($XDG_CONFIG_HOME/git/ignore) when it is unset.  configured reports
+// which.
+func globalExcludesFile() (path string, configured bool) {
+	if p, _ := gitOutput("", "config", "--global", "--path", "--get", "core.excludesFile"); p != "" {
+		return expandHome(p), true
+	}
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, _ := os.UserHomeDir()
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "git", "ignore"), false
+}
+
+// ignoredBySource groups the ignored untracked paths under root by the file
+// whose rule ignores them.
+func ignoredBySource(root string) (map[string][]string, error) {
+	out, err := gitRaw(root, "ls-files", "--others", "--ignored", "--exclude-standard", "--directory", "-z")
+	if err != nil || out == "" {
+		return nil, err
+	}
+	cmd := gitCmd(root, "check-ignore", "--verbose", "--stdin", "-z")
+	cmd.Stdin = strings.NewReader(out)
+	verbose, _ := cmd.Output()
+	groups := map[string][]string{}
+	f := strings.Split(string(verbose), "\x00")
+	for i := 0; i+3 < len(f); i += 4 {
+		source := f[i]
+		if !filepath.IsAbs(source) {
+			source = filepath.Join(root, source)
+		}
+		groups[source] = append(groups[source], f[i+3])
+	}
+	return groups, nil
+}
+
+// runIgnoreGlobal implements `gits ignore global [--create] [--edit]`.
+func runIgnoreGlobal(status *Status, args []string) {
+	c := status.cfg.Colors
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	create, edit := containsString(args, "--create"), containsString(args, "--edit")
+
+	path, configured := globalExcludesFile()
+	note := "core.excludesFile"
+	if !configured {
+		note = "git's default; core.excludesFile is unset"
+	}
+	if !IsFile(path) {
+		if !create && !edit {
+			fmt.Printf("%s No global ignore file at %s %s(%s)%s\n", Icons.INFO, displayPath(path), Dim, note, Reset)
+			fmt.Printf("    %screate one with `gits ignore global --create`%s\n", Dim, Reset)
+			return
+		}
+		// Seed it with the rules for this OS; editor files are a matter of taste.
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			fail(err)
+		}
+		if err := atomicWriteFile(path, []byte(ignoreTemplates[osIgnoreTemplate()]), 0o644); err != nil {
+			fail(err)
+		}
+		fmt.Printf("%s %sCreated%s %s %s(%s rules)%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset,
+			displayPath(path), Dim, osIgnoreTemplate(), Reset)
+	}
+	if edit {
+		editor, _ := gitOutput("", "var", "GIT_EDITOR")
+		if editor == "" {
+			editor = "vi"
+		}
+		cmd := exec.Command("sh", "-c", editor+" "+shellQuote(path))
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			fail(fmt.Errorf("%s: %v", editor, err))
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fail(err)
+	}
+	var rules []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if rule := ignoreRule(line); rule != "" {
+			rules = append(rules, rule)
+		}
+	}
+	fmt.Printf("🙈 %s%s%s %s(%s, %d rule(s))%s\n", Bold, displayPath(path), Reset, Dim, note, len(rules), Reset)
+	for _, r := range rules {
+		fmt.Printf("    %s%s%s\n", resolveColor(c.Untracked), r, Reset)
+	}
+
+	// Inside a repository: which ignored files each layer hides.
+	root, err := repoRoot(".")
+	if err != nil {
+		return
+	}
+	groups, err := ignoredBySource(root)
+	if err != nil {
+		fail(err)
+	}
+	if len(groups) == 0 {
+		return
+	}
+	globalAbs, _ := filepath.EvalSymlinks(path)
+	var sources []string
+	for s := range groups {
+		sources = append(sources, s)
+	}
+	// Global first, then the repository's own files.
+	isGlobal := func(s string) bool {
+		abs, _ := filepath.EvalSymlinks(s)
+		return abs != "" && abs == globalAbs
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if isGlobal(sources[i]) != isGlobal(sources[j]) {
+			return isGlobal(sources[i])
+		}
+		return sources[i] < sources[j]
+	})
+	fmt.Printf("\n%sHidden in %s%s\n", Bold+resolveColor(c.Header), filepath.Base(root), Reset)
+	for _, s := range sources {
+		label, style := "repo", resolveColor(c.TreeFile)
+		name := s
+		if rel, err := filepath.Rel(root, s); err == nil && !strings.HasPrefix(rel, "..") {
+			name = rel
+		}
+		if isGlobal(s) {
+			label, style, name = "global", resolveColor(c.AheadBehind), displayPath(s)
+		}
+		paths := groups[s]
+		fmt.Printf("    %s%-6s%s %s %s(%d path(s))%s\n", Bold+style, label, Reset, name, Dim, len(paths), Reset)
+		for i, p := range paths {
+			if i == 5 {
+				fmt.Printf("        %s… %d more%s\n", Dim, len(paths)-i, Reset)
+				break
+			}
+			fmt.Printf("        %s%s%s\n", Dim, p, Reset)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// File: ignorelint.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: .gitignore linter (`gits ignore lint`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// windowsSeparator finds a backslash used as a path separator.
+var windowsSeparator = regexp.MustCompile(`\\[A-Za-z0-9._-]`)
+
+// lintIssue is one finding on a .gitignore line.  Fixable issues are
+// corrected by --fix: the line is dropped, or replaced by Fix.
+type lintIssue struct {
+	Line    int
+	Kind    string
+	Rule    string
+	Detail  string
+	Fixable bool
+	Fix     string // replacement line; "" drops it
+	Hint    bool   // advice only: does not fail the lint
+}
+
+// hasGlob reports whether a pattern uses wildcard syntax.
+func hasGlob(s string) bool { return strings.ContainsAny(s, "*?[") }
+
+// ruleCovers reports whether ignore pattern a (earlier, not negated)
+// already matches everything pattern b does.  It only answers when it can
+// be sure: b literal, or b inside a directory a ignores.
+func ruleCovers(a, b string) bool {
+	aDir := strings.HasSuffix(a, "/")
+	a = strings.TrimSuffix(a, "/")
+	anchored := strings.Contains(a, "/")
+	a = strings.TrimPrefix(a, "/")
+	if strings.HasPrefix(a, "**/") {
+		a, anchored = a[3:], strings.Contains(a[3:], "/")
+	}
+	b = strings.TrimPrefix(strings.TrimSuffix(b, "/"), "/")
+	bParts := strings.Split(b, "/")
+	// Only the literal leading part of b can be tested.
+	literal := 0
+	for literal < len(bParts) && !hasGlob(bParts[literal]) {
+		literal++
+	}
+	if literal == 0 {
+		return false
+	}
+	for k := 1; k <= literal; k++ {
+		// A match on a leading directory covers everything below it; a match
+		// on b itself needs a to not be directory-only.
+		if k == len(bParts) && aDir {
+			continue
+		}
+		if anchored {
+			if ok, _ := path.Match(a, strings.Join(bParts[:k], "/")); ok {
+				return true
+			}
+		} else if ok, _ := path.Match(a, bParts[k-1]); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleMatchesAnything asks git whether pattern ignores any tracked or
+// untracked path under root.
+func ruleMatchesAnything(root, pattern string) bool {
+	if out, _ := gitRaw(root, "ls-files", "--cached", "--ignored", "--exclude="+pattern); out != "" {
+		return true
+	}
+	out, _ := gitRaw(root, "ls-files", "--others", "--ignored", "--directory", "--no-empty-directory", "--exclude="+pattern)
+	return out != ""
+}
+
+// lintIgnore checks the lines of a .gitignore at the top of root.
+func lintIgnore(root string, lines []string) []lintIssue {
+	var issues []lintIssue
+	type seenRule struct {
+		line int
+		rule string
+	}
+	var earlier []seenRule
+	first := map[string]int{}
+	lastNegation := 0
+	var live []int // line numbers checked against the tree
+	for i, raw := range lines {
+		n := i + 1
+		trimmedRight := strings.TrimRight(raw, " \t")
+		// An escaped trailing space ("foo\ ") is intentional.
+		if trimmedRight != raw && !strings.HasSuffix(trimmedRight, "\\") && strings.TrimSpace(raw) != "" {
+			issues = append(issues, lintIssue{Line: n, Kind: "whitespace", Rule: raw,
+				Detail: "trailing whitespace is ignored by git", Fixable: true, Fix: trimmedRight})
+		}
+		rule := ignoreRule(raw)
+		if rule == "" {
+			continue
+		}
+		// "\#", "\!", "\*" and "\ " are escapes; "\b" in "build\bin" is a
+		// Windows path separator that git reads as a plain "b".
+		if windowsSeparator.MatchString(rule) {
+			issues = append(issues, lintIssue{Line: n, Kind: "backslash", Rule: rule,
+				Detail: "git paths use /, not \\ (backslash escapes the next character)", Fixable: true,
+				Fix: windowsSeparator.ReplaceAllStringFunc(rule, func(m string) string { return "/" + m[1:] })})
+			continue
+		}
+		if strings.HasPrefix(rule, "!") {
+			target := strings.TrimPrefix(rule, "!")
+			for _, e := range earlier {
+				if strings.HasSuffix(e.rule, "/") && !strings.HasPrefix(e.rule, "!") && ruleCovers(e.rule, target) && target != strings.TrimSuffix(e.rule, "/") {
+					issues = append(issues, lintIssue{Line: n, Kind: "unreachable", Rule: rule,
+						Detail: fmt.Sprintf("line %d ignores the whole directory, so git never looks inside to re-include this (use %s* instead)", e.line, e.rule)})
+					break
+				}
+			}
+			lastNegation = n
+			earlier = append(earlier, seenRule{n, rule})
+			continue
+		}
+		if prev, ok := first[rule]; ok {
+			issues = append(issues, lintIssue{Line: n, Kind: "duplicate", Rule: rule,
+				Detail: fmt.Sprintf("same as line %d", prev), Fixable: true})
+			continue
+		}
+		first[rule] = n
+		shadowed := false
+		for _, e := range earlier {
+			// A negation in between may re-include part of it: not provable.
+			if e.line <= lastNegation || strings.HasPrefix(e.rule, "!") {
+				continue
+			}
+			if ruleCovers(e.rule, rule) {
+				issues = append(issues, lintIssue{Line: n, Kind: "shadowed", Rule: rule,
+					Detail: fmt.Sprintf("already ignored by %s on line %d", e.rule, e.line), Fixable: true})
+				shadowed = true
+				break
+			}
+		}
+		earlier = append(earlier, seenRule{n, rule})
+		if !shadowed {
+			live = append(live, n)
+		}
+	}
+
+	// Dead rules need git and the tree; ask in parallel.
+	dead := make([]lintIssue, len(live))
+	parallelEach(len(live), func(i int) {
+		n := live[i]
+		rule := ignoreRule(lines[n-1])
+		if ruleMatchesAnything(root, rule) {
+			return
+		}
+		issue := lintIssue{Line: n, Kind: "unused", Rule: rule, Detail: "matches nothing in the tree", Hint: true}
+		// "docs/build" is anchored to the top because of its slash.
+		inner := strings.TrimSuffix(strings.TrimPrefix(rule, "/"), "/")
+		if !strings.HasPrefix(rule, "/") && !strings.HasPrefix(rule, "**/") && strings.Contains(inner, "/") &&
+			ruleMatchesAnything(root, "**/"+rule) {
+			issue.Detail = "a / in the middle anchors it to the top; **/" + rule + " would match at any depth"
+			issue.Hint = false
+		}
+		dead[i] = issue
+	})
+	for _, d := range dead {
+		if d.Kind != "" {
+			issues = append(issues, d)
+		}
+	}
+	return issues
+}
+
+// runIgnoreLint implements `gits ignore lint [--fix] [--yes]`.
+func runIgnoreLint(status *Status, m *GitIgnoreManager, fix, yes bool) {
+	c := status.cfg.Colors
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	data, err := os.ReadFile(m.Path())
+	if os.IsNotExist(err) {
+		fmt.Printf("%s No .gitignore at the top of the repository\n", Icons.INFO)
+		return
+	}
+	if err != nil {
+		fail(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	issues := lintIgnore(m.root, lines)
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	if len(issues) == 0 {
+		fmt.Printf("%s %s.gitignore looks clean%s %s(%d line(s))%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset, Dim, len(lines), Reset)
+		return
+	}
+
+	problems, fixable := 0, 0
+	for _, is := range issues {
+		style := resolveColor(c.AheadBehind)
+		if is.Hint {
+			style = Dim
+		} else {
+			problems++
+		}
+		if is.Fixable {
+			fixable++
+		}
+		fmt.Printf("  %s%4d%s  %s%-11s%s %s\"%s\"%s  %s%s%s\n", Dim, is.Line, Reset, Bold+style, is.Kind, Reset,
+			resolveColor(c.Untracked), is.Rule, Reset, Dim, is.Detail, Reset)
+	}
+	fmt.Printf("%s %d problem(s), %d hint(s)", Icons.WARNING, problems, len(issues)-problems)
+	if fixable > 0 && !fix {
+		fmt.Printf(" %s(%d fixable: gits ignore lint --fix)%s", Dim, fixable, Reset)
+	}
+	fmt.Println()
+	if !fix || fixable == 0 {
+		if problems > 0 {
+			exit(1)
+		}
+		return
+	}
+
+	// Apply fixes: drop duplicates and shadowed rules, rewrite the rest.
+	drop := map[int]bool{}
+	replace := map[int]string{}
+	for _, is := range issues {
+		if !is.Fixable {
+			continue
+		}
+		if is.Fix == "" {
+			drop[is.Line] = true
+		} else {
+			replace[is.Line] = is.Fix
+		}
+	}
+	var out []string
+	for i, line := range lines {
+		if drop[i+1] {
+			continue
+		}
+		if r, ok := replace[i+1]; ok {
+			line = r
+		}
+		out = append(out, line)
+	}
+	fixed := strings.Join(out, "\n") + "\n"
+	if !yes {
+		ok, err := confirm(fmt.Sprintf("Apply %d fix(es) to .gitignore?", fixable))
+		if err != nil {
+			fail(fmt.Errorf("%v (use --yes)", err))
+		}
+		if !ok {
+			return
+		}
+	}
+	if _, _, err := m.Backup(defaultIgnoreRemote(m.root)); err != nil {
+		fail(err)
+	}
+	if err := atomicWriteFile(m.Path(), []byte(fixed), fileMode(m.Path(), 0o644)); err != nil {
+		fail(err)
+	}
+	fmt.Printf("%s %sFixed %d issue(s)%s %s(previous version kept: gits ignore list)%s\n", Icons.SUCCESS,
+		resolveColor(c.UpToDate), fixable, Reset, Dim, Reset)
+}
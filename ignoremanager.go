@@ -0,0 +1,275 @@
+// File: ignoremanager.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: per-remote .gitignore backups and restore around operations
+// License: MIT
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ignoreBackupsKept is how many backups are kept per remote profile.
+const ignoreBackupsKept = 20
+
+// IgnoreProfile is the .gitignore a remote should see, from
+// [ignore.profiles.<remote>] in the config: File replaces the repository's
+// .gitignore (relative paths are from the repository root), Rules are
+// appended to it.
+type IgnoreProfile struct {
+	File  string   `toml:"file"`
+	Rules []string `toml:"rules"`
+}
+
+// IgnoreConfig holds the per-remote ignore profiles.
+type IgnoreConfig struct {
+	Profiles map[string]IgnoreProfile `toml:"profiles"`
+}
+
+// ignoreBackup is one saved copy of .gitignore.
+type ignoreBackup struct {
+	Remote string
+	Path   string
+	Time   time.Time
+	Size   int64
+}
+
+// ignoreBaseline is .gitignore as it was when an operation started.
+type ignoreBaseline struct {
+	data    []byte
+	existed bool
+}
+
+// GitIgnoreManager keeps .gitignore backups per remote profile under
+// .git/gits/ignore/<remote>/ and puts the file back after operations that
+// may swap it.
+type GitIgnoreManager struct {
+	root      string
+	dir       string
+	baselines map[string]ignoreBaseline
+}
+
+// NewGitIgnoreManager returns the manager for the repository containing dir.
+func NewGitIgnoreManager(dir string) (*GitIgnoreManager, error) {
+	root, err := repoRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	gitDir, err := gitOutput(root, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return nil, err
+	}
+	return &GitIgnoreManager{
+		root:      root,
+		dir:       filepath.Join(gitDir, "gits", "ignore"),
+		baselines: map[string]ignoreBaseline{},
+	}, nil
+}
+
+// Path is the repository's top-level .gitignore.
+func (m *GitIgnoreManager) Path() string { return filepath.Join(m.root, ".gitignore") }
+
+// profile turns a remote name into a directory name; "" is "default".
+func profile(remote string) string {
+	if remote == "" {
+		return "default"
+	}
+	return strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(remote)
+}
+
+// Backups lists the backups of remote, newest first.
+func (m *GitIgnoreManager) Backups(remote string) ([]ignoreBackup, error) {
+	entries, err := os.ReadDir(filepath.Join(m.dir, profile(remote)))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []ignoreBackup
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".gitignore") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		list = append(list, ignoreBackup{
+			Remote: profile(remote),
+			Path:   filepath.Join(m.dir, profile(remote), e.Name()),
+			Time:   info.ModTime(),
+			Size:   info.Size(),
+		})
+	}
+	// Names are timestamps, so reverse name order is newest first.
+	sort.Slice(list, func(i, j int) bool { return list[i].Path > list[j].Path })
+	return list, nil
+}
+
+// Remotes lists the profiles that have backups.
+func (m *GitIgnoreManager) Remotes() []string {
+	entries, _ := os.ReadDir(m.dir)
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Backup saves the current .gitignore for remote.  It returns false when
+// there is no .gitignore or it matches the newest backup already.
+func (m *GitIgnoreManager) Backup(remote string) (ignoreBackup, bool, error) {
+	data, err := os.ReadFile(m.Path())
+	if os.IsNotExist(err) {
+		return ignoreBackup{}, false, nil
+	}
+	if err != nil {
+		return ignoreBackup{}, false, err
+	}
+	list, err := m.Backups(remote)
+	if err != nil {
+		return ignoreBackup{}, false, err
+	}
+	if len(list) > 0 {
+		if last, err := os.ReadFile(list[0].Path); err == nil && bytes.Equal(last, data) {
+			return list[0], false, nil
+		}
+	}
+	dir := filepath.Join(m.dir, profile(remote))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return ignoreBackup{}, false, err
+	}
+	now := time.Now()
+	path := filepath.Join(dir, now.Format("20060102-150405.000000")+".gitignore")
+	if err := atomicWriteFile(path, data, 0o644); err != nil {
+		return ignoreBackup{}, false, err
+	}
+	for _, old := range list[min(len(list), ignoreBackupsKept-1):] {
+		os.Remove(old.Path)
+	}
+	return ignoreBackup{Remote: profile(remote), Path: path, Time: now, Size: int64(len(data))}, true, nil
+}
+
+// Restore replaces .gitignore with backup b, atomically.
+func (m *GitIgnoreManager) Restore(b ignoreBackup) error {
+	data, err := os.ReadFile(b.Path)
+	if err != nil {
+		return err
+	}
+	return atomicWriteFile(m.Path(), data, fileMode(m.Path(), 0o644))
+}
+
+// CheckGitignore is called before a status or push involving remote: it
+// backs .gitignore up under the remote's profile and remembers it, so
+// RestoreGitignore can undo any change made while the operation ran.
+func (m *GitIgnoreManager) CheckGitignore(remote string) error {
+	data, err := os.ReadFile(m.Path())
+	switch {
+	case os.IsNotExist(err):
+		m.baselines[remote] = ignoreBaseline{}
+		return nil
+	case err != nil:
+		return err
+	}
+	m.baselines[remote] = ignoreBaseline{data: data, existed: true}
+	_, _, err = m.Backup(remote)
+	return err
+}
+
+// RestoreGitignore puts .gitignore back the way CheckGitignore found it,
+// if it changed since.  It is a no-op without a matching CheckGitignore.
+func (m *GitIgnoreManager) RestoreGitignore(remote string) error {
+	base, ok := m.baselines[remote]
+	if !ok {
+		return nil
+	}
+	delete(m.baselines, remote)
+	data, err := os.ReadFile(m.Path())
+	exists := err == nil
+	if exists == base.existed && bytes.Equal(data, base.data) {
+		return nil
+	}
+	if !base.existed {
+		return os.Remove(m.Path())
+	}
+	return atomicWriteFile(m.Path(), base.data, fileMode(m.Path(), 0o644))
+}
+
+// ApplyProfile swaps in the profile for remote.  Call it after
+// CheckGitignore, so RestoreGitignore puts the original back.  It reports
+// whether .gitignore changed.
+func (m *GitIgnoreManager) ApplyProfile(remote string, p IgnoreProfile) (bool, error) {
+	if _, ok := m.baselines[remote]; !ok {
+		return false, fmt.Errorf("ignore profile %s applied without a backup", remote)
+	}
+	current, err := os.ReadFile(m.Path())
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	content := string(current)
+	if p.File != "" {
+		file := expandHome(p.File)
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(m.root, file)
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return false, fmt.Errorf("ignore profile %s: %w", remote, err)
+		}
+		content = string(data)
+	}
+	if len(p.Rules) > 0 {
+		content, _, _ = mergeIgnoreRules(content, "profile "+remote, strings.Join(p.Rules, "\n"))
+	}
+	if content == string(current) {
+		return false, nil
+	}
+	return true, atomicWriteFile(m.Path(), []byte(content), fileMode(m.Path(), 0o644))
+}
+
+// fileMode returns path's permission bits, or def when it does not exist.
+func fileMode(path string, def os.FileMode) os.FileMode {
+	if info, err := os.Stat(path); err == nil {
+		return info.Mode().Perm()
+	}
+	return def
+}
+
+// atomicWriteFile writes data to a temporary file next to path and renames
+// it into place, so readers never see a half-written file.
+func atomicWriteFile(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	name := tmp.Name()
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(name, perm)
+	}
+	if err == nil {
+		err = os.Rename(name, path)
+	}
+	if err != nil {
+		os.Remove(name)
+		return fmt.Errorf("write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
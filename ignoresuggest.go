@@ -0,0 +1,217 @@
+// File: ignoresuggest.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: .gitignore suggestions from untracked files (`gits ignore suggest`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ignoreDirRules are directory names that are almost always generated.
+var ignoreDirRules = map[string]string{
+	"node_modules":     "dependencies",
+	"vendor":           "dependencies",
+	"bower_components": "dependencies",
+	".venv":            "virtualenv",
+	"venv":             "virtualenv",
+	"__pycache__":      "bytecode",
+	".pytest_cache":    "test cache",
+	".mypy_cache":      "type-check cache",
+	".ruff_cache":      "lint cache",
+	".tox":             "test envs",
+	".cache":           "cache",
+	".gradle":          "build cache",
+	".next":            "build output",
+	".nuxt":            "build output",
+	"build":            "build output",
+	"dist":             "build output",
+	"out":              "build output",
+	"target":           "build output",
+	"bin":              "build output",
+	"obj":              "build output",
+	"coverage":         "coverage report",
+	"htmlcov":          "coverage report",
+	".idea":            "JetBrains settings",
+	".vscode":          "VS Code settings",
+}
+
+// ignoreFileRules map a file-name test to the rule that covers it.
+var ignoreFileRules = []struct {
+	match  func(name string) bool
+	rule   string
+	reason string
+}{
+	{func(n string) bool { return n == ".DS_Store" }, ".DS_Store", "macOS metadata"},
+	{func(n string) bool { return n == "Thumbs.db" || n == "desktop.ini" }, "Thumbs.db", "Windows metadata"},
+	{func(n string) bool { return strings.HasSuffix(n, ".log") }, "*.log", "logs"},
+	{func(n string) bool { return strings.HasSuffix(n, "~") }, "*~", "backup files"},
+	{func(n string) bool { return strings.HasSuffix(n, ".bak") || strings.HasSuffix(n, ".orig") }, "*.bak", "backup files"},
+	{func(n string) bool { return strings.HasSuffix(n, ".tmp") || strings.HasSuffix(n, ".temp") }, "*.tmp", "temporary files"},
+	{func(n string) bool {
+		return strings.HasPrefix(n, ".") && (strings.HasSuffix(n, ".swp") || strings.HasSuffix(n, ".swo"))
+	}, "*.sw[op]", "editor swap files"},
+	{func(n string) bool { return strings.HasSuffix(n, ".pyc") || strings.HasSuffix(n, ".pyo") }, "*.py[co]", "bytecode"},
+	{func(n string) bool { return strings.HasSuffix(n, ".class") }, "*.class", "bytecode"},
+	{func(n string) bool { return strings.HasSuffix(n, ".o") || strings.HasSuffix(n, ".obj") }, "*.o", "object files"},
+	{func(n string) bool { return strings.HasSuffix(n, ".exe") || strings.HasSuffix(n, ".dll") }, "*.exe", "binaries"},
+	{func(n string) bool { return strings.HasSuffix(n, ".test") || strings.HasSuffix(n, ".out") }, "*.test", "test binaries"},
+	{func(n string) bool {
+		return n == ".env" || strings.HasPrefix(n, ".env.") && !strings.HasSuffix(n, ".example")
+	}, ".env", "secrets"},
+	{func(n string) bool { return strings.HasSuffix(n, ".pem") || strings.HasSuffix(n, ".key") }, "*.pem", "keys"},
+}
+
+// ignoreSuggestion is one proposed rule and the untracked paths it covers.
+type ignoreSuggestion struct {
+	Rule   string
+	Reason string
+	Paths  []string
+}
+
+// suggestIgnoreRules proposes rules for the untracked paths, most covering
+// first.  A path under a suggested directory is not also matched by name.
+func suggestIgnoreRules(untracked []string) []ignoreSuggestion {
+	byRule := map[string]*ignoreSuggestion{}
+	add := func(rule, reason, p string) {
+		s := byRule[rule]
+		if s == nil {
+			s = &ignoreSuggestion{Rule: rule, Reason: reason}
+			byRule[rule] = s
+		}
+		s.Paths = append(s.Paths, p)
+	}
+	for _, p := range untracked {
+		parts := strings.Split(strings.TrimSuffix(p, "/"), "/")
+		matched := false
+		for i, part := range parts[:len(parts)-1] {
+			if reason, ok := ignoreDirRules[part]; ok {
+				rule := part + "/"
+				if part == "bin" || part == "obj" || part == "out" || part == "build" || part == "dist" || part == "target" {
+					// Generic names only as top-level build dirs.
+					if i > 0 {
+						continue
+					}
+					rule = "/" + rule
+				}
+				add(rule, reason, p)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		name := path.Base(p)
+		for _, r := range ignoreFileRules {
+			if r.match(name) {
+				add(r.rule, r.reason, p)
+				break
+			}
+		}
+	}
+	list := make([]ignoreSuggestion, 0, len(byRule))
+	for _, s := range byRule {
+		list = append(list, *s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if len(list[i].Paths) != len(list[j].Paths) {
+			return len(list[i].Paths) > len(list[j].Paths)
+		}
+		return list[i].Rule < list[j].Rule
+	})
+	return list
+}
+
+// runIgnoreSuggest implements `gits ignore suggest [--yes]`.
+func runIgnoreSuggest(status *Status, m *GitIgnoreManager, yes bool) {
+	c := status.cfg.Colors
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	out, err := gitRaw(m.root, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		fail(err)
+	}
+	var untracked []string
+	for _, p := range strings.Split(out, "\x00") {
+		if p != "" {
+			untracked = append(untracked, p)
+		}
+	}
+	suggestions := suggestIgnoreRules(untracked)
+	if len(suggestions) == 0 {
+		fmt.Printf("%s %sNothing to suggest%s %s(%d untracked file(s) look like source)%s\n", Icons.SUCCESS,
+			resolveColor(c.UpToDate), Reset, Dim, len(untracked), Reset)
+		return
+	}
+
+	items := make([]pickItem, len(suggestions))
+	for i, s := range suggestions {
+		sample := s.Paths[0]
+		if len(s.Paths) > 1 {
+			sample += fmt.Sprintf(", +%d more", len(s.Paths)-1)
+		}
+		items[i] = pickItem{
+			Tag:      fmt.Sprintf("%-16s", s.Rule),
+			TagStyle: Bold + resolveColor(c.Untracked),
+			Label:    fmt.Sprintf("%s %s(%d file(s): %s)%s", s.Reason, Dim, len(s.Paths), sample, Reset),
+			Checked:  true,
+		}
+	}
+	checked := make([]bool, len(items))
+	if yes {
+		for i := range checked {
+			checked[i] = true
+		}
+	} else {
+		var ok bool
+		checked, ok, err = runPicker("Add these rules to .gitignore?", items, c)
+		if err != nil {
+			fail(fmt.Errorf("%v (use --yes)", err))
+		}
+		if !ok {
+			fmt.Printf("%s Cancelled, .gitignore unchanged\n", Icons.INFO)
+			return
+		}
+	}
+	var rules []string
+	covered := 0
+	for i, v := range checked {
+		if v {
+			rules = append(rules, suggestions[i].Rule)
+			covered += len(suggestions[i].Paths)
+		}
+	}
+	if len(rules) == 0 {
+		fmt.Printf("%s Nothing selected\n", Icons.INFO)
+		return
+	}
+
+	data, err := os.ReadFile(m.Path())
+	if err != nil && !os.IsNotExist(err) {
+		fail(err)
+	}
+	merged, added, _ := mergeIgnoreRules(string(data), "suggested", strings.Join(rules, "\n"))
+	if added == 0 {
+		fmt.Printf("%s .gitignore already has these rules\n", Icons.INFO)
+		return
+	}
+	if _, _, err := m.Backup(defaultIgnoreRemote(m.root)); err != nil {
+		fail(err)
+	}
+	if err := atomicWriteFile(m.Path(), []byte(merged), fileMode(m.Path(), 0o644)); err != nil {
+		fail(err)
+	}
+	fmt.Printf("%s %sAdded %d rule(s) to .gitignore%s %s(%d untracked file(s) now ignored)%s\n", Icons.SUCCESS,
+		resolveColor(c.UpToDate), added, Reset, Dim, covered, Reset)
+	for _, r := range rules {
+		fmt.Printf("    %s%s%s\n", resolveColor(c.Untracked), r, Reset)
+	}
+}
@@ -0,0 +1,262 @@
+// File: ignoretemplates.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: bundled .gitignore templates and project-type detection
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ignoreTemplates are condensed versions of the common templates from
+// github.com/github/gitignore, usable offline.  Keys are lower case.
+var ignoreTemplates = map[string]string{
+	"go": `# Go
+*.exe
+*.exe~
+*.dll
+*.so
+*.dylib
+*.test
+*.out
+go.work.sum
+vendor/
+`,
+	"node": `# Node
+node_modules/
+npm-debug.log*
+yarn-debug.log*
+yarn-error.log*
+pnpm-debug.log*
+.npm
+.eslintcache
+.env
+.env.*.local
+dist/
+coverage/
+`,
+	"python": `# Python
+__pycache__/
+*.py[cod]
+*$py.class
+*.egg-info/
+.eggs/
+build/
+dist/
+.venv/
+venv/
+.env
+.pytest_cache/
+.mypy_cache/
+.ruff_cache/
+.coverage
+htmlcov/
+`,
+	"rust": `# Rust
+/target/
+**/*.rs.bk
+`,
+	"java": `# Java
+*.class
+*.jar
+*.war
+*.ear
+hs_err_pid*
+target/
+build/
+.gradle/
+`,
+	"c": `# C / C++
+*.o
+*.obj
+*.a
+*.lib
+*.so
+*.dylib
+*.dll
+*.exe
+*.out
+build/
+cmake-build-*/
+CMakeFiles/
+CMakeCache.txt
+`,
+	"macos": `# macOS
+.DS_Store
+.AppleDouble
+.LSOverride
+._*
+`,
+	"windows": `# Windows
+Thumbs.db
+ehthumbs.db
+Desktop.ini
+$RECYCLE.BIN/
+`,
+	"linux": `# Linux
+*~
+.fuse_hidden*
+.directory
+.Trash-*
+.nfs*
+`,
+	"vscode": `# VS Code
+.vscode/*
+!.vscode/settings.json
+!.vscode/tasks.json
+!.vscode/launch.json
+!.vscode/extensions.json
+`,
+	"jetbrains": `# JetBrains
+.idea/
+*.iml
+out/
+`,
+	"vim": `# Vim
+[._]*.s[a-v][a-z]
+[._]*.sw[a-p]
+Session.vim
+tags
+`,
+}
+
+// ignoreTemplateNames returns the bundled template names, sorted.
+func ignoreTemplateNames() []string {
+	names := make([]string, 0, len(ignoreTemplates))
+	for name := range ignoreTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// projectMarkers map a file found at the top of a project to the template
+// it suggests.
+var projectMarkers = map[string]string{
+	"go.mod":           "go",
+	"package.json":     "node",
+	"pyproject.toml":   "python",
+	"requirements.txt": "python",
+	"setup.py":         "python",
+	"Cargo.toml":       "rust",
+	"pom.xml":          "java",
+	"build.gradle":     "java",
+	"build.gradle.kts": "java",
+	"CMakeLists.txt":   "c",
+	"Makefile":         "c",
+	".vscode":          "vscode",
+	".idea":            "jetbrains",
+}
+
+// detectIgnoreTemplates suggests templates for the project in dir from its
+// top-level files.
+func detectIgnoreTemplates(dir string) []string {
+	seen := map[string]bool{}
+	for marker, name := range projectMarkers {
+		if Exists(filepath.Join(dir, marker)) {
+			seen[name] = true
+		}
+	}
+	if entries, err := os.ReadDir(dir); err == nil {
+		for _, e := range entries {
+			if strings.HasSuffix(e.Name(), ".c") || strings.HasSuffix(e.Name(), ".cpp") {
+				seen["c"] = true
+			}
+		}
+	}
+	var names []string
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// githubIgnorePaths name the github/gitignore file for bundled templates
+// whose key is not simply the capitalized file name.
+var githubIgnorePaths = map[string]string{
+	"node":      "Node",
+	"c":         "C++",
+	"macos":     "Global/macOS",
+	"windows":   "Global/Windows",
+	"linux":     "Global/Linux",
+	"vscode":    "Global/VisualStudioCode",
+	"jetbrains": "Global/JetBrains",
+	"vim":       "Global/Vim",
+}
+
+// fetchIgnoreTemplate downloads name from github.com/github/gitignore.
+func fetchIgnoreTemplate(name string) (string, error) {
+	path, ok := githubIgnorePaths[name]
+	if !ok {
+		path = strings.ToUpper(name[:1]) + name[1:]
+	}
+	req, err := http.NewRequest("GET", "https://raw.githubusercontent.com/github/gitignore/main/"+path+".gitignore", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "gits-go/1.0")
+	resp, err := (&http.Client{Timeout: 8 * time.Second}).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("no template %q on github/gitignore (%s)", name, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	return string(body), err
+}
+
+// ignoreRule normalizes a .gitignore line for comparison; "" for blank
+// lines and comments.
+func ignoreRule(line string) string {
+	rule := strings.TrimSpace(line)
+	if rule == "" || strings.HasPrefix(rule, "#") {
+		return ""
+	}
+	// An escaped trailing space ("foo\ ") is part of the pattern.
+	if strings.HasSuffix(rule, "\\") && strings.HasPrefix(line[strings.LastIndex(line, "\\")+1:], " ") {
+		rule += " "
+	}
+	return rule
+}
+
+// mergeIgnoreRules appends the rules of template name to existing under a
+// "# name" header, leaving out rules existing already has.
+func mergeIgnoreRules(existing, name, template string) (merged string, added, skipped int) {
+	have := map[string]bool{}
+	for _, line := range strings.Split(existing, "\n") {
+		if rule := ignoreRule(line); rule != "" {
+			have[rule] = true
+		}
+	}
+	var block []string
+	for _, line := range strings.Split(template, "\n") {
+		rule := ignoreRule(line)
+		switch {
+		case rule == "":
+		case have[rule]:
+			skipped++
+		default:
+			have[rule] = true
+			block = append(block, rule)
+		}
+	}
+	if len(block) == 0 {
+		return existing, 0, skipped
+	}
+	if existing != "" {
+		existing = strings.TrimRight(existing, "\n") + "\n\n"
+	}
+	return existing + "# " + name + " (gits)\n" + strings.Join(block, "\n") + "\n", len(block), skipped
+}
@@ -0,0 +1,105 @@
+// File: ignoretracked.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: tracked files that match .gitignore rules
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ignoredTracked is a tracked file and the rule that would ignore it.
+type ignoredTracked struct {
+	Path   string `json:"path"`
+	Source string `json:"source,omitempty"` // "<file>:<line>"
+	Rule   string `json:"rule,omitempty"`
+}
+
+// trackedIgnored lists tracked files under root that match an ignore rule.
+// git keeps tracking them regardless, which is easy to forget.
+func trackedIgnored(root string) ([]ignoredTracked, error) {
+	out, err := gitRaw(root, "ls-files", "--cached", "--ignored", "--exclude-standard", "-z")
+	if err != nil || out == "" {
+		return nil, err
+	}
+	paths := strings.TrimSuffix(out, "\x00")
+	cmd := gitCmd(root, "check-ignore", "--no-index", "--verbose", "--stdin", "-z")
+	cmd.Stdin = strings.NewReader(paths + "\x00")
+	verbose, _ := cmd.Output()
+	// -z -v output: source, line, pattern, path — NUL separated.
+	rules := map[string]ignoredTracked{}
+	f := strings.Split(string(verbose), "\x00")
+	for i := 0; i+3 < len(f); i += 4 {
+		rules[f[i+3]] = ignoredTracked{Path: f[i+3], Source: displayPath(f[i]) + ":" + f[i+1], Rule: f[i+2]}
+	}
+	var list []ignoredTracked
+	for _, p := range strings.Split(paths, "\x00") {
+		if it, ok := rules[p]; ok {
+			list = append(list, it)
+		} else {
+			list = append(list, ignoredTracked{Path: p})
+		}
+	}
+	return list, nil
+}
+
+// writeTrackedIgnored is the optional status section for trackedIgnored
+// (show_tracked_ignored = true or --tracked-ignored).
+func writeTrackedIgnored(w io.Writer, list []ignoredTracked, c ColorConfig) {
+	fmt.Fprintf(w, "%s%s%s\n", Bold+resolveColor(c.Header), "Tracked but ignored:", Reset)
+	fmt.Fprintf(w, "    %s(use \"git rm --cached <file>...\" to stop tracking, or gits ignore tracked --fix)%s\n", Dim, Reset)
+	for _, it := range list {
+		fmt.Fprintf(w, "\t%s%s %s%s", resolveColor(c.AheadBehind), getFileEmoji(it.Path), it.Path, Reset)
+		if it.Rule != "" {
+			fmt.Fprintf(w, "  %s%s %s%s", Dim, it.Source, it.Rule, Reset)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// runIgnoreTracked implements `gits ignore tracked [--fix] [--yes]`:
+// list tracked-but-ignored files, and with --fix untrack them (the files
+// stay on disk).
+func runIgnoreTracked(status *Status, m *GitIgnoreManager, fix, yes bool) {
+	c := status.cfg.Colors
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	list, err := trackedIgnored(m.root)
+	if err != nil {
+		fail(err)
+	}
+	if len(list) == 0 {
+		fmt.Printf("%s %sNo tracked file matches an ignore rule%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+		return
+	}
+	writeTrackedIgnored(os.Stdout, list, c)
+	if !fix {
+		return
+	}
+	if !yes {
+		ok, err := confirm(fmt.Sprintf("Stop tracking %d file(s)? They stay on disk.", len(list)))
+		if err != nil {
+			fail(fmt.Errorf("%v (use --yes)", err))
+		}
+		if !ok {
+			return
+		}
+	}
+	var paths []string
+	for _, it := range list {
+		paths = append(paths, it.Path)
+	}
+	cmd := gitCmd(m.root, "rm", "--cached", "--quiet", "--pathspec-from-file=-", "--pathspec-file-nul")
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\x00"))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		fail(fmt.Errorf("git rm --cached: %s", strings.TrimSpace(string(out))))
+	}
+	fmt.Printf("%s %sUntracked %d file(s)%s %s(staged as deletions; commit to finish)%s\n", Icons.SUCCESS,
+		resolveColor(c.UpToDate), len(paths), Reset, Dim, Reset)
+}
@@ -0,0 +1,82 @@
+// File: ignorewhy.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: explain whether and why paths are ignored (`gits ignore why`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ignoreSourceLabel names the layer a check-ignore source file belongs to.
+func ignoreSourceLabel(root, source string) string {
+	// check-ignore reports repository files relative to the top.
+	abs := source
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(root, source)
+	}
+	if global, _ := globalExcludesFile(); global != "" {
+		g, _ := filepath.EvalSymlinks(global)
+		if a, _ := filepath.EvalSymlinks(abs); a != "" && a == g {
+			return "global excludes file"
+		}
+	}
+	if strings.HasSuffix(filepath.ToSlash(abs), "/info/exclude") {
+		return "repository-local exclude, not shared"
+	}
+	if rel, err := filepath.Rel(root, abs); err == nil && rel == ".gitignore" {
+		return "top-level .gitignore"
+	}
+	return "nested .gitignore"
+}
+
+// runIgnoreWhy implements `gits ignore why PATH...`.
+func runIgnoreWhy(status *Status, m *GitIgnoreManager, paths []string) {
+	c := status.cfg.Colors
+	if len(paths) == 0 {
+		fmt.Printf("%s %susage: gits ignore why PATH...%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), Reset)
+		exit(1)
+	}
+	// --no-index: report the rule even for tracked files, then say that
+	// tracking wins.
+	cmd := gitCmd(".", "check-ignore", "--no-index", "--verbose", "--non-matching", "--stdin", "-z")
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\x00") + "\x00")
+	out, _ := cmd.Output()
+	f := strings.Split(string(out), "\x00")
+	type verdict struct{ source, line, pattern string }
+	verdicts := map[string]verdict{}
+	for i := 0; i+3 < len(f); i += 4 {
+		verdicts[f[i+3]] = verdict{f[i], f[i+1], f[i+2]}
+	}
+
+	for _, p := range paths {
+		v := verdicts[p]
+		tracked, _ := gitOutput(".", "ls-files", "--", p)
+		negated := strings.HasPrefix(v.pattern, "!")
+		ignored := v.source != "" && !negated
+		switch {
+		case ignored && tracked != "":
+			fmt.Printf("%s %s%s%s %s— matches an ignore rule but is tracked, so git still tracks it%s\n",
+				Icons.WARNING, Bold+resolveColor(c.AheadBehind), p, Reset, Dim, Reset)
+		case ignored:
+			fmt.Printf("🙈 %s%s%s %sis ignored%s\n", Bold+resolveColor(c.Untracked), p, Reset, Dim, Reset)
+		case negated:
+			fmt.Printf("%s %s%s%s %sis re-included by a negation%s\n", Icons.SUCCESS, Bold+resolveColor(c.UpToDate), p, Reset, Dim, Reset)
+		default:
+			fmt.Printf("%s %s%s%s %sis not ignored: no rule matches%s\n", Icons.INFO, Bold, p, Reset, Dim, Reset)
+			continue
+		}
+		fmt.Printf("    %srule   %s %s%s%s\n", Dim, Reset, Bold+resolveColor(c.Untracked), v.pattern, Reset)
+		fmt.Printf("    %sfrom   %s %s:%s %s(%s)%s\n", Dim, Reset, displayPath(v.source), v.line, Dim, ignoreSourceLabel(m.root, v.source), Reset)
+		if ignored && tracked != "" {
+			fmt.Printf("    %sstop tracking it with `git rm --cached %s` (or gits ignore tracked --fix)%s\n", Dim, shellQuote(p), Reset)
+		}
+		if ignored && strings.HasSuffix(v.pattern, "/") && !strings.HasSuffix(strings.TrimSuffix(p, "/"), strings.Trim(v.pattern, "/")) {
+			fmt.Printf("    %sa parent directory is ignored, so no rule can re-include this path%s\n", Dim, Reset)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// File: init.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: guided repository initialization (`gits init`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// osIgnoreTemplate is the bundled template for the operating system gits
+// runs on.
+func osIgnoreTemplate() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "macos"
+	case "windows":
+		return "windows"
+	}
+	return "linux"
+}
+
+// runInit implements `gits init [DIR] [--branch NAME] [--ignore a,b]
+// [--commit] [--remote URL] [--yes]`.  Each flag answers one question of
+// the walkthrough; --yes takes the defaults for the rest.
+func runInit(status *Status, args []string) {
+	c := status.cfg.Colors
+	dir, branch, remote := ".", "", ""
+	var ignore []string
+	ignoreSet, commit, yes := false, false, false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-b", "--branch":
+			if i+1 < len(args) {
+				i++
+				branch = args[i]
+			}
+		case "--ignore":
+			if i+1 < len(args) {
+				i++
+				ignoreSet = true
+				for _, name := range strings.Split(args[i], ",") {
+					if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+						ignore = append(ignore, name)
+					}
+				}
+			}
+		case "--commit":
+			commit = true
+		case "--remote":
+			if i+1 < len(args) {
+				i++
+				remote = args[i]
+			}
+		case "-y", "--yes":
+			yes = true
+		default:
+			dir = args[i]
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	// ask wraps promptLine: the default when --yes, an error without a tty.
+	ask := func(question, def string) string {
+		if yes {
+			return def
+		}
+		answer, err := promptLine(question, def)
+		if err != nil {
+			fail(fmt.Errorf("%v (use --yes or flags)", err))
+		}
+		return answer
+	}
+
+	dir, _ = filepath.Abs(expandHome(dir))
+	if isGitDir(dir) {
+		fail(fmt.Errorf("%s is already a git repository", displayPath(dir)))
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		fail(err)
+	}
+
+	// 1. default branch
+	if branch == "" {
+		def, _ := gitOutput("", "config", "--get", "init.defaultBranch")
+		if def == "" {
+			def = "main"
+		}
+		branch = ask("Default branch name?", def)
+	}
+	if out, err := gitCmd(dir, "init", "--quiet", "--initial-branch", branch).CombinedOutput(); err != nil {
+		fail(fmt.Errorf("git init: %s", strings.TrimSpace(string(out))))
+	}
+	fmt.Printf("%s %sInitialized%s %s%s%s on %s%s %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset,
+		resolveColor(c.CwdPath), displayPath(dir), Reset, Bold+resolveColor(c.Branch), Icons.GIT, branch, Reset)
+
+	// 2. identity
+	for _, key := range []string{"user.name", "user.email"} {
+		if v, _ := gitOutput(dir, "config", "--get", key); v != "" {
+			fmt.Printf("   %s%-10s%s %s\n", Dim, key, Reset, v)
+			continue
+		}
+		fmt.Printf("%s %s%s is not set; commits need it%s\n", Icons.WARNING, resolveColor(c.AheadBehind), key, Reset)
+		if yes {
+			continue
+		}
+		if v := ask("Set "+key+" for this repository (empty to skip)?", ""); v != "" {
+			if _, err := gitOutput(dir, "config", key, v); err != nil {
+				fail(err)
+			}
+		}
+	}
+
+	// 3. .gitignore
+	if !ignoreSet {
+		preselect := map[string]bool{osIgnoreTemplate(): true}
+		for _, name := range detectIgnoreTemplates(dir) {
+			preselect[name] = true
+		}
+		names := ignoreTemplateNames()
+		if yes {
+			for _, name := range names {
+				if preselect[name] {
+					ignore = append(ignore, name)
+				}
+			}
+		} else {
+			items := make([]pickItem, len(names))
+			for i, name := range names {
+				items[i] = pickItem{Tag: "🙈", Label: name, Checked: preselect[name]}
+			}
+			checked, ok, err := runPicker(".gitignore templates", items, c)
+			if err != nil {
+				fail(err)
+			}
+			for i, v := range checked {
+				if ok && v {
+					ignore = append(ignore, names[i])
+				}
+			}
+		}
+	}
+	if len(ignore) > 0 {
+		var sb strings.Builder
+		for _, name := range ignore {
+			body, found := ignoreTemplates[name]
+			if !found {
+				fail(fmt.Errorf("no bundled .gitignore template %q (have: %s)", name, strings.Join(ignoreTemplateNames(), ", ")))
+			}
+			if sb.Len() > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(body)
+		}
+		path := filepath.Join(dir, ".gitignore")
+		if Exists(path) {
+			fmt.Printf("%s .gitignore already exists; left as is %s(gits ignore add %s)%s\n", Icons.INFO, Dim, strings.Join(ignore, " "), Reset)
+		} else if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+			fail(err)
+		} else {
+			fmt.Printf("%s %sWrote .gitignore%s %s(%s)%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset, Dim, strings.Join(ignore, ", "), Reset)
+		}
+	}
+
+	// 4. first commit
+	if !commit && !yes {
+		ok, err := confirm("Create an initial commit with the current files?")
+		if err != nil {
+			fail(err)
+		}
+		commit = ok
+	}
+	if commit {
+		if out, err := gitCmd(dir, "add", "--all").CombinedOutput(); err != nil {
+			fail(fmt.Errorf("git add: %s", strings.TrimSpace(string(out))))
+		}
+		if out, err := gitCmd(dir, "commit", "--quiet", "--allow-empty", "-m", "Initial commit").CombinedOutput(); err != nil {
+			fmt.Printf("%s %sInitial commit failed: %s%s\n", Icons.WARNING, resolveColor(c.AheadBehind), strings.TrimSpace(string(out)), Reset)
+		} else {
+			short, _ := gitOutput(dir, "rev-parse", "--short", "HEAD")
+			files, _ := gitOutput(dir, "ls-files")
+			count := 0
+			if files != "" {
+				count = len(strings.Split(files, "\n"))
+			}
+			fmt.Printf("%s %sInitial commit%s %s%s%s %s(%d file(s))%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset,
+				Bold+resolveColor(c.AheadBehind), short, Reset, Dim, count, Reset)
+		}
+	}
+
+	// 5. remote
+	if remote == "" && !yes {
+		remote = ask("Remote URL for origin (empty to skip)?", "")
+	}
+	if remote != "" {
+		if _, err := gitOutput(dir, "remote", "add", "origin", remote); err != nil {
+			fail(err)
+		}
+		fmt.Printf("%s %sAdded remote%s origin %s%s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset, resolveColor(c.RemoteURL), remote, Reset)
+		fmt.Printf("   %spublish with `gits push -u`%s\n", Dim, Reset)
+	}
+}
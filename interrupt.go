@@ -0,0 +1,132 @@
+// File: interrupt.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: Ctrl+C and SIGTERM: stop git, restore the terminal, say what was cut short
+// License: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// interrupted is done once gits got SIGINT or SIGTERM; every git started
+// by gitCmd is stopped when it is.
+var interrupted, interrupt = context.WithCancel(context.Background())
+
+// gitStops hears from each git stopped on the way out, so the handler can
+// wait for them and count them.
+var gitStops = make(chan struct{}, 64)
+
+var (
+	interruptMu    sync.Mutex
+	interruptHooks []*func()
+)
+
+// foreground is the plugin gits is waiting on, if any.  The plugin owns
+// the terminal until it exits, so an interrupt is for it to handle.
+var foreground atomic.Pointer[os.Process]
+
+// quietInterrupts are the commands that run until they are interrupted,
+// for which Ctrl+C is just the way out rather than a cut-short result.
+var quietInterrupts = map[string]bool{"-w": true, "--watch": true, "daemon": true, "exporter": true, "ui": true, "popup": true}
+
+// onInterrupt runs undo if gits is interrupted before release is called.
+// Hooks run last registered first, before gits exits.
+func onInterrupt(undo func()) (release func()) {
+	interruptMu.Lock()
+	defer interruptMu.Unlock()
+	h := &undo
+	interruptHooks = append(interruptHooks, h)
+	return func() {
+		interruptMu.Lock()
+		defer interruptMu.Unlock()
+		interruptHooks = slices.DeleteFunc(interruptHooks, func(x *func()) bool { return x == h })
+	}
+}
+
+// rawTerminal puts fd in raw mode until restore is called, or until gits
+// is interrupted; either way leave (the escapes that undo an alternate
+// screen, a hidden cursor, ...) is printed first.
+func rawTerminal(fd int, leave string) (restore func(), err error) {
+	old, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	undo := func() {
+		fmt.Print(leave)
+		term.Restore(fd, old)
+	}
+	release := onInterrupt(undo)
+	return func() {
+		release()
+		undo()
+	}, nil
+}
+
+// handleInterrupts takes over SIGINT and SIGTERM for command (args[0], or
+// "" for the status): the running git processes are stopped, the terminal
+// is put back from raw mode and the alternate screen, and a note says the
+// output is partial.  gits then exits with 128 plus the signal number, as
+// a shell would report it.  `gits serve` shuts down on its own, and while
+// a plugin runs the signals are passed on to it instead.
+func handleInterrupts(command string, c ColorConfig) {
+	if command == "serve" {
+		return
+	}
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-sigs
+		for p := foreground.Load(); p != nil; p = foreground.Load() {
+			// The terminal sent SIGINT to the plugin as well; pass on
+			// what was sent to gits alone.
+			if sig != os.Interrupt {
+				p.Signal(sig)
+			}
+			sig = <-sigs
+		}
+		interrupt()
+
+		interruptMu.Lock()
+		hooks := slices.Clone(interruptHooks)
+		interruptMu.Unlock()
+		for i := len(hooks) - 1; i >= 0; i-- {
+			(*hooks[i])()
+		}
+
+		// exec stops each git from a goroutine of its own; wait until no
+		// more report in.
+		stopped := 0
+		for quiet := false; !quiet; {
+			select {
+			case <-gitStops:
+				stopped++
+			case <-time.After(100 * time.Millisecond):
+				quiet = true
+			}
+		}
+		if !quietInterrupts[command] {
+			what := "the output above may be incomplete"
+			if stopped > 0 {
+				what = fmt.Sprintf("stopped %d git process(es); the output above may be incomplete", stopped)
+			}
+			fmt.Fprintf(os.Stderr, "\n%s %sInterrupted:%s %s%s%s\n", Icons.WARNING, Bold+resolveColor(c.AheadBehind), Reset, Dim, what, Reset)
+		}
+		code := 130
+		if s, ok := sig.(syscall.Signal); ok {
+			code = 128 + int(s)
+		}
+		exit(code)
+	}()
+}
@@ -0,0 +1,331 @@
+// File: keymap.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: remappable keys for the interactive modes (`gits keys`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// keyAction is one remappable action.  Keys is the default binding: key
+// names separated by spaces, as in the [keys.<context>] config tables.
+type keyAction struct {
+	Context string
+	Name    string
+	Keys    string
+	Help    string
+}
+
+// keyContexts lists the interactive modes in display order.
+var keyContexts = []struct{ name, help string }{
+	{"ui", "gits ui, file list"},
+	{"pane", "gits ui, diff pane focused (d)"},
+	{"filter", "gits ui, typing a filter (/)"},
+	{"hunks", "line picker (gits add -p, h in gits ui)"},
+	{"commit", "commit form (c in gits ui)"},
+	{"picker", "checkbox lists and menus (gits add, restore, ...)"},
+	{"fuzzy", "fuzzy finders (gits switch, ...)"},
+	{"branches", "gits ui, branches panel (b)"},
+	{"stashes", "gits ui, stash panel (s)"},
+	{"conflicts", "gits ui, conflict resolution (m on an unmerged file)"},
+	{"prompt", "gits ui, text prompts (new branch name, ...)"},
+}
+
+// keyActions are every remappable action with its default keys.
+var keyActions = []keyAction{
+	{"ui", "up", "up k", "previous file"},
+	{"ui", "down", "down j", "next file"},
+	{"ui", "pgup", "pgup", "page up"},
+	{"ui", "pgdn", "pgdn", "page down"},
+	{"ui", "top", "home g", "first file"},
+	{"ui", "bottom", "end G", "last file"},
+	{"ui", "section", "tab", "next section"},
+	{"ui", "toggle", "space", "stage or unstage the file"},
+	{"ui", "stage-all", "a", "stage every file shown"},
+	{"ui", "unstage-all", "u", "unstage every file shown"},
+	{"ui", "lines", "h", "stage or unstage lines"},
+	{"ui", "commit", "c", "open the commit form"},
+	{"ui", "branches", "b", "open the branches panel"},
+	{"ui", "stashes", "s", "open the stash panel"},
+	{"ui", "resolve", "m", "resolve the conflicts of the file"},
+	{"ui", "filter", "/", "filter the files"},
+	{"ui", "diff", "d enter", "let the diff fill the screen"},
+	{"ui", "scroll-down", "J", "scroll the pane down"},
+	{"ui", "scroll-up", "K", "scroll the pane up"},
+	{"ui", "refresh", "r", "read the status again"},
+	{"ui", "quit", "q esc", "quit (ctrl-c always quits)"},
+
+	{"pane", "up", "up k K", "scroll up"},
+	{"pane", "down", "down j J", "scroll down"},
+	{"pane", "pgup", "pgup", "page up"},
+	{"pane", "pgdn", "pgdn space", "page down"},
+	{"pane", "top", "home g", "top"},
+	{"pane", "bottom", "end G", "bottom"},
+	{"pane", "back", "d q esc enter", "back to the list"},
+
+	{"filter", "accept", "enter", "keep the filter"},
+	{"filter", "cancel", "esc", "drop the filter"},
+	{"filter", "erase", "backspace", "delete a character"},
+	{"filter", "clear", "ctrl-u", "clear the query"},
+
+	{"hunks", "up", "up k", "previous line"},
+	{"hunks", "down", "down j", "next line"},
+	{"hunks", "pgup", "pgup", "ten lines up"},
+	{"hunks", "pgdn", "pgdn", "ten lines down"},
+	{"hunks", "next-hunk", "] n", "next hunk"},
+	{"hunks", "prev-hunk", "[ p", "previous hunk"},
+	{"hunks", "line", "space x", "pick the line"},
+	{"hunks", "hunk", "h", "pick the hunk"},
+	{"hunks", "all", "a", "pick everything"},
+	{"hunks", "apply", "enter", "stage (or unstage) the picked lines"},
+	{"hunks", "skip", "esc", "leave this file"},
+	{"hunks", "quit", "q ctrl-c", "stop"},
+
+	{"commit", "next-field", "tab down", "next field"},
+	{"commit", "prev-field", "up", "previous field"},
+	{"commit", "prev-type", "left h", "previous type (type field)"},
+	{"commit", "next-type", "right l space", "next type (type field)"},
+	{"commit", "breaking", "!", "toggle breaking change (type field)"},
+	{"commit", "newline", "enter", "next field, or a new body line"},
+	{"commit", "commit", "ctrl-s", "commit"},
+	{"commit", "cancel", "esc ctrl-c", "close the form"},
+	{"commit", "erase", "backspace", "delete a character"},
+	{"commit", "clear", "ctrl-u", "clear the field"},
+
+	{"picker", "up", "up k", "previous item"},
+	{"picker", "down", "down j", "next item"},
+	{"picker", "pgup", "pgup", "ten items up"},
+	{"picker", "pgdn", "pgdn", "ten items down"},
+	{"picker", "top", "home g", "first item"},
+	{"picker", "bottom", "end G", "last item"},
+	{"picker", "toggle", "space x", "check or uncheck"},
+	{"picker", "all", "a", "check or uncheck everything"},
+	{"picker", "accept", "enter", "confirm"},
+	{"picker", "cancel", "q esc ctrl-c", "cancel"},
+
+	{"fuzzy", "up", "up ctrl-p", "previous match"},
+	{"fuzzy", "down", "down ctrl-n", "next match"},
+	{"fuzzy", "pgup", "pgup", "ten matches up"},
+	{"fuzzy", "pgdn", "pgdn", "ten matches down"},
+	{"fuzzy", "erase", "backspace", "delete a character"},
+	{"fuzzy", "clear", "ctrl-u", "clear the query"},
+	{"fuzzy", "accept", "enter", "select"},
+	{"fuzzy", "cancel", "esc ctrl-c", "cancel"},
+
+	{"branches", "up", "up k", "previous branch"},
+	{"branches", "down", "down j", "next branch"},
+	{"branches", "pgup", "pgup", "ten branches up"},
+	{"branches", "pgdn", "pgdn", "ten branches down"},
+	{"branches", "top", "home g", "first branch"},
+	{"branches", "bottom", "end G", "last branch"},
+	{"branches", "checkout", "enter space", "check out the branch"},
+	{"branches", "create", "n", "create a branch at HEAD and switch to it"},
+	{"branches", "delete", "d", "delete the branch if it is merged"},
+	{"branches", "force-delete", "D", "delete the branch anyway"},
+	{"branches", "refresh", "r", "read the branches again"},
+	{"branches", "back", "b q esc", "back to the status"},
+
+	{"stashes", "up", "up k", "previous stash"},
+	{"stashes", "down", "down j", "next stash"},
+	{"stashes", "top", "home g", "first stash"},
+	{"stashes", "bottom", "end G", "last stash"},
+	{"stashes", "scroll-down", "J", "scroll the preview down"},
+	{"stashes", "scroll-up", "K", "scroll the preview up"},
+	{"stashes", "pgdn", "pgdn space", "page the preview down"},
+	{"stashes", "pgup", "pgup", "page the preview up"},
+	{"stashes", "apply", "a enter", "apply the stash, keeping it"},
+	{"stashes", "pop", "p", "apply the stash and drop it"},
+	{"stashes", "drop", "d", "drop the stash"},
+	{"stashes", "new", "n", "stash the local changes"},
+	{"stashes", "refresh", "r", "read the stashes again"},
+	{"stashes", "back", "s q esc", "back to the status"},
+
+	{"conflicts", "next-hunk", "n ] down j", "next conflict"},
+	{"conflicts", "prev-hunk", "p [ up k", "previous conflict"},
+	{"conflicts", "ours", "o", "take our side"},
+	{"conflicts", "theirs", "t", "take their side"},
+	{"conflicts", "both", "b", "take ours, then theirs"},
+	{"conflicts", "undo", "u", "leave the conflict unresolved"},
+	{"conflicts", "scroll-down", "J pgdn", "scroll down"},
+	{"conflicts", "scroll-up", "K pgup", "scroll up"},
+	{"conflicts", "write", "enter w", "write the file, staging it once resolved"},
+	{"conflicts", "back", "q esc", "back without writing"},
+
+	{"prompt", "accept", "enter", "confirm"},
+	{"prompt", "cancel", "esc", "cancel"},
+	{"prompt", "erase", "backspace", "delete a character"},
+	{"prompt", "clear", "ctrl-u", "clear the text"},
+}
+
+// keyNames are the named keys accepted in the config besides single
+// characters and ctrl-a … ctrl-z.
+var keyNames = []string{keyUp, keyDown, keyLeft, keyRight, keyPgUp, keyPgDn, keyHome, keyEnd,
+	keyEnter, keyEsc, "tab", "space", "backspace"}
+
+// keyName turns what readKey returned into the name used in bindings.
+func keyName(key string) string {
+	switch key {
+	case " ":
+		return "space"
+	case "\t":
+		return "tab"
+	case "\x7f", "\b":
+		return "backspace"
+	}
+	if len(key) == 1 && key[0] >= 1 && key[0] <= 26 {
+		return "ctrl-" + string(rune('a'+key[0]-1))
+	}
+	return key
+}
+
+// validKeyName reports whether name can be bound.
+func validKeyName(name string) bool {
+	if containsString(keyNames, name) || len([]rune(name)) == 1 {
+		return true
+	}
+	rest, ok := strings.CutPrefix(name, "ctrl-")
+	return ok && len(rest) == 1 && rest[0] >= 'a' && rest[0] <= 'z'
+}
+
+// keyBinding is the effective binding of an action.
+type keyBinding struct {
+	keyAction
+	Keys    []string
+	Changed bool // set from the config
+}
+
+// keyConflict is a key bound to several actions of one context.
+type keyConflict struct {
+	Context string
+	Key     string
+	Actions []string // the first one wins
+}
+
+// keyMaps resolves bindings from [keys.<context>] config tables.
+type keyMaps struct {
+	bindings  []keyBinding
+	lookup    map[string]map[string]string // context → key → action
+	conflicts []keyConflict
+	problems  []string
+}
+
+// newKeyMaps applies the config over the defaults.  Keys set in the config
+// win over defaults they collide with.
+func newKeyMaps(config map[string]map[string]string) *keyMaps {
+	km := &keyMaps{lookup: map[string]map[string]string{}}
+	known := map[string]bool{}
+	for _, a := range keyActions {
+		known[a.Context+"."+a.Name] = true
+		b := keyBinding{keyAction: a, Keys: strings.Fields(a.Keys)}
+		if spec, ok := config[a.Context][a.Name]; ok {
+			b.Changed = true
+			b.Keys = nil
+			for _, k := range strings.Fields(spec) {
+				if !validKeyName(k) {
+					km.problems = append(km.problems, fmt.Sprintf("keys.%s.%s: unknown key %q", a.Context, a.Name, k))
+					continue
+				}
+				b.Keys = append(b.Keys, k)
+			}
+		}
+		km.bindings = append(km.bindings, b)
+	}
+	var contexts []string
+	for ctx := range config {
+		contexts = append(contexts, ctx)
+	}
+	sort.Strings(contexts)
+	for _, ctx := range contexts {
+		for name := range config[ctx] {
+			if !known[ctx+"."+name] {
+				km.problems = append(km.problems, fmt.Sprintf("keys.%s.%s: no such action", ctx, name))
+			}
+		}
+	}
+	sort.Strings(km.problems)
+
+	// Configured bindings first, so they win a collision.
+	owners := map[string][]string{}
+	for _, pass := range []bool{true, false} {
+		for _, b := range km.bindings {
+			if b.Changed != pass {
+				continue
+			}
+			if km.lookup[b.Context] == nil {
+				km.lookup[b.Context] = map[string]string{}
+			}
+			for _, k := range b.Keys {
+				id := b.Context + "\x00" + k
+				owners[id] = append(owners[id], b.Name)
+				if _, taken := km.lookup[b.Context][k]; !taken {
+					km.lookup[b.Context][k] = b.Name
+				}
+			}
+		}
+	}
+	for _, ctx := range keyContexts {
+		var clashes []string
+		for id, names := range owners {
+			if c, k, _ := strings.Cut(id, "\x00"); c == ctx.name && len(names) > 1 {
+				clashes = append(clashes, k)
+			}
+		}
+		sort.Strings(clashes)
+		for _, k := range clashes {
+			km.conflicts = append(km.conflicts, keyConflict{ctx.name, k, owners[ctx.name+"\x00"+k]})
+		}
+	}
+	return km
+}
+
+// keys is the active key map; main replaces it with the configured one.
+var keys = newKeyMaps(nil)
+
+// boundAction returns the action key triggers in context, or "".
+func boundAction(context, key string) string {
+	return keys.lookup[context][keyName(key)]
+}
+
+// runKeys implements `gits keys`: the effective bindings per mode, with
+// configured ones highlighted, then conflicts and config mistakes.
+func runKeys(status *Status, args []string) {
+	c := status.cfg.Colors
+	for _, ctx := range keyContexts {
+		if len(args) > 0 && !containsString(args, ctx.name) {
+			continue
+		}
+		fmt.Printf("%s%s%s %s%s%s\n", Bold+resolveColor(c.Header), ctx.name, Reset, Dim, ctx.help, Reset)
+		for _, b := range keys.bindings {
+			if b.Context != ctx.name {
+				continue
+			}
+			style, mark := "", " "
+			if b.Changed {
+				style, mark = Bold+resolveColor(c.Staged), "*"
+			}
+			bound := strings.Join(b.Keys, " ")
+			if bound == "" {
+				bound = Dim + "(unbound)" + Reset
+			}
+			fmt.Printf("  %s%s%-12s%s %s%-18s%s %s%s%s\n", style, mark, b.Name, Reset, Bold, bound, Reset, Dim, b.Help, Reset)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%s* set in [keys] of the config; ctrl-c always quits gits ui%s\n", Dim, Reset)
+
+	for _, cf := range keys.conflicts {
+		fmt.Printf("%s %s%s: %q is bound to %s%s %s(%s wins)%s\n", Icons.WARNING, resolveColor(c.AheadBehind), cf.Context, cf.Key,
+			strings.Join(cf.Actions, " and "), Reset, Dim, cf.Actions[0], Reset)
+	}
+	for _, p := range keys.problems {
+		fmt.Printf("%s %s%s%s\n", Icons.WARNING, resolveColor(c.AheadBehind), p, Reset)
+	}
+	if len(keys.conflicts)+len(keys.problems) > 0 {
+		exit(1)
+	}
+}
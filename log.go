@@ -0,0 +1,157 @@
+// File: log.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: compact colored history (`gits log`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logFieldSep separates the fields of each commit line in the git log
+// format; graph-only lines do not contain it.
+const logFieldSep = "\x1f"
+
+// lanePalette returns the colors cycled through for graph lanes and authors.
+func lanePalette(c ColorConfig) []string {
+	return []string{
+		resolveColor(c.Branch), resolveColor(c.Added), resolveColor(c.Modified),
+		resolveColor(c.Untracked), resolveColor(c.AheadBehind), resolveColor(c.Renamed),
+		resolveColor(c.RemoteURL),
+	}
+}
+
+// colorGraph colors the lane characters of a `git log --graph` prefix; each
+// lane (two columns wide) keeps its own color.
+func colorGraph(graph string, palette []string) string {
+	var sb strings.Builder
+	col := 0
+	for _, r := range graph {
+		switch r {
+		case ' ':
+			sb.WriteRune(r)
+		case '*':
+			sb.WriteString(Bold + palette[(col/2)%len(palette)] + "●" + Reset)
+		default:
+			sb.WriteString(palette[(col/2)%len(palette)] + string(r) + Reset)
+		}
+		col++
+	}
+	return sb.String()
+}
+
+// authorColor picks a stable color for an author name.
+func authorColor(name string, palette []string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return palette[h.Sum32()%uint32(len(palette))]
+}
+
+// colorDecorations renders `%D` ref names: HEAD, local branches, remote
+// branches and tags each in their own color.
+func colorDecorations(refs string, c ColorConfig) string {
+	if refs == "" {
+		return ""
+	}
+	var parts []string
+	for _, ref := range strings.Split(refs, ", ") {
+		switch {
+		case strings.HasPrefix(ref, "HEAD -> "):
+			parts = append(parts, Bold+resolveColor(c.CwdLabel)+"HEAD → "+Reset+
+				Bold+resolveColor(c.Branch)+strings.TrimPrefix(ref, "HEAD -> ")+Reset)
+		case ref == "HEAD":
+			parts = append(parts, Bold+resolveColor(c.CwdLabel)+ref+Reset)
+		case strings.HasPrefix(ref, "tag: "):
+			parts = append(parts, Bold+resolveColor(c.AheadBehind)+"🏷 "+strings.TrimPrefix(ref, "tag: ")+Reset)
+		case strings.Contains(ref, "/"):
+			parts = append(parts, resolveColor(c.RemoteURL)+ref+Reset)
+		default:
+			parts = append(parts, Bold+resolveColor(c.Branch)+ref+Reset)
+		}
+	}
+	return Dim + "(" + Reset + strings.Join(parts, Dim+", "+Reset) + Dim + ")" + Reset + " "
+}
+
+// relativeAge formats t as "3d ago", or "just now" for the last minute.
+func relativeAge(t time.Time) string {
+	if age := shortAge(t); age != "now" {
+		return age + " ago"
+	}
+	return "just now"
+}
+
+// runLog implements `gits log [-n N] [--all] [revisions] [-- paths]`.
+func runLog(status *Status, args []string) {
+	c := status.cfg.Colors
+	limit := 20
+	var passthrough []string
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "-n" || a == "--max-count":
+			if i+1 < len(args) {
+				i++
+				if n, err := strconv.Atoi(args[i]); err == nil {
+					limit = n
+				}
+			}
+		case strings.HasPrefix(a, "--max-count="):
+			if n, err := strconv.Atoi(strings.TrimPrefix(a, "--max-count=")); err == nil {
+				limit = n
+			}
+		case len(a) > 1 && a[0] == '-' && a[1] >= '0' && a[1] <= '9':
+			if n, err := strconv.Atoi(a[1:]); err == nil {
+				limit = n
+			}
+		default:
+			passthrough = append(passthrough, a)
+		}
+	}
+
+	format := strings.Join([]string{"", "%h", "%H", "%ct", "%an", "%D", "%s"}, logFieldSep)
+	gitArgs := []string{"log", "--graph", "--no-color", "--format=" + format}
+	if limit > 0 {
+		gitArgs = append(gitArgs, "-n", strconv.Itoa(limit))
+	}
+	gitArgs = append(gitArgs, passthrough...)
+	out, err := gitRaw(".", gitArgs...)
+	if err != nil {
+		exitWithError(err, c)
+	}
+
+	palette := lanePalette(c)
+	web := ""
+	if stdoutIsTerminal && !links.Disable {
+		web = repoWebURL(".")
+	}
+	var sb strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		graph, rest, isCommit := strings.Cut(line, logFieldSep)
+		sb.WriteString(colorGraph(graph, palette))
+		if !isCommit {
+			sb.WriteString("\n")
+			continue
+		}
+		f := strings.SplitN(rest, logFieldSep, 6)
+		if len(f) < 6 {
+			sb.WriteString(rest + "\n")
+			continue
+		}
+		age := ""
+		if ts, err := strconv.ParseInt(f[2], 10, 64); err == nil {
+			age = relativeAge(time.Unix(ts, 0))
+		}
+		fmt.Fprintf(&sb, "%s%s%s %s%-8s%s %s%s%s %s%s\n",
+			Bold+resolveColor(c.AheadBehind), hyperlink(commitWebURL(web, f[1]), f[0]), Reset,
+			Dim, age, Reset,
+			authorColor(f[3], palette), f[3], Reset,
+			colorDecorations(f[4], c), f[5])
+	}
+	fmt.Print(sb.String())
+}
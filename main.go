@@ -7,17 +7,19 @@
 package main
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pelletier/go-toml/v2"
 	"github.com/cumulus13/go-config-get/configget"
@@ -48,17 +50,26 @@ const (
 // hexToAnsi converts a CSS hex color (#RRGGBB or #RGB) to a 24-bit ANSI
 // foreground escape sequence.
 func hexToAnsi(hex string) string {
+	r, g, b, ok := hexRGB(hex)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
+}
+
+// hexRGB splits a "#rrggbb" or "#rgb" color into its components.
+func hexRGB(hex string) (r, g, b int64, ok bool) {
 	hex = strings.TrimPrefix(hex, "#")
 	if len(hex) == 3 {
 		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
 	}
 	if len(hex) != 6 {
-		return ""
+		return 0, 0, 0, false
 	}
-	r, _ := strconv.ParseInt(hex[0:2], 16, 32)
-	g, _ := strconv.ParseInt(hex[2:4], 16, 32)
-	b, _ := strconv.ParseInt(hex[4:6], 16, 32)
-	return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
+	r, err1 := strconv.ParseInt(hex[0:2], 16, 32)
+	g, err2 := strconv.ParseInt(hex[2:4], 16, 32)
+	b, err3 := strconv.ParseInt(hex[4:6], 16, 32)
+	return r, g, b, err1 == nil && err2 == nil && err3 == nil
 }
 
 // ---------------------------------------------------------------------------
@@ -90,9 +101,42 @@ type ColorConfig struct {
 	TreeFile  string `toml:"tree_file"`
 }
 
+// WatchConfig controls `gits --watch`.  Durations use Go syntax ("300ms", "2s").
+type WatchConfig struct {
+	Debounce     string `toml:"debounce"`
+	PollInterval string `toml:"poll_interval"`
+	Poll         bool   `toml:"poll"` // always poll instead of using fs notifications
+}
+
 type AppConfig struct {
-	TreeMode bool        `toml:"tree_mode"`
-	Colors   ColorConfig `toml:"colors"`
+	TreeMode bool                   `toml:"tree_mode"`
+	// ShowTrackedIgnored adds a status section for tracked files that
+	// match .gitignore rules.
+	ShowTrackedIgnored bool `toml:"show_tracked_ignored"`
+	Colors   ColorConfig            `toml:"colors"`
+	Watch    WatchConfig            `toml:"watch"`
+	Exporter ExporterConfig         `toml:"exporter"`
+	Notify   NotifyConfig           `toml:"notify"`
+	Daemon   DaemonConfig           `toml:"daemon"`
+	Sync     SyncConfig             `toml:"sync"`
+	Ignore   IgnoreConfig           `toml:"ignore"`
+	Groups   map[string]GroupConfig `toml:"group"`
+	Cache    StatusCacheConfig      `toml:"cache"`
+	Hooks    HooksConfig            `toml:"hooks"`
+	Links    LinksConfig            `toml:"links"`
+	Desktop  DesktopConfig          `toml:"desktop"`
+	// GitMessages shows or hides what git writes to stderr on success.
+	GitMessages GitMessagesConfig `toml:"git_messages"`
+	// ResolveSymlinks shows physical paths instead of the logical ones a
+	// symlinked working directory was reached through.
+	ResolveSymlinks bool `toml:"resolve_symlinks"`
+	// ExpandUntracked lists the files in untracked directories instead of
+	// their count and size.
+	ExpandUntracked bool `toml:"expand_untracked"`
+	// Backend reads the status: "cli" (git status) or "native" (go-git).
+	Backend  string                 `toml:"backend"`
+	// Keys remaps the interactive modes: [keys.ui] quit = "q", ...
+	Keys     map[string]map[string]string `toml:"keys"`
 }
 
 // DefaultConfig returns sensible defaults.
@@ -122,6 +166,35 @@ func DefaultConfig() AppConfig {
 			TreeDir:	 "#0055FF",
 			TreeFile:    "#00FFFF",
 		},
+		Watch: WatchConfig{
+			Debounce:     "300ms",
+			PollInterval: "2s",
+		},
+		Exporter: ExporterConfig{
+			Listen:   ":9321",
+			Interval: "60s",
+		},
+		Notify: NotifyConfig{
+			UnpushedOlderThan: "24h",
+		},
+		Daemon: DaemonConfig{
+			Schedule:    "@every 15m",
+			KeepReports: 96,
+		},
+		Sync: SyncConfig{
+			Strategy: "rebase",
+		},
+		GitMessages: GitMessagesConfig{Warnings: true, Hints: true},
+		Desktop: DesktopConfig{
+			Behind:    true,
+			Diverged:  true,
+			Conflicts: true,
+			Errors:    true,
+		},
+		Cache: StatusCacheConfig{
+			Enabled: true,
+			TTL:     "10m",
+		},
 	}
 }
 
@@ -241,6 +314,9 @@ func IsDir(path string) bool {
 // 	return cfg
 // }
 
+// debugMode enables diagnostic output; set with --debug or GITS_DEBUG=1.
+var debugMode = os.Getenv("GITS_DEBUG") != ""
+
 func LoadConfig() AppConfig {
     cfg := DefaultConfig()
 
@@ -249,7 +325,10 @@ func LoadConfig() AppConfig {
         return cfg
     }
 
-    fmt.Printf("Load Config File: %s\n", path)
+    if debugMode {
+        // stderr, so machine-readable output (--json) stays clean
+        fmt.Fprintf(os.Stderr, "Load Config File: %s\n", path)
+    }
 
     if !IsFile(path) {
         return cfg
@@ -310,20 +389,40 @@ var Icons = struct {
 // ---------------------------------------------------------------------------
 
 type textSegment struct {
-	text  string
-	style string
+	prefix string // written before text inside the same style
+	text   string
+	style  string
 }
 
 type ColoredText struct {
 	segments []textSegment
 }
 
+// coloredTextPool recycles builders along with their segment slices, so a
+// status of 100k lines does not allocate a builder per line.
+var coloredTextPool = sync.Pool{New: func() any {
+	return &ColoredText{segments: make([]textSegment, 0, 8)}
+}}
+
 func NewColoredText() *ColoredText {
-	return &ColoredText{segments: make([]textSegment, 0)}
+	return coloredTextPool.Get().(*ColoredText)
+}
+
+// Release hands ct back to the pool; it must not be used afterwards.
+func (ct *ColoredText) Release() {
+	clear(ct.segments)
+	ct.segments = ct.segments[:0]
+	coloredTextPool.Put(ct)
 }
 
 func (ct *ColoredText) Append(text, style string) {
-	ct.segments = append(ct.segments, textSegment{text, style})
+	ct.segments = append(ct.segments, textSegment{text: text, style: style})
+}
+
+// AppendPrefixed is Append(prefix+text, style) without building the
+// joined string.
+func (ct *ColoredText) AppendPrefixed(prefix, text, style string) {
+	ct.segments = append(ct.segments, textSegment{prefix: prefix, text: text, style: style})
 }
 
 func (ct *ColoredText) String() string {
@@ -332,6 +431,7 @@ func (ct *ColoredText) String() string {
 		if seg.style != "" {
 			sb.WriteString(seg.style)
 		}
+		sb.WriteString(seg.prefix)
 		sb.WriteString(seg.text)
 		if seg.style != "" {
 			sb.WriteString(Reset)
@@ -340,6 +440,22 @@ func (ct *ColoredText) String() string {
 	return sb.String()
 }
 
+// WriteLine writes the text and a newline to w, then releases ct.
+func (ct *ColoredText) WriteLine(w *bufio.Writer) {
+	for _, seg := range ct.segments {
+		if seg.style != "" {
+			w.WriteString(seg.style)
+		}
+		w.WriteString(seg.prefix)
+		w.WriteString(seg.text)
+		if seg.style != "" {
+			w.WriteString(Reset)
+		}
+	}
+	w.WriteByte('\n')
+	ct.Release()
+}
+
 // ---------------------------------------------------------------------------
 // Tree builder for untracked files
 // ---------------------------------------------------------------------------
@@ -348,15 +464,16 @@ type treeNode struct {
 	name     string
 	children map[string]*treeNode
 	isDir    bool
+	note     string // dimmed after the name, e.g. an untracked directory's size
 }
 
 func newTreeNode(name string, isDir bool) *treeNode {
 	return &treeNode{name: name, children: map[string]*treeNode{}, isDir: isDir}
 }
 
-// insertPath adds a slash-separated path into the tree.
-func insertPath(root *treeNode, path string) {
-	path = filepath.ToSlash(strings.TrimSpace(path))
+// insertPath adds a slash-separated path into the tree and returns its node.
+func insertPath(root *treeNode, path string) *treeNode {
+	path = filepath.ToSlash(path)
 	isDir := strings.HasSuffix(path, "/")
 	path = strings.TrimSuffix(path, "/")
 	parts := strings.Split(path, "/")
@@ -374,6 +491,7 @@ func insertPath(root *treeNode, path string) {
 		}
 		cur = child
 	}
+	return cur
 }
 
 // renderTree prints the tree recursively.
@@ -421,32 +539,35 @@ func insertPath(root *treeNode, path string) {
 // }
 
 // renderTree prints the tree recursively with separate colors for files/dirs and emojis
-func renderTree(node *treeNode, prefix string, isLast bool, dirColor, fileColor string, depth int) {
+func renderTree(w *bufio.Writer, node *treeNode, prefix string, isLast bool, dirColor, fileColor string, depth int) {
 	if depth > 0 {
 		connector := "├── "
 		if isLast {
 			connector = "└── "
 		}
 		
-		label := node.name
+		label := visiblePath(node.name)
 		emoji := ""
 		color := fileColor
 		
 		if node.isDir {
-			emoji = getDirEmoji() + " "
+			emoji = getDirEmoji()
 			color = dirColor
 			if !strings.HasSuffix(label, "/") {
 				label += "/"
 			}
 		} else {
-			emoji = getFileEmoji(node.name) + " "
+			emoji = getFileEmoji(node.name)
 		}
 		
 		ct := NewColoredText()
-		ct.Append(prefix+connector, Dim)
-		ct.Append(emoji, "") // emoji without color styling
-		ct.Append(label, Bold+color)
-		fmt.Println(ct.String())
+		ct.AppendPrefixed(prefix, connector, Dim)
+		ct.AppendPrefixed(padCells(emoji, 2, false), " ", "") // emoji without color styling
+		ct.Append(label, color)
+		if node.note != "" {
+			ct.Append(" ("+node.note+")", Dim)
+		}
+		ct.WriteLine(w)
 	}
 
 	// Sort children: directories first, then files
@@ -472,7 +593,7 @@ func renderTree(node *treeNode, prefix string, isLast bool, dirColor, fileColor
 	}
 
 	for i, k := range keys {
-		renderTree(node.children[k], childPrefix, i == len(keys)-1, dirColor, fileColor, depth+1)
+		renderTree(w, node.children[k], childPrefix, i == len(keys)-1, dirColor, fileColor, depth+1)
 	}
 }
 
@@ -480,16 +601,44 @@ func renderTree(node *treeNode, prefix string, isLast bool, dirColor, fileColor
 // Status
 // ---------------------------------------------------------------------------
 
+// Status runs the commands: it reads through a Collector and draws with a
+// Renderer made for each status it shows.
 type Status struct {
-	cfg AppConfig
+	cfg    AppConfig
+	filter string // fuzzy query narrowing the listed paths (--filter)
+	format string // the Renderer drawing the status (--format); "" is ansi
+	// exitCode is the exit status for the last status that failed.
+	exitCode int
 }
 
 func NewStatus(cfg AppConfig) *Status {
 	return &Status{cfg: cfg}
 }
 
-func (s *Status) fileStyles() map[string]string {
-	c := s.cfg.Colors
+// lineStyles are the styles of status lines, resolved once rather than for
+// every line.
+type lineStyles struct {
+	file                              map[string]string
+	header, arrow, renamed            string
+	untracked, staged, notStaged      string
+	treeDir, treeFile                 string
+}
+
+func newLineStyles(c ColorConfig) *lineStyles {
+	return &lineStyles{
+		file:      fileStyles(c),
+		header:    Bold + resolveColor(c.Header),
+		arrow:     Bold + resolveColor(c.Arrow),
+		renamed:   Bold + resolveColor(c.Renamed),
+		untracked: Bold + resolveColor(c.Untracked),
+		staged:    Bold + resolveColor(c.Staged),
+		notStaged: Bold + resolveColor(c.NotStaged),
+		treeDir:   Bold + resolveColor(c.TreeDir),
+		treeFile:  Bold + resolveColor(c.TreeFile),
+	}
+}
+
+func fileStyles(c ColorConfig) map[string]string {
 	return map[string]string{
 		"modified": Bold + resolveColor(c.Modified),
 		"deleted":  Bold + resolveColor(c.Deleted),
@@ -499,322 +648,300 @@ func (s *Status) fileStyles() map[string]string {
 	}
 }
 
-// colorHeader returns (ColoredText, headerKey)
-func (s *Status) colorHeader(line string) (*ColoredText, string) {
-	headerStyle := Bold + resolveColor(s.cfg.Colors.Header)
-
-	patterns := []struct {
-		regex string
-		key   string
-	}{
-		{`^\s*Changes to be committed:`, "staged"},
-		{`^\s*Changes not staged for commit:`, "not_staged"},
-		{`^\s*Untracked files:`, "untracked"},
-		{`^\s*no changes added to commit`, ""},
-		{`^\s*.+:$`, ""},
-	}
+// headerPatterns recognise section headers of the long status, with the
+// context they start.
+var headerPatterns = []struct {
+	re  *regexp.Regexp
+	key string
+}{
+	{regexp.MustCompile(`^\s*Changes to be committed:`), "staged"},
+	{regexp.MustCompile(`^\s*Changes not staged for commit:`), "not_staged"},
+	{regexp.MustCompile(`^\s*Untracked files:`), "untracked"},
+	{regexp.MustCompile(`^\s*no changes added to commit`), ""},
+	{regexp.MustCompile(`^\s*.+:$`), ""},
+}
 
-	for _, p := range patterns {
-		if matched, _ := regexp.MatchString(p.regex, line); matched {
-			ct := NewColoredText()
-			ct.Append("    "+line, headerStyle)
-			return ct, p.key
-		}
+var (
+	branchLineRe = regexp.MustCompile(`^On branch (.+)$`)
+	hintLineRe   = regexp.MustCompile(`^\s*\(use "git `)
+	fileLineRe   = regexp.MustCompile(`^(\s*)(modified|deleted|new file|renamed|added):( +)(.+)$`)
+	indentLineRe = regexp.MustCompile(`^(\s+)(.+)$`)
+)
+
+// statusLabels are the labels fileLineRe captures, as they are printed.
+var statusLabels = map[string]string{
+	"modified": "modified: ",
+	"deleted":  "deleted: ",
+	"new file": "new file: ",
+	"renamed":  "renamed: ",
+	"added":    "added: ",
+}
+
+// fileLinePath splits a file line of the long status into its label and
+// its path as git printed it, still quoted.
+func fileLinePath(line string) (indent, status, path string, ok bool) {
+	matches := fileLineRe.FindStringSubmatch(line)
+	if matches == nil {
+		return "", "", "", false
 	}
-	return nil, ""
+	indent, status, pad, path := matches[1], matches[2], matches[3], matches[4]
+	// git pads the label to 12 columns; spaces past that start the path.
+	if extra := len(pad) - max(1, 11-len(status)); extra > 0 {
+		path = pad[:extra] + path
+	}
+	return indent, status, path, true
 }
 
-// colorFileLine styles file status lines
-func (s *Status) colorFileLine(line, context string) *ColoredText {
+// fileLine styles a file or entry line of a status section, linking paths
+// through links.
+func (r *ansiRenderer) fileLine(l StatusLine, links *repoLinks) *ColoredText {
 	ct := NewColoredText()
-	c := s.cfg.Colors
-	styles := s.fileStyles()
-
-	re := regexp.MustCompile(`^(\s*)(modified|deleted|new file|renamed|added):\s+(.+)$`)
-	if matches := re.FindStringSubmatch(line); matches != nil {
-		indent, status, rest := matches[1], matches[2], matches[3]
-		ct.Append(indent, "")
-		ct.Append("      "+status+": ", Bold+resolveColor(c.Header))
-
-		if strings.Contains(rest, "->") {
-			parts := strings.SplitN(rest, "->", 2)
-			left := strings.TrimSpace(parts[0])
-			right := strings.TrimSpace(parts[1])
-			ct.Append(left, styles[status])
-			ct.Append(" -> ", Bold+resolveColor(c.Arrow))
-			ct.Append(right, Bold+resolveColor(c.Renamed))
+	st := r.styles
+
+	if l.Kind == lineFile {
+		ct.Append(l.Text[:len(l.Text)-len(strings.TrimLeft(l.Text, "\t\n\f\r "))], "")
+		ct.AppendPrefixed("      ", statusLabels[l.Label], st.header)
+
+		if l.From != "" {
+			ct.Append(hyperlink(links.file(l.From), visiblePath(l.From)), st.file[l.Label])
+			ct.Append(" -> ", st.arrow)
+			ct.Append(visiblePath(l.Path), st.renamed)
+		} else if l.Label == "modified" || l.Label == "deleted" {
+			// New files are not on the remote yet.
+			ct.Append(hyperlink(links.file(l.Path), visiblePath(l.Path)), st.file[l.Label])
 		} else {
-			ct.Append(rest, styles[status])
+			ct.Append(visiblePath(l.Path), st.file[l.Label])
 		}
 		return ct
 	}
 
-	re2 := regexp.MustCompile(`^(\s+)(.+)$`)
-	if matches := re2.FindStringSubmatch(line); matches != nil {
+	if matches := indentLineRe.FindStringSubmatch(l.Text); matches != nil {
 		indent, payload := matches[1], matches[2]
 		ct.Append(indent, "")
-		switch context {
-		case "untracked":
-			ct.Append("      "+payload, Bold+resolveColor(c.Untracked))
+		switch l.Section {
 		case "staged":
-			ct.Append("      "+payload, Bold+resolveColor(c.Staged))
+			ct.AppendPrefixed("      ", payload, st.staged)
 		case "not_staged":
-			ct.Append("      "+payload, Bold+resolveColor(c.NotStaged))
+			ct.AppendPrefixed("      ", payload, st.notStaged)
 		default:
-			ct.Append("      "+payload, "")
+			ct.AppendPrefixed("      ", payload, "")
 		}
 		return ct
 	}
 
-	ct.Append(line, "")
+	ct.Append(l.Text, "")
 	return ct
 }
 
-// ColorizeGitStatus runs git status and prints colorized output.
+// ColorizeGitStatus reads the status of cwd and draws it in the --format
+// chosen, colorized by default.
 func (s *Status) ColorizeGitStatus(cwd, remoteName string) bool {
 	c := s.cfg.Colors
+	r, err := newRenderer(s.format, os.Stdout, s.cfg)
+	if err != nil {
+		exitWithError(err, c)
+	}
 
-	if cwd != "" {
-		if abs, err := filepath.Abs(cwd); err == nil {
-			cwd = abs
+	// Snapshot .gitignore under the remote's profile and put it back
+	// afterward should anything swap it while status runs.
+	profile, profileErr := "", ""
+	if remoteName != "" {
+		if m, err := NewGitIgnoreManager(cwd); err == nil && m.CheckGitignore(remoteName) == nil {
+			defer m.RestoreGitignore(remoteName)
+			if p, ok := s.cfg.Ignore.Profiles[remoteName]; ok {
+				if swapped, err := m.ApplyProfile(remoteName, p); err != nil {
+					profileErr = err.Error()
+				} else if swapped {
+					profile = remoteName
+				}
+			}
 		}
 	}
 
-	fmt.Printf("%s %schdir:%s %s%s%s\n",
-		Icons.FOLDER,
-		Bold+resolveColor(c.CwdLabel), Reset,
-		Bold+resolveColor(c.CwdPath), cwd, Reset)
+	rep := s.collector().Report(cwd)
+	rep.Profile, rep.ProfileErr = profile, profileErr
+	return s.renderReport(r, rep)
+}
 
-	cmd := exec.Command("git", "-c", "color.status=never", "status")
-	if cwd != "" {
-		cmd.Dir = cwd
-	}
+// collector is the Collector for the status in the chosen format: only
+// the colored one carries hyperlinks.
+func (s *Status) collector() Collector {
+	return Collector{Links: s.format == "" || s.format == "ansi", TrackedIgnored: s.cfg.ShowTrackedIgnored}
+}
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		fmt.Printf("%s %s%s%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), err.Error(), Reset)
+// renderReport narrows rep by the filter and draws it with r.  It reports
+// whether the status could be shown, leaving the exit code in s.exitCode
+// when not.
+func (s *Status) renderReport(r Renderer, rep *StatusReport) bool {
+	c := s.cfg.Colors
+	ansi := s.format == "" || s.format == "ansi"
+	parseStart := time.Now()
+	rep.filter(s.filter)
+	timings.phase("parse", parseStart)
+
+	renderStart := time.Now()
+	if err := r.RenderStatus(rep); err != nil {
+		s.exitCode = reportError(err, c)
 		return false
 	}
+	timings.phase("render", renderStart)
+
+	// The other formats carry the failure in what they drew.
+	switch {
+	case rep.NotRepo && ansi:
+		return s.explainNotRepo(rep.Dir)
+	case rep.NotRepo:
+		s.exitCode = failureModes[failureNotRepo].code
+		return false
+	case rep.Err != nil && ansi:
+		s.exitCode = reportError(rep.Err, c)
+		return false
+	case rep.Err != nil:
+		kind, _ := classifyGitError(rep.Err)
+		s.exitCode = failureModes[kind].code
+		return false
+	}
+	return true
+}
 
-	lines := strings.Split(string(output), "\n")
-	context := ""
-	var untrackedFiles []string
-	inUntracked := false
-
-	for _, line := range lines {
-		line = strings.TrimRight(line, "\r")
-
-		// Branch line
-		if matched, _ := regexp.MatchString(`^On branch (.+)$`, line); matched {
-			re := regexp.MustCompile(`^On branch (.+)$`)
-			matches := re.FindStringSubmatch(line)
-			if len(matches) > 1 {
-				fmt.Printf("%s On branch %s%s %s%s\n",
-					Icons.INFO,
-					Bold+resolveColor(c.Branch), Icons.GIT,
-					matches[1], Reset)
-				context = ""
-				inUntracked = false
-				continue
-			}
+// renderLines writes the lines of rep colorized to w, with the last commit
+// under the branch line and the untracked section as a tree or a list.
+func (r *ansiRenderer) renderLines(w *bufio.Writer, rep *StatusReport) {
+	c := r.cfg.Colors
+	var untracked []StatusLine
+
+	printHead := func() {
+		if rep.HasHead {
+			hc := rep.Head
+			fmt.Fprintf(w, "   %s%s%s %s%s · %s · %s%s\n", Bold+resolveColor(c.AheadBehind), hc.Hash, Reset,
+				Dim, hc.Subject, relativeAge(hc.Time), hc.Author, Reset)
 		}
-
-		// "HEAD detached" line
-		if strings.Contains(line, "HEAD detached") {
-			fmt.Printf("%s %s%s%s\n", Icons.WARNING, Bold+resolveColor(c.AheadBehind), line, Reset)
-			continue
+	}
+	flush := func() {
+		if len(untracked) > 0 {
+			r.flushUntrackedPaths(w, untracked)
+			untracked = nil
 		}
+	}
 
-		// No commits yet
-		if strings.Contains(line, "No commits yet") {
-			fmt.Printf("%s %s%s%s\n", Icons.WARNING, Bold+resolveColor(c.AheadBehind), line, Reset)
-			continue
+	// With --fast, say once that untracked files were left out, in place of
+	// git's own remark or else after the final line.
+	noted := !fastStatus
+	noteFast := func() {
+		if !noted {
+			fmt.Fprintf(w, "%s %s[untracked files not scanned]%s\n", Icons.INFO, Dim, Reset)
+			noted = true
 		}
+	}
 
-		// Up to date
-		if strings.Contains(line, "Your branch is up to date") {
-			fmt.Printf("%s %s%s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), line, Reset)
-			context = ""
-			inUntracked = false
+	for _, l := range rep.Lines {
+		if l.Kind == lineUntracked {
+			// Directories are summed up or expanded once the section ends.
+			untracked = append(untracked, l)
 			continue
 		}
-
-		// Ahead/behind/diverged
-		if strings.Contains(line, "ahead") || strings.Contains(line, "behind") || strings.Contains(line, "diverged") {
-			fmt.Printf("%s%s%s\n", resolveColor(c.AheadBehind), line, Reset)
-			context = ""
-			inUntracked = false
+		if l.Section == "untracked" && l.Kind == lineText && strings.TrimSpace(l.Text) == "" {
 			continue
 		}
+		if l.Kind != lineHint {
+			flush()
+		}
 
-		// Header detection
-		if headerText, key := s.colorHeader(line); headerText != nil {
-			// Before switching away from untracked, flush tree
-			if inUntracked && s.cfg.TreeMode {
-				s.flushUntrackedTree(untrackedFiles, cwd)
-				untrackedFiles = nil
+		switch l.Kind {
+		case lineBranch:
+			fmt.Fprintf(w, "%s On branch %s%s %s%s\n",
+				Icons.INFO,
+				Bold+resolveColor(c.Branch), Icons.GIT,
+				hyperlink(rep.Links.branch(), l.Path), Reset)
+			printHead()
+
+		case lineDetached:
+			fmt.Fprintf(w, "%s %s%s%s\n", Icons.WARNING, Bold+resolveColor(c.AheadBehind), l.Text, Reset)
+			printHead()
+
+		case lineUntrackedHidden:
+			// --fast, or status.showUntrackedFiles=no
+			if !fastStatus {
+				fmt.Fprintf(w, "%s %s[untracked files not listed: status.showUntrackedFiles=no]%s\n", Icons.INFO, Dim, Reset)
 			}
-			fmt.Println(headerText.String())
-			context = key
-			inUntracked = key == "untracked"
-			continue
-		}
+			noteFast()
+
+		case lineStash:
+			noteFast()
+			fmt.Fprintf(w, "%s %s%s%s\n", Icons.INFO, Dim, l.Text, Reset)
+
+		case lineNoCommits:
+			fmt.Fprintf(w, "%s %s%s%s\n", Icons.WARNING, Bold+resolveColor(c.AheadBehind), l.Text, Reset)
+
+		case lineUpToDate:
+			fmt.Fprintf(w, "%s %s%s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), l.Text, Reset)
+
+		case lineTracking:
+			fmt.Fprintf(w, "%s%s%s\n", resolveColor(c.AheadBehind), l.Text, Reset)
+
+		case lineHeader:
+			ct := NewColoredText()
+			ct.AppendPrefixed("    ", l.Text, r.styles.header)
+			ct.WriteLine(w)
 
-		// Hints: any line starting with (use "git ..."
-		// The prefix check is intentionally loose — some variants end with
-		// plain text (e.g. "...to include in what will be committed)") rather
-		// than with '")' so we cannot anchor to the end.
-		if matched, _ := regexp.MatchString(`^\s*\(use "git `, line); matched {
-			if inUntracked && s.cfg.TreeMode {
+		case lineHint:
+			if l.Section == "untracked" && r.cfg.TreeMode {
 				// Inside the untracked tree block: suppress — the tree speaks for itself.
 				continue
 			}
 			// All other contexts: print dimmed with consistent 4-space indent.
-			trimmed := strings.TrimSpace(line)
-			fmt.Printf("    %s%s%s\n", Dim, trimmed, Reset)
-			continue
-		}
+			fmt.Fprintf(w, "    %s%s%s\n", Dim, strings.TrimSpace(l.Text), Reset)
 
-		// Terminal status lines — any of the three "nothing to do" variants:
-		//   "nothing to commit, working tree clean"
-		//   "nothing added to commit but untracked files present ..."
-		//   "no changes added to commit (use "git add" and/or "git commit -a")"
-		lower := strings.ToLower(strings.TrimSpace(line))
-		isTerminalStatus := strings.HasPrefix(lower, "nothing to commit") ||
-			strings.HasPrefix(lower, "nothing added to commit") ||
-			strings.HasPrefix(lower, "no changes added to commit") ||
-			strings.Contains(lower, "clean working tree")
-		if isTerminalStatus {
-			if inUntracked && s.cfg.TreeMode {
-				s.flushUntrackedTree(untrackedFiles, cwd)
-				untrackedFiles = nil
-				inUntracked = false
-			}
-			fmt.Printf("%s %s%s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), line, Reset)
-			context = ""
-			continue
-		}
+		case lineDone:
+			fmt.Fprintf(w, "%s %s%s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), l.Text, Reset)
+			noteFast()
 
-		// Collect untracked file paths for tree rendering
-		if context == "untracked" && s.cfg.TreeMode {
-			trimmed := strings.TrimSpace(line)
-			if trimmed != "" {
-				untrackedFiles = append(untrackedFiles, trimmed)
-			}
-			continue
+		default:
+			r.fileLine(l, rep.Links).WriteLine(w)
 		}
-
-		// Normal file line
-		fileText := s.colorFileLine(line, context)
-		fmt.Println(fileText.String())
 	}
-
-	// Flush any remaining untracked files
-	if inUntracked && s.cfg.TreeMode && len(untrackedFiles) > 0 {
-		s.flushUntrackedTree(untrackedFiles, cwd)
-	}
-
-	return true
+	flush()
+	noteFast()
 }
 
-// gitUntrackedUnder runs `git ls-files --others --exclude-standard` inside
-// subDir (relative to repoRoot) and returns paths relative to repoRoot.
-// This respects .gitignore exactly the same way `git status` does.
-func gitUntrackedUnder(repoRoot, subDir string) []string {
-	cmd := exec.Command(
-		"git", "ls-files",
-		"--others",
-		"--exclude-standard",
-		"--",
-		subDir,
-	)
-	cmd.Dir = repoRoot
-	out, err := cmd.Output()
-	if err != nil {
-		return nil
-	}
-	var result []string
-	for _, line := range strings.Split(string(out), "\n") {
-		line = strings.TrimRight(line, "\r")
-		if line != "" {
-			result = append(result, filepath.ToSlash(line))
-		}
+// flushUntrackedPaths renders the untracked section collected from the
+// status, as a tree in tree mode and as a list otherwise.
+func (r *ansiRenderer) flushUntrackedPaths(w *bufio.Writer, lines []StatusLine) {
+	if r.cfg.TreeMode {
+		r.flushUntrackedTree(w, lines)
+		return
 	}
-	return result
+	r.flushUntracked(w, lines)
 }
 
 // flushUntrackedTree renders collected untracked paths as an ASCII tree.
-// Directories reported by git (e.g. "src/") are expanded via
-// `git ls-files --others --exclude-standard` so .gitignore is respected.
-// func (s *Status) flushUntrackedTree(paths []string, cwd string) {
-// 	color := resolveColor(s.cfg.Colors.Untracked)
-// 	root := newTreeNode(".", true)
-
-// 	for _, p := range paths {
-// 		clean := filepath.ToSlash(strings.TrimSpace(p))
-// 		isDir := strings.HasSuffix(clean, "/")
-// 		clean = strings.TrimSuffix(clean, "/")
-
-// 		if isDir {
-// 			// Ask git for the real untracked contents under this directory,
-// 			// honouring .gitignore — never walk the filesystem directly.
-// 			subPaths := gitUntrackedUnder(cwd, clean)
-// 			if len(subPaths) == 0 {
-// 				// git gave us the dir name but returned nothing — insert the
-// 				// dir node alone so it still appears in the tree.
-// 				insertPath(root, clean+"/")
-// 			} else {
-// 				for _, sp := range subPaths {
-// 					insertPath(root, sp)
-// 				}
-// 			}
-// 		} else {
-// 			insertPath(root, clean)
-// 		}
-// 	}
-
-// 	// Label + render
-// 	ct := NewColoredText()
-// 	ct.Append("        . (untracked root)", Dim)
-// 	fmt.Println(ct.String())
-// 	renderTree(root, "        ", true, color, 0)
-// }
-
-// flushUntrackedTree renders collected untracked paths as an ASCII tree.
-func (s *Status) flushUntrackedTree(paths []string, cwd string) {
-	dirColor := resolveColor(s.cfg.Colors.TreeDir)
-	fileColor := resolveColor(s.cfg.Colors.TreeFile)
-	
+// Directories reported by git carry their file count and size and, under
+// --expand-untracked, their files.
+func (r *ansiRenderer) flushUntrackedTree(w *bufio.Writer, lines []StatusLine) {
+	st := r.styles
 	root := newTreeNode(".", true)
 
-	for _, p := range paths {
-		clean := filepath.ToSlash(strings.TrimSpace(p))
-		isDir := strings.HasSuffix(clean, "/")
-		clean = strings.TrimSuffix(clean, "/")
-
-		if isDir {
-			// Ask git for the real untracked contents under this directory,
-			// honouring .gitignore — never walk the filesystem directly.
-			subPaths := gitUntrackedUnder(cwd, clean)
-			if len(subPaths) == 0 {
-				// git gave us the dir name but returned nothing — insert the
-				// dir node alone so it still appears in the tree.
-				insertPath(root, clean+"/")
-			} else {
-				for _, sp := range subPaths {
-					insertPath(root, sp)
-				}
-			}
-		} else {
+	for _, l := range lines {
+		clean := filepath.ToSlash(l.Path)
+		if l.Dir == nil {
+			// A file, or a directory git named but listed nothing
+			// under — insert it alone so it still appears in the tree.
 			insertPath(root, clean)
+			continue
 		}
+		if expandUntracked {
+			for _, f := range l.Dir.files {
+				insertPath(root, f)
+			}
+		}
+		insertPath(root, clean).note = l.Dir.summary()
 	}
 
 	// Label + render
 	ct := NewColoredText()
 	ct.Append("        . (untracked root)", Dim)
-	fmt.Println(ct.String())
-	renderTree(root, "        ", true, dirColor, fileColor, 0)
+	ct.WriteLine(w)
+	renderTree(w, root, "        ", true, st.treeDir, st.treeFile, 0)
 }
 
 // ---------------------------------------------------------------------------
@@ -888,11 +1015,7 @@ func parseRemote(input, cwd string) (owner, repo string, ok bool) {
 		if r == "" {
 			continue
 		}
-		cmd := exec.Command("git", "remote", "get-url", r)
-		if cwd != "" {
-			cmd.Dir = cwd
-		}
-		out, err := cmd.Output()
+		out, err := gitCmd(cwd, "remote", "get-url", r).Output()
 		if err == nil {
 			url := strings.TrimSpace(string(out))
 			if o, rp, ok2 := parseRemote(url, ""); ok2 {
@@ -955,10 +1078,7 @@ func (s *Status) ShowRemoteInfo(input, cwd string) {
 	// If a path was given as input (or input is empty meaning "use cwd"),
 	// show which directory we are resolving the remote from.
 	if input == "" || isPathLike(input) {
-		resolvedCwd := cwd
-		if abs, err := filepath.Abs(cwd); err == nil {
-			resolvedCwd = abs
-		}
+		resolvedCwd := workingDir(cwd)
 		fmt.Printf("%s %sResolving remote from:%s %s%s%s\n",
 			Icons.FOLDER,
 			Bold+resolveColor(c.CwdLabel), Reset,
@@ -972,7 +1092,7 @@ func (s *Status) ShowRemoteInfo(input, cwd string) {
 		} else {
 			fmt.Printf("%s Cannot resolve remote from %q\n", Icons.ERROR, input)
 		}
-		return
+		exit(1)
 	}
 
 	repoSlug := owner + "/" + repo
@@ -983,7 +1103,7 @@ func (s *Status) ShowRemoteInfo(input, cwd string) {
 	data, err := ghGet("https://api.github.com/repos/" + repoSlug)
 	if err != nil {
 		fmt.Printf("%s GitHub API error: %v\n", Icons.ERROR, err)
-		return
+		exit(1)
 	}
 	var ghR ghRepo
 	if err := json.Unmarshal(data, &ghR); err == nil && ghR.FullName != "" {
@@ -1057,7 +1177,80 @@ func (s *Status) ShowRemoteInfo(input, cwd string) {
 func printUsage() {
 	fmt.Println("Usage:")
 	fmt.Println("  gits [path]                    - show git status (colorized, tree mode)")
+	fmt.Println("  gits --filter QUERY [path]     - status of the paths fuzzy-matching QUERY only")
+	fmt.Println("  gits --format FORMAT [path]    - status as ansi, plain, json, markdown or html")
+	fmt.Println("  gits NAME [args]               - run the plugin gits-NAME from PATH, with GITS_REPO, GITS_BRANCH and GITS_JSON set")
+	fmt.Println("  gits self-update [--check]     - install the latest release once its checksum is verified; --check only tells")
+	fmt.Println("  gits --editor-mode [path]      - tab-separated status for editor plugins (see README)")
+	fmt.Println("  gits --copy staged|modified|untracked|all [path] - copy those paths to the clipboard (OSC 52 over SSH)")
 	fmt.Println("  gits -r [remote] [path]        - show GitHub remote info for a repo")
+	fmt.Println("  gits --watch [--poll [dur]] [--desktop] [path] - keep the status on screen, refresh on change")
+	fmt.Println("  gits add [--all] [path]        - pick files to stage from a checkbox list")
+	fmt.Println("  gits add --patch [path]        - pick the hunks and lines to stage, file by file")
+	fmt.Println("  gits diff [--staged] [path...] - colorized diff with changed words highlighted")
+	fmt.Println("  gits log [-n N] [--all] [-- path] - compact colored history with graph")
+	fmt.Println("  gits branch [--local] [--stale DAYS] [--delete-merged] - branch overview and cleanup")
+	fmt.Println("  gits branches --plain [--local] - branch names only, one per line")
+	fmt.Println("  gits files [--print0] [--staged|--unstaged|--untracked|--conflicts] [path] - changed files, one per line")
+	fmt.Println("  gits pick-file [--multi] [filters] [path] - pick changed files with fzf (or the built-in finder)")
+	fmt.Println("  gits edit [filters] [--filter GLOB] [path] - open changed files in the editor, conflicts at their markers")
+	fmt.Println("  gits stash [list|show|apply|pop|drop|push] [N] - stashes with diffstat previews")
+	fmt.Println("  gits commit -m MSG [--yes]     - preview staged changes, confirm, then commit")
+	fmt.Println("  gits push [-u] [--force] [remote [refspec]] - push with upstream/force checks and summary")
+	fmt.Println("  gits pull [--rebase]           - pull, then summarize received commits, files and conflicts")
+	fmt.Println("  gits fetch [--timeout DUR] [remote...] - fetch all remotes in parallel, report moved refs")
+	fmt.Println("  gits remote [list|add|rename|remove] - remotes with protocol, default branch, reachability")
+	fmt.Println("  gits tag [--date] [-n N|--all] - tags with annotations, signers and distance from HEAD")
+	fmt.Println("  gits tag create [NAME] [-m MSG] [--sign] - guided annotated/signed tag creation")
+	fmt.Println("  gits blame [-L START,END] FILE - aligned blame, colored by commit age")
+	fmt.Println("  gits show [REF] [--stat|--name-only] - commit metadata, signature, diffstat and patch")
+	fmt.Println("  gits clean [--untracked-only] [--force] [--trash [DIR]] - preview and confirm git clean -fdx")
+	fmt.Println("  gits restore [--staged] [--yes] [paths] - pick files to discard or unstage, previewing the diff")
+	fmt.Println("  gits switch [--yes] [QUERY] - fuzzy-find a branch (recent first) and switch to it")
+	fmt.Println("  gits undo [commit|unstage|discard [FILE]|reflog] [--yes] - guided undo with a safety stash")
+	fmt.Println("  gits amend [--no-edit] [--add PATH...] [--yes] - preview and amend the last commit")
+	fmt.Println("  gits wip [MESSAGE] - stage everything and save it as a timestamped WIP commit")
+	fmt.Println("  gits unwip [--yes] - soft-reset the latest WIP commit back into the worktree")
+	fmt.Println("  gits sync [--rebase|--merge] - fetch, integrate the upstream and push, with a divergence report")
+	fmt.Println("  gits worktree [list|add BRANCH [PATH]|remove [WORKTREE]] - worktrees with branch and dirtiness")
+	fmt.Println("  gits submodule [--update [--remote]] - pinned vs checked-out commits, dirtiness and lag")
+	fmt.Println("  gits hooks [list|install|enable|disable|check] [HOOK...] - inspect, install and toggle git hooks ([hooks] config)")
+	fmt.Println("  gits bisect start BAD GOOD... [--run CMD] | good|bad|skip | run CMD | reset - guided bisect")
+	fmt.Println("  gits pick [BRANCH] [-n N] - pick commits from another branch and cherry-pick them in order")
+	fmt.Println("  gits conflicts [--mark-resolved] - conflicted files, hunk counts and editor commands")
+	fmt.Println("  gits export [--ref REF] [--format zip|tar.gz|tar] [--untracked] [OUT] - source archive with progress")
+	fmt.Println("  gits init [DIR] [--branch NAME] [--ignore go,node] [--commit] [--remote URL] [--yes] - guided init")
+	fmt.Println("  gits clone URL [DIR] [--recursive] [--print-dir] - clone with a progress bar and summary")
+	fmt.Println("  gits ignore backup|restore|list [REMOTE] [N] [--yes] - per-remote .gitignore backups")
+	fmt.Println("  gits ignore add [TEMPLATE...] [--offline] - merge github/gitignore templates into .gitignore")
+	fmt.Println("  gits ignore suggest [--yes] - propose .gitignore rules from untracked files")
+	fmt.Println("  gits ignore tracked [--fix] [--yes] - tracked files matching .gitignore (git rm --cached them)")
+	fmt.Println("  gits ignore global [--create] [--edit] - global excludes file and what it hides here")
+	fmt.Println("  gits ignore lint [--fix] [--yes] - duplicate, shadowed, unused and malformed .gitignore rules")
+	fmt.Println("  gits ignore why PATH... - which file, line and pattern (don't) ignore a path")
+	fmt.Println("  gits ui [--no-mouse] [--no-watch] [DIR] - full-screen status browser, refreshed live")
+	fmt.Println("  gits popup [DIR]               - gits ui in a tmux popup (plain status outside tmux)")
+	fmt.Println("  gits keys [CONTEXT...]    - list key bindings of the interactive modes, with conflicts")
+	fmt.Println("  gits --backend native|cli ... - read the status with go-git or the git binary")
+	fmt.Println("  gits backend [check] [DIR...] - show the status backend, or compare both on DIRs")
+	fmt.Println("  gits accel [enable|disable] [DIR] - show or set git's fsmonitor and untracked cache")
+	fmt.Println("  gits bench [--files N] [--renames R] [--modified R] [--untracked N] [--runs K] [--json]")
+	fmt.Println("             [--baseline FILE [--tolerance PCT]] [--keep DIR] - time both backends on a generated repo")
+	fmt.Println("  gits repos add|remove|list     - manage the registry of bookmarked repos")
+	fmt.Println("  gits scan [@tag|dir]...        - dashboard table for many repos (--full for full status)")
+	fmt.Println("      --dirty-only               - hide repos that are clean and pushed")
+	fmt.Println("      --json                     - one JSON report with an aggregate summary")
+	fmt.Println("      --cached                   - show the latest `gits daemon` results instantly")
+	fmt.Println("      --go                       - add GOPATH, go.work members and ~/src-style roots")
+	fmt.Println("      --stale DAYS               - flag repos whose last commit is older than DAYS")
+	fmt.Println("      --refresh                  - bypass the directory discovery cache")
+	fmt.Println("      --fetch                    - fetch all repos in parallel first (--fetch-jobs, --fetch-timeout)")
+	fmt.Println("  gits unpushed [--json] [targets...] - local branches with commits on no remote")
+	fmt.Println("  gits exporter [--listen :9321] [targets...] - serve Prometheus metrics")
+	fmt.Println("  gits notify [--webhook URL] [--dry-run] [targets...] - post dirty/unpushed summary")
+	fmt.Println("  gits daemon [--once] [--schedule SPEC] [--desktop] - scheduled scans; read with `gits scan --cached`")
+	fmt.Println("  gits prompt [--async] [--powerline [--shell bash|zsh]] [DIR] - one-line status for shell prompts")
+	fmt.Println("  gits serve [--socket PATH] [--http ADDR] [--root DIR]... - JSON-RPC socket or REST/HTML server (status, summary, scan)")
 	fmt.Println("")
 	fmt.Println("  [remote] can be:")
 	fmt.Println("    .                   (current dir — resolves origin automatically)")
@@ -1069,7 +1262,19 @@ func printUsage() {
 	fmt.Println("")
 	fmt.Println("Config: ~/.gits.toml  (see --dump-config for example)")
 	fmt.Println("")
+	fmt.Println("Flags: --debug      - print diagnostics (config path, ...) to stderr")
+	fmt.Println("       --no-cache   - read every repository again instead of using the status cache")
+	fmt.Println("       --fast       - skip the untracked scan and submodule worktrees (-uno --ignore-submodules=dirty)")
+	fmt.Println("       --resolve-symlinks - show and use physical paths when the directory is reached through a symlink")
+	fmt.Println("       --expand-untracked - list the files inside untracked directories, not only their count and size")
+	fmt.Println("       --trust      - add a repository owned by another user to safe.directory (asks first), then run again")
+	fmt.Println("       --remove-stale-lock[=AGE] - remove an index.lock older than AGE (10m) that no git holds (asks first)")
+	fmt.Println("       --timings    - time spent spawning git, waiting on it, parsing and rendering (stderr)")
+	fmt.Println("       --cpuprofile FILE, --memprofile FILE - write pprof profiles of the run")
+	fmt.Println("       Flags go before the command; what follows it, or --, is the command's.")
+	fmt.Println("")
 	fmt.Println("Env: GITHUB_TOKEN   - set to avoid rate limits on -r")
+	fmt.Println("     GITS_DEBUG=1   - same as --debug")
 }
 
 func dumpConfig(cfg AppConfig) {
@@ -1078,27 +1283,307 @@ func dumpConfig(cfg AppConfig) {
 }
 
 func main() {
+	exit(run())
+}
+
+// run is gits: it reads the global flags and runs the command, returning
+// the exit code.
+func run() int {
+	args := make([]string, 0, len(os.Args))
+	backend, filter, format := "", "", ""
+	noCache := false
+	profiles := map[string]string{}
+	sawDir := false
+flags:
+	for i := 1; i < len(os.Args); i++ {
+		switch a := os.Args[i]; {
+		case a == "--":
+			args = append(args, os.Args[i+1:]...)
+			break flags
+		case a == "--debug":
+			debugMode = true
+		case a == "--no-cache":
+			noCache = true
+		case a == "--fast":
+			fastStatus = true
+		case a == "--resolve-symlinks":
+			resolveSymlinks = true
+		case a == "--expand-untracked":
+			expandUntracked = true
+		case a == "--trust":
+			trustRepos = true
+		case a == "--remove-stale-lock":
+			staleLockAfter = defaultStaleLock
+		case strings.HasPrefix(a, "--remove-stale-lock="):
+			staleLockAfter = parseDurationOr(strings.TrimPrefix(a, "--remove-stale-lock="), defaultStaleLock)
+		case a == "--timings":
+			startTimings()
+			atExit = append(atExit, timings.print)
+		case (a == "--cpuprofile" || a == "--memprofile") && i+1 < len(os.Args):
+			i++
+			profiles[a[2:]] = os.Args[i]
+		case strings.HasPrefix(a, "--cpuprofile=") || strings.HasPrefix(a, "--memprofile="):
+			name, file, _ := strings.Cut(a[2:], "=")
+			profiles[name] = file
+		case a == "--backend" && i+1 < len(os.Args):
+			i++
+			backend = os.Args[i]
+		case strings.HasPrefix(a, "--backend="):
+			backend = strings.TrimPrefix(a, "--backend=")
+		case a == "--filter":
+			if i+1 == len(os.Args) {
+				fmt.Printf("%s %susage: gits --filter QUERY [DIR] [REMOTE]%s\n", Icons.ERROR, Bold+resolveColor(DefaultConfig().Colors.Deleted), Reset)
+				return 1
+			}
+			i++
+			filter = os.Args[i]
+		case strings.HasPrefix(a, "--filter="):
+			filter = strings.TrimPrefix(a, "--filter=")
+		case a == "--format":
+			if i+1 == len(os.Args) {
+				fmt.Printf("%s %susage: gits --format %s [DIR] [REMOTE]%s\n", Icons.ERROR, Bold+resolveColor(DefaultConfig().Colors.Deleted), strings.Join(statusFormats, "|"), Reset)
+				return 1
+			}
+			i++
+			format = os.Args[i]
+		case strings.HasPrefix(a, "--format="):
+			format = strings.TrimPrefix(a, "--format=")
+		default:
+			args = append(args, a)
+			// The first word that is not a directory names a command:
+			// what follows is its own, flags included.  A directory is
+			// the status', which may be followed by a remote and flags.
+			if !strings.HasPrefix(a, "-") && !sawDir {
+				if !IsDir(a) {
+					args = append(args, os.Args[i+1:]...)
+					break flags
+				}
+				sawDir = true
+			}
+		}
+	}
+
+	if len(profiles) > 0 {
+		stop, err := startProfiles(profiles["cpuprofile"], profiles["memprofile"])
+		if err != nil {
+			fmt.Printf("%s %sprofile: %v%s\n", Icons.ERROR, Bold+resolveColor(DefaultConfig().Colors.Deleted), err, Reset)
+			return 1
+		}
+		atExit = append(atExit, stop)
+	}
+
 	cfg := LoadConfig()
+	keys = newKeyMaps(cfg.Keys)
+	links = cfg.Links
+	gitMessages = cfg.GitMessages
+	resolveSymlinks = resolveSymlinks || cfg.ResolveSymlinks
+	expandUntracked = expandUntracked || cfg.ExpandUntracked
+	switch {
+	case backend != "":
+		statusBackend = backend
+	case cfg.Backend != "":
+		statusBackend = cfg.Backend
+	}
+	if statusBackend != "cli" && statusBackend != "native" {
+		fmt.Printf("%s %sunknown backend %q (cli, native)%s\n", Icons.ERROR, Bold+resolveColor(cfg.Colors.Deleted), statusBackend, Reset)
+		return 1
+	}
+	if format != "" {
+		if _, err := newRenderer(format, io.Discard, cfg); err != nil {
+			fmt.Printf("%s %s%v%s\n", Icons.ERROR, Bold+resolveColor(cfg.Colors.Deleted), err, Reset)
+			return 1
+		}
+	}
+	if cfg.Cache.Enabled && !noCache {
+		statusCacheTTL = parseDurationOr(cfg.Cache.TTL, 10*time.Minute)
+	}
+
 	status := NewStatus(cfg)
+	status.filter = filter
+	status.format = format
 
-	args := os.Args[1:]
+	command := ""
+	if len(args) > 0 {
+		command = args[0]
+	}
+	handleInterrupts(command, cfg.Colors)
 
 	if len(args) > 0 {
+		if strings.HasPrefix(args[0], "-") || !IsDir(args[0]) {
+			requireGit(args[0], cfg.Colors)
+		}
 		switch args[0] {
 		case "-h", "--help":
 			printUsage()
-			return
+			return 0
 		case "--dump-config":
 			dumpConfig(cfg)
-			return
+			return 0
+		case "-w", "--watch":
+			runWatch(status, args[1:])
+			return 0
+		case "prompt":
+			runPrompt(status, args[1:])
+			return 0
+		case "files":
+			runFiles(status, args[1:])
+			return 0
+		case "pick-file":
+			runPickFile(status, args[1:])
+			return 0
+		case "edit":
+			runEdit(status, args[1:])
+			return 0
+		case "serve":
+			runServe(status, args[1:])
+			return 0
+		case "add":
+			runAdd(status, args[1:])
+			return 0
+		case "diff":
+			runDiff(status, args[1:])
+			return 0
+		case "log":
+			runLog(status, args[1:])
+			return 0
+		case "branch", "branches":
+			runBranch(status, args[1:])
+			return 0
+		case "stash":
+			runStash(status, args[1:])
+			return 0
+		case "commit":
+			runCommit(status, args[1:])
+			return 0
+		case "push":
+			runPush(status, args[1:])
+			return 0
+		case "pull":
+			runPull(status, args[1:])
+			return 0
+		case "fetch":
+			runFetch(status, args[1:])
+			return 0
+		case "remote":
+			runRemote(status, args[1:])
+			return 0
+		case "tag":
+			runTag(status, args[1:])
+			return 0
+		case "blame":
+			runBlame(status, args[1:])
+			return 0
+		case "show":
+			runShow(status, args[1:])
+			return 0
+		case "clean":
+			runClean(status, args[1:])
+			return 0
+		case "restore":
+			runRestore(status, args[1:])
+			return 0
+		case "switch":
+			runSwitch(status, args[1:])
+			return 0
+		case "undo":
+			runUndo(status, args[1:])
+			return 0
+		case "amend":
+			runAmend(status, args[1:])
+			return 0
+		case "wip":
+			runWip(status, args[1:])
+			return 0
+		case "unwip":
+			runUnwip(status, args[1:])
+			return 0
+		case "sync":
+			runSync(status, args[1:])
+			return 0
+		case "worktree":
+			runWorktree(status, args[1:])
+			return 0
+		case "submodule":
+			runSubmodule(status, args[1:])
+			return 0
+		case "hooks":
+			runHooks(status, args[1:])
+			return 0
+		case "bisect":
+			runBisect(status, args[1:])
+			return 0
+		case "pick":
+			runPick(status, args[1:])
+			return 0
+		case "conflicts":
+			runConflicts(status, args[1:])
+			return 0
+		case "export":
+			runExport(status, args[1:])
+			return 0
+		case "init":
+			runInit(status, args[1:])
+			return 0
+		case "clone":
+			runClone(status, args[1:])
+			return 0
+		case "ignore":
+			runIgnore(status, args[1:])
+			return 0
+		case "ui":
+			runUI(status, args[1:])
+			return 0
+		case "popup":
+			runPopup(status, args[1:])
+			return 0
+		case "keys":
+			runKeys(status, args[1:])
+			return 0
+		case "backend":
+			runBackend(status, args[1:])
+			return 0
+		case "accel":
+			runAccel(status, args[1:])
+			return 0
+		case "bench":
+			runBench(status, args[1:])
+			return 0
+		case "repos":
+			runRepos(cfg, args[1:])
+			return 0
+		case "scan", "dashboard":
+			runScan(status, args[1:])
+			return 0
+		case "unpushed":
+			runUnpushed(status, args[1:])
+			return 0
+		case "exporter":
+			runExporter(status, args[1:])
+			return 0
+		case "notify":
+			runNotify(status, args[1:])
+			return 0
+		case "daemon":
+			runDaemon(status, args[1:])
+			return 0
 		case "--tree":
-			cfg.TreeMode = true
-			status = NewStatus(cfg)
+			status.cfg.TreeMode = true
 			args = args[1:]
 		case "--no-tree":
-			cfg.TreeMode = false
-			status = NewStatus(cfg)
+			status.cfg.TreeMode = false
+			args = args[1:]
+		case "--tracked-ignored":
+			status.cfg.ShowTrackedIgnored = true
 			args = args[1:]
+		case "--editor-mode":
+			runEditorMode(status, args[1:])
+			return 0
+		case "self-update":
+			runSelfUpdate(status, args[1:])
+			return 0
+		case "--copy":
+			runCopy(status, args[1:])
+			return 0
 		case "-r", "--remote":
 			// Accepted forms:
 			//   gits -r                        -> origin of cwd "."
@@ -1123,7 +1608,14 @@ func main() {
 				}
 			}
 			status.ShowRemoteInfo(remoteInput, cwd)
-			return
+			return 0
+		default:
+			// Not a command or a directory: a plugin, if one is on PATH.
+			if !IsDir(args[0]) {
+				if path, ok := findPlugin(args[0]); ok {
+					runPlugin(status, path, args[1:])
+				}
+			}
 		}
 	}
 
@@ -1136,5 +1628,8 @@ func main() {
 		remoteName = args[1]
 	}
 
-	status.ColorizeGitStatus(targetDir, remoteName)
+	if !status.ColorizeGitStatus(targetDir, remoteName) {
+		return status.exitCode
+	}
+	return 0
 }
\ No newline at end of file
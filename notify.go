@@ -0,0 +1,187 @@
+// File: notify.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: webhook (Slack/Discord/JSON) reports of dirty or unpushed repos
+// License: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// NotifyConfig controls `gits notify` (and scheduled reports).
+type NotifyConfig struct {
+	Webhook           string   `toml:"webhook"`
+	Format            string   `toml:"format"`              // slack, discord, json (empty = guess from URL)
+	UnpushedOlderThan string   `toml:"unpushed_older_than"` // e.g. "24h"; only older unpushed commits are reported
+	Targets           []string `toml:"targets"`
+}
+
+// webhookFormat picks the payload flavour, guessing from the URL when the
+// format is not configured.
+func webhookFormat(url, format string) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+	switch {
+	case strings.Contains(url, "hooks.slack.com"):
+		return "slack"
+	case strings.Contains(url, "discord.com/api/webhooks"), strings.Contains(url, "discordapp.com/api/webhooks"):
+		return "discord"
+	}
+	return "json"
+}
+
+// staleUnpushed returns the unpushed branches whose oldest commit is older
+// than threshold.
+func staleUnpushed(r *RepoStatus, threshold time.Duration) []UnpushedBranch {
+	var out []UnpushedBranch
+	for _, u := range r.Unpushed {
+		if time.Since(u.Oldest) >= threshold {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// notifyMessage builds a plain-text summary of the repositories needing
+// attention.  It returns "" when there is nothing to report.
+func notifyMessage(results []*RepoStatus, threshold time.Duration) string {
+	var sb strings.Builder
+	count := 0
+	for _, r := range results {
+		stale := staleUnpushed(r, threshold)
+		if r.Err == "" && !r.Dirty() && len(stale) == 0 {
+			continue
+		}
+		count++
+		fmt.Fprintf(&sb, "• %s", displayPath(r.Path))
+		if r.Branch != "" {
+			fmt.Fprintf(&sb, " (%s)", r.Branch)
+		}
+		var parts []string
+		if r.Err != "" {
+			parts = append(parts, "error: "+firstLine(r.Err))
+		}
+		if r.Dirty() {
+			parts = append(parts, fmt.Sprintf("%d uncommitted", r.Staged+r.Modified+r.Untracked+r.Conflicts))
+		}
+		for _, u := range stale {
+			parts = append(parts, fmt.Sprintf("%s: %d unpushed since %s", u.Name, u.Commits, u.Oldest.Format("2006-01-02")))
+		}
+		fmt.Fprintf(&sb, " — %s\n", strings.Join(parts, ", "))
+	}
+	if count == 0 {
+		return ""
+	}
+	host, _ := os.Hostname()
+	return fmt.Sprintf("gits: %d repositories on %s need attention\n%s", count, host, sb.String())
+}
+
+// postWebhook sends text (and the report, for generic JSON hooks) to url.
+func postWebhook(url, format, text string, report *FleetReport) error {
+	var payload any
+	switch webhookFormat(url, format) {
+	case "slack":
+		payload = map[string]string{"text": text}
+	case "discord":
+		// Discord rejects content longer than 2000 characters.
+		if r := []rune(text); len(r) > 1990 {
+			text = string(r[:1990]) + "…"
+		}
+		payload = map[string]string{"content": text}
+	default:
+		payload = map[string]any{"text": text, "report": report}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// runNotify implements `gits notify [--webhook URL] [--format F]
+// [--older-than DUR] [--dry-run] [targets...]`.
+func runNotify(status *Status, args []string) {
+	c := status.cfg.Colors
+	nc := status.cfg.Notify
+	url := nc.Webhook
+	format := nc.Format
+	threshold := parseDurationOr(nc.UnpushedOlderThan, 0)
+	targets := nc.Targets
+	dryRun := false
+
+	var argTargets []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--webhook":
+			if i+1 < len(args) {
+				i++
+				url = args[i]
+			}
+		case "--format":
+			if i+1 < len(args) {
+				i++
+				format = args[i]
+			}
+		case "--older-than":
+			if i+1 < len(args) {
+				i++
+				if d, err := time.ParseDuration(args[i]); err == nil {
+					threshold = d
+				}
+			}
+		case "--dry-run", "-n":
+			dryRun = true
+		default:
+			argTargets = append(argTargets, args[i])
+		}
+	}
+	if len(argTargets) > 0 {
+		targets = argTargets
+	}
+	if url == "" && !dryRun {
+		fmt.Printf("%s %sNo webhook configured%s (set [notify] webhook in the config or pass --webhook)\n",
+			Icons.ERROR, Bold+resolveColor(c.Deleted), Reset)
+		exit(1)
+	}
+
+	repos, err := resolveScanTargets(status.cfg.Groups, targets, 6)
+	if err != nil {
+		exitWithError(err, c)
+	}
+	results := collectAll(repos)
+	fillUnpushed(results)
+
+	text := notifyMessage(results, threshold)
+	if text == "" {
+		fmt.Printf("%s %sNothing to report%s (%d repositories checked)\n",
+			Icons.SUCCESS, resolveColor(c.UpToDate), Reset, len(repos))
+		return
+	}
+	if dryRun {
+		fmt.Print(text)
+		return
+	}
+	if err := postWebhook(url, format, text, buildFleetReport(results)); err != nil {
+		exitWithError(err, c)
+	}
+	fmt.Printf("%s Report sent (%s)\n", Icons.SUCCESS, webhookFormat(url, format))
+}
@@ -0,0 +1,118 @@
+// File: notrepo.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: a friendly explanation, with suggestions, outside a repository
+// License: MIT
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"golang.org/x/term"
+)
+
+// isNotRepoErr reports whether err says there is no repository, from git,
+// go-git or gits itself.
+func isNotRepoErr(err error) bool {
+	return err != nil && (errors.Is(err, errNotRepo) || errors.Is(err, git.ErrRepositoryNotExists) ||
+		strings.Contains(err.Error(), "not a git repository"))
+}
+
+// ancestorGitDir returns the nearest directory above dir holding a .git
+// that git did not pick up, which happens when GIT_CEILING_DIRECTORIES
+// stops the search or the repository is on another filesystem.
+func ancestorGitDir(dir string) string {
+	for d := filepath.Dir(dir); ; d = filepath.Dir(d) {
+		if Exists(filepath.Join(d, ".git")) {
+			return d
+		}
+		if filepath.Dir(d) == d {
+			return ""
+		}
+	}
+}
+
+// childRepos returns up to max repositories in the directories right below
+// dir and one level further, nearest first.
+func childRepos(dir string, max int) []string {
+	var found []string
+	level := []string{dir}
+	for depth := 0; depth < 2 && len(found) < max; depth++ {
+		var next []string
+		for _, d := range level {
+			entries, err := os.ReadDir(d)
+			if err != nil {
+				continue
+			}
+			for _, e := range entries {
+				name := e.Name()
+				if !e.IsDir() || strings.HasPrefix(name, ".") || skipScanDirs[name] {
+					continue
+				}
+				p := filepath.Join(d, name)
+				if Exists(filepath.Join(p, ".git")) {
+					found = append(found, p)
+				} else {
+					next = append(next, p)
+				}
+			}
+		}
+		level = next
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return strings.Count(found[i], string(filepath.Separator)) < strings.Count(found[j], string(filepath.Separator))
+	})
+	return found[:min(len(found), max)]
+}
+
+// explainNotRepo tells the user dir is not in a repository, instead of
+// git's fatal error: where the nearest repositories are, and on a
+// terminal a single key to run `gits init` there.  It reports whether a
+// repository was created.
+func (s *Status) explainNotRepo(dir string) bool {
+	c := s.cfg.Colors
+	s.exitCode = failureModes[failureNotRepo].code
+	fmt.Printf("%s %s%s is not in a git repository%s\n", Icons.WARNING, Bold+resolveColor(c.AheadBehind), displayPath(dir), Reset)
+	if up := ancestorGitDir(dir); up != "" {
+		fmt.Printf("   %sgit did not look as far up as%s %s %s(GIT_CEILING_DIRECTORIES, or another filesystem)%s\n",
+			Dim, Reset, displayPath(up), Dim, Reset)
+		fmt.Printf("   %s$ cd %s%s\n", Dim, shellQuote(up), Reset)
+	}
+	if repos := childRepos(dir, 5); len(repos) > 0 {
+		fmt.Printf("   %srepositories below:%s\n", Dim, Reset)
+		for _, r := range repos {
+			rel, err := filepath.Rel(dir, r)
+			if err != nil {
+				rel = r
+			}
+			fmt.Printf("   %s$ cd %s%s\n", Dim, shellQuote(rel), Reset)
+		}
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Printf("   %s$ gits init%s %s(to create one here)%s\n", Dim, Reset, Dim, Reset)
+		return false
+	}
+	fmt.Printf("%s Press %si%s to create one here with gits init, any other key to leave it ", Icons.INFO, Bold, Reset)
+	restore, err := rawTerminal(fd, "")
+	if err != nil {
+		fmt.Println()
+		return false
+	}
+	key, err := readTerminalKey()
+	restore()
+	fmt.Println()
+	if err != nil || (key != "i" && key != "I") {
+		return false
+	}
+	runInit(s, []string{dir})
+	return true
+}
@@ -0,0 +1,142 @@
+// File: pick.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: cherry-pick from a list of another branch's commits (`gits pick`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// runPick implements `gits pick [BRANCH] [-n N]`: list commits on BRANCH
+// that HEAD does not have (patch-equivalent ones are left out), pick some,
+// and cherry-pick them oldest first.
+func runPick(status *Status, args []string) {
+	c := status.cfg.Colors
+	limit := 30
+	branch := ""
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n":
+			if i+1 < len(args) {
+				i++
+				if n, err := strconv.Atoi(args[i]); err == nil {
+					limit = n
+				}
+			}
+		default:
+			branch = args[i]
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	if branch == "" {
+		branches, err := switchCandidates(root)
+		if err != nil {
+			fail(err)
+		}
+		var items []pickItem
+		var names []string
+		for _, b := range branches {
+			if b.Current {
+				continue
+			}
+			names = append(names, b.Name)
+			items = append(items, pickItem{Tag: fmt.Sprintf("%4s", shortAge(b.LastCommit)), TagStyle: Dim, Label: b.Name})
+		}
+		if len(items) == 0 {
+			fail(fmt.Errorf("no other branches to pick from"))
+		}
+		i, ok, err := fuzzyFind("Pick commits from which branch?", "", items, c)
+		if err != nil {
+			fail(err)
+		}
+		if !ok {
+			return
+		}
+		branch = names[i]
+	}
+
+	out, err := gitOutput(root, "log", "--cherry-pick", "--right-only", "--no-merges",
+		"-n", strconv.Itoa(limit), "--format=%H%x00%h%x00%an%x00%at%x00%s", "HEAD..."+branch)
+	if err != nil {
+		fail(err)
+	}
+	if out == "" {
+		fmt.Printf("%s %sNothing to pick: every commit on %s is already here%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), branch, Reset)
+		return
+	}
+	var shas []string
+	var items []pickItem
+	palette := lanePalette(c)
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.SplitN(line, "\x00", 5)
+		if len(f) < 5 {
+			continue
+		}
+		age := ""
+		if ts, err := strconv.ParseInt(f[3], 10, 64); err == nil {
+			age = shortAge(time.Unix(ts, 0))
+		}
+		shas = append(shas, f[0])
+		items = append(items, pickItem{
+			Tag:      f[1],
+			TagStyle: Bold + resolveColor(c.AheadBehind),
+			Label:    fmt.Sprintf("%s%-4s%s %s%s%s %s", Dim, age, Reset, authorColor(f[2], palette), f[2], Reset, f[4]),
+		})
+	}
+
+	checked, ok, err := runPicker(fmt.Sprintf("Cherry-pick from %s onto HEAD", branch), items, c)
+	if err != nil {
+		fail(err)
+	}
+	if !ok {
+		fmt.Printf("%s Cancelled, nothing picked\n", Icons.INFO)
+		return
+	}
+	// The list is newest first; apply in history order.
+	var picked []string
+	for i := len(checked) - 1; i >= 0; i-- {
+		if checked[i] {
+			picked = append(picked, shas[i])
+		}
+	}
+	if len(picked) == 0 {
+		fmt.Printf("%s Nothing selected\n", Icons.INFO)
+		return
+	}
+
+	before, _ := gitOutput(root, "rev-parse", "HEAD")
+	if out, err := gitCmd(root, append([]string{"cherry-pick"}, picked...)...).CombinedOutput(); err != nil {
+		done, _ := gitOutput(root, "rev-list", "--count", before+"..HEAD")
+		if n, _ := strconv.Atoi(done); n > 0 {
+			fmt.Printf("%s %sPicked %d of %d commit(s) before stopping%s\n", Icons.WARNING, resolveColor(c.AheadBehind), n, len(picked), Reset)
+		}
+		if rs := CollectStatus(root); rs.Conflicts > 0 {
+			// The status view carries git's continue/skip/abort hints.
+			fmt.Println()
+			status.ColorizeGitStatus(root, "")
+		} else {
+			fmt.Printf("%s %sgit cherry-pick: %s%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), strings.TrimSpace(string(out)), Reset)
+		}
+		exit(1)
+	}
+	fmt.Printf("%s %sPicked %d commit(s) from %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), len(picked), branch, Reset)
+	if log, err := gitOutput(root, "log", "--format=%h%x00%s", "-n", strconv.Itoa(len(picked))); err == nil {
+		for _, line := range strings.Split(log, "\n") {
+			sha, subject, _ := strings.Cut(line, "\x00")
+			fmt.Printf("    %s%s%s %s\n", Bold+resolveColor(c.AheadBehind), sha, Reset, subject)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// File: picker.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: interactive checkbox lists, menus and prompts
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// errNotTerminal is returned when an interactive prompt has no terminal.
+var errNotTerminal = errors.New("not a terminal — interactive mode needs a tty")
+
+// pickItem is one line of a picker: a short colored tag and a label.
+type pickItem struct {
+	Tag      string
+	TagStyle string
+	Label    string
+	Checked  bool
+}
+
+// Keys returned by readKey besides plain characters.
+const (
+	keyUp    = "up"
+	keyDown  = "down"
+	keyLeft  = "left"
+	keyRight = "right"
+	keyPgUp  = "pgup"
+	keyPgDn  = "pgdn"
+	keyHome  = "home"
+	keyEnd   = "end"
+	keyEnter = "enter"
+	keyEsc   = "esc"
+	keyCtrlC = "ctrl-c"
+)
+
+// keyPress is one result of readTerminalKey.
+type keyPress struct {
+	key string
+	err error
+}
+
+// keyInput, once startKeyReader has run, delivers the keypresses read in the
+// background, so a caller can wait for keys and other events at once.
+var keyInput chan keyPress
+
+// startKeyReader moves terminal reads to a goroutine feeding keyInput.  It
+// runs until a read fails; readKey then takes its keys from keyInput.
+func startKeyReader() {
+	keyInput = make(chan keyPress)
+	go func() {
+		for {
+			key, err := readTerminalKey()
+			keyInput <- keyPress{key, err}
+			if err != nil {
+				return
+			}
+		}
+	}()
+}
+
+// readKey returns the next keypress, from keyInput when the background
+// reader runs.
+func readKey() (string, error) {
+	if keyInput != nil {
+		kp := <-keyInput
+		return kp.key, kp.err
+	}
+	return readTerminalKey()
+}
+
+// readTerminalKey reads one keypress from a terminal in raw mode.  Escape
+// sequences arrive in a single read, so a lone ESC byte is the Escape key
+// itself.
+func readTerminalKey() (string, error) {
+	var buf [16]byte
+	n, err := os.Stdin.Read(buf[:])
+	if err != nil {
+		return "", err
+	}
+	b := buf[:n]
+	switch {
+	case n == 1 && b[0] == 3:
+		return keyCtrlC, nil
+	case n == 1 && (b[0] == '\r' || b[0] == '\n'):
+		return keyEnter, nil
+	case n == 1 && b[0] == 27:
+		return keyEsc, nil
+	case n >= 3 && b[0] == 27 && b[1] == '[' && b[2] == '<':
+		// SGR mouse report, decoded by parseMouse.
+		return string(b), nil
+	case n >= 3 && b[0] == 27 && (b[1] == '[' || b[1] == 'O'):
+		switch string(b[2:n]) {
+		case "A":
+			return keyUp, nil
+		case "B":
+			return keyDown, nil
+		case "C":
+			return keyRight, nil
+		case "D":
+			return keyLeft, nil
+		case "H", "1~", "7~":
+			return keyHome, nil
+		case "F", "4~", "8~":
+			return keyEnd, nil
+		case "5~":
+			return keyPgUp, nil
+		case "6~":
+			return keyPgDn, nil
+		}
+		return "", nil
+	}
+	return string(b), nil
+}
+
+// runPicker shows items as a checkbox list on the terminal and lets the user
+// toggle them with the arrow keys and space.  It returns the checked state
+// of every item, or ok=false when the user cancelled with q, Esc or Ctrl-C.
+func runPicker(title string, items []pickItem, c ColorConfig) (checked []bool, ok bool, err error) {
+	checked, _, ok, err = pickList(title, items, c, true)
+	return checked, ok, err
+}
+
+// pickOne shows items as a menu and returns the index chosen with enter.
+func pickOne(title string, items []pickItem, c ColorConfig) (index int, ok bool, err error) {
+	_, index, ok, err = pickList(title, items, c, false)
+	return index, ok, err
+}
+
+// pickList drives both pickers: with multi it toggles checkboxes, otherwise
+// enter selects the item under the cursor.
+func pickList(title string, items []pickItem, c ColorConfig, multi bool) (checked []bool, cursor int, ok bool, err error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil, 0, false, errNotTerminal
+	}
+	restore, err := rawTerminal(fd, "\x1b[?25h")
+	if err != nil {
+		return nil, 0, false, err
+	}
+	defer restore()
+
+	checked = make([]bool, len(items))
+	for i, it := range items {
+		checked[i] = it.Checked
+	}
+
+	top, drawn := 0, 0
+	fmt.Print("\x1b[?25l")
+	defer fmt.Print("\x1b[?25h")
+
+	draw := func() {
+		height := len(items)
+		if _, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil && h > 4 && height > h-3 {
+			height = h - 3
+		}
+		if cursor < top {
+			top = cursor
+		}
+		if cursor >= top+height {
+			top = cursor - height + 1
+		}
+
+		var sb strings.Builder
+		if drawn > 0 {
+			fmt.Fprintf(&sb, "\x1b[%dA", drawn)
+		}
+		sb.WriteString("\r\x1b[J")
+		n := 0
+		fmt.Fprintf(&sb, "%s %s%s%s\r\n", Icons.GIT, Bold+resolveColor(c.Header), title, Reset)
+		n++
+		for i := top; i < top+height; i++ {
+			pointer, box := "  ", ""
+			if multi {
+				box = "[ ] "
+				if checked[i] {
+					box = Bold + resolveColor(c.Staged) + "[x]" + Reset + " "
+				}
+			}
+			label := items[i].Label
+			if i == cursor {
+				pointer = Bold + resolveColor(c.Arrow) + "❯ " + Reset
+				label = Bold + label + Reset
+			}
+			fmt.Fprintf(&sb, "%s%s%s%s%s %s\r\n", pointer, box, items[i].TagStyle, items[i].Tag, Reset, label)
+			n++
+		}
+		if multi {
+			selected := 0
+			for _, v := range checked {
+				if v {
+					selected++
+				}
+			}
+			fmt.Fprintf(&sb, "%s%d/%d selected · ↑↓ move · space toggle · a all · enter confirm · q cancel%s",
+				Dim, selected, len(items), Reset)
+		} else {
+			fmt.Fprintf(&sb, "%s↑↓ move · enter select · q cancel%s", Dim, Reset)
+		}
+		fmt.Print(sb.String())
+		drawn = n
+	}
+
+	finish := func() {
+		fmt.Print("\r\n")
+	}
+
+	for {
+		draw()
+		key, err := readKey()
+		if err != nil {
+			finish()
+			return nil, 0, false, err
+		}
+		switch boundAction("picker", key) {
+		case "up":
+			if cursor > 0 {
+				cursor--
+			}
+		case "down":
+			if cursor < len(items)-1 {
+				cursor++
+			}
+		case "pgup":
+			cursor = max(cursor-10, 0)
+		case "pgdn":
+			cursor = min(cursor+10, len(items)-1)
+		case "top":
+			cursor = 0
+		case "bottom":
+			cursor = len(items) - 1
+		case "toggle":
+			if multi {
+				checked[cursor] = !checked[cursor]
+			}
+		case "all":
+			if !multi {
+				break
+			}
+			// Select everything, or clear when everything is selected.
+			all := true
+			for _, v := range checked {
+				all = all && v
+			}
+			for i := range checked {
+				checked[i] = !all
+			}
+		case "accept":
+			finish()
+			return checked, cursor, true, nil
+		case "cancel":
+			finish()
+			return nil, 0, false, nil
+		}
+	}
+}
+
+// confirm asks a yes/no question on the terminal; anything but y/yes is no.
+// Without a terminal it returns errNotTerminal so callers can demand --yes.
+func confirm(question string) (bool, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, errNotTerminal
+	}
+	fmt.Printf("%s %s [y/N] ", Icons.WARNING, question)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Println()
+		return false, nil
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
+
+// promptLine asks for one line of text on the terminal, returning def when
+// the answer is empty.
+func promptLine(question, def string) (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", errNotTerminal
+	}
+	if def != "" {
+		fmt.Printf("%s %s %s[%s]%s ", Icons.INFO, question, Dim, def, Reset)
+	} else {
+		fmt.Printf("%s %s ", Icons.INFO, question)
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Println()
+		return "", err
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
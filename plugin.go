@@ -0,0 +1,79 @@
+// File: plugin.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: plugins: `gits NAME` runs a gits-NAME executable from PATH
+// License: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// pluginPrefix starts the name of every plugin executable.
+const pluginPrefix = "gits-"
+
+// maxPluginJSON caps GITS_JSON: past it the file entries are left out, as
+// an environment variable that large could keep the plugin from starting.
+const maxPluginJSON = 64 << 10
+
+// findPlugin looks up the plugin for the command name on PATH: gits-NAME,
+// or on Windows gits-NAME.exe and the other PATHEXT extensions.
+func findPlugin(name string) (string, bool) {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, `/\`) {
+		return "", false
+	}
+	path, err := exec.LookPath(pluginPrefix + name)
+	return path, err == nil
+}
+
+// pluginEnv is the environment of a plugin run from dir: gits' own, with
+// the repository holding dir in GITS_REPO, its branch in GITS_BRANCH and
+// its status as `gits scan --json` has it in GITS_JSON.  Outside any
+// repository the three are empty.
+func pluginEnv(dir string) []string {
+	env := os.Environ()
+	root, err := repoRoot(dir)
+	if err != nil {
+		return append(env, "GITS_REPO=", "GITS_BRANCH=", "GITS_JSON=")
+	}
+	rs := Collector{}.Status(root)
+	data, _ := json.Marshal(rs)
+	if len(data) > maxPluginJSON {
+		rs.Entries = nil
+		data, _ = json.Marshal(rs)
+	}
+	return append(env, "GITS_REPO="+root, "GITS_BRANCH="+rs.Branch, "GITS_JSON="+string(data))
+}
+
+// runPlugin runs the plugin at path with args, the way git runs its
+// external commands, and exits with its exit status.  Ctrl+C is the
+// plugin's to handle while it runs.
+func runPlugin(status *Status, path string, args []string) {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = pluginEnv(".")
+	if err := cmd.Start(); err != nil {
+		exitWithError(err, status.cfg.Colors)
+	}
+	foreground.Store(cmd.Process)
+	err := cmd.Wait()
+	foreground.Store(nil)
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		exit(0)
+	case errors.As(err, &exitErr):
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			exit(128 + int(ws.Signal()))
+		}
+		exit(exitErr.ExitCode())
+	}
+	exitWithError(err, status.cfg.Colors)
+}
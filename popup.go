@@ -0,0 +1,122 @@
+// File: popup.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: `gits popup`: the status UI in a tmux popup
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// popupSize picks a popup size for the UI over rs that fits its rows, on a
+// client of cw×ch cells: the list and the pane below it split the height
+// evenly, so the list rows are counted twice.
+func popupSize(rs *RepoStatus, cw, ch int) (int, int) {
+	rows, width := 0, 0
+	sections := map[string]bool{}
+	for _, e := range rs.Entries {
+		if e.Kind == "ignored" {
+			continue
+		}
+		n := 1
+		switch {
+		case e.Kind == "unmerged":
+			sections["unmerged"] = true
+		case e.Kind == "untracked":
+			sections["untracked"] = true
+		default:
+			if e.Staged() {
+				sections["staged"] = true
+			}
+			if e.Unstaged() {
+				sections["unstaged"] = true
+			}
+			if e.Staged() && e.Unstaged() {
+				n = 2
+			}
+		}
+		rows += n
+		width = max(width, stringCells(e.Path)+stringCells(e.OrigPath))
+	}
+	rows += len(sections)
+	// Status line, header and separator, the pane, then the border.
+	h := 2*rows + 3 + 2
+	w := width + 24
+	return min(max(w, 72), cw*9/10), min(max(h, 16), ch*9/10)
+}
+
+// runPopup implements `gits popup [DIR]`: inside tmux, `gits ui` opens in
+// a display-popup sized to the status and closes with it, which makes a
+// quick check one key binding away:
+//
+//	bind g run-shell -b "gits popup '#{pane_current_path}'"
+//
+// Outside tmux, with a tmux too old for popups (before 3.2) or with no
+// client attached, the plain status is printed instead.
+func runPopup(status *Status, args []string) {
+	c := status.cfg.Colors
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	root, err := repoRoot(dir)
+	if err != nil {
+		fail(err)
+	}
+	plain := func() {
+		if !status.ColorizeGitStatus(root, "") {
+			exit(status.exitCode)
+		}
+	}
+	if os.Getenv("TMUX") == "" {
+		plain()
+		return
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		plain()
+		return
+	}
+
+	cw, ch := 200, 50
+	if out, err := exec.Command("tmux", "display-message", "-p", "#{client_width} #{client_height}").Output(); err == nil {
+		if f := strings.Fields(string(out)); len(f) == 2 {
+			if n, err := strconv.Atoi(f[0]); err == nil {
+				cw = n
+			}
+			if n, err := strconv.Atoi(f[1]); err == nil {
+				ch = n
+			}
+		}
+	}
+	rs := CollectStatus(root)
+	if rs.Err != "" {
+		fail(fmt.Errorf("%s", rs.Err))
+	}
+	w, h := popupSize(rs, cw, ch)
+	command := strings.Join([]string{shellQuote(exe), "--backend", statusBackend, "ui", shellQuote(root)}, " ")
+	cmd := exec.Command("tmux", "display-popup", "-E", "-d", root,
+		"-w", strconv.Itoa(w), "-h", strconv.Itoa(h), command)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if debugMode {
+			fmt.Fprintf(os.Stderr, "popup: tmux display-popup: %v %s\n", err, out)
+		}
+		// A tmux without display-popup, or a session no client is
+		// attached to: there is nowhere to draw a popup.
+		if strings.Contains(string(out), "unknown command") || strings.Contains(string(out), "no current client") {
+			plain()
+			return
+		}
+		fail(fmt.Errorf("tmux display-popup: %s", strings.TrimSpace(string(out))))
+	}
+}
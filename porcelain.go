@@ -0,0 +1,229 @@
+// File: porcelain.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: structured status collection via `git status --porcelain=v2`
+// License: MIT
+
+package main
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileEntry is one path reported by `git status --porcelain=v2`.
+type FileEntry struct {
+	Path     string `json:"path"`
+	OrigPath string `json:"orig_path,omitempty"` // rename/copy source
+	Index    string `json:"index"`               // X column ("." = unchanged)
+	Worktree string `json:"worktree"`            // Y column ("." = unchanged)
+	Kind     string `json:"kind"`                // changed, renamed, unmerged, untracked, ignored
+}
+
+// Staged reports whether the entry has changes in the index.
+func (e FileEntry) Staged() bool {
+	return (e.Kind == "changed" || e.Kind == "renamed") && e.Index != "."
+}
+
+// Unstaged reports whether the entry has worktree changes not yet staged.
+func (e FileEntry) Unstaged() bool {
+	return (e.Kind == "changed" || e.Kind == "renamed") && e.Worktree != "."
+}
+
+// RepoStatus is the parsed, render-independent status of one repository.
+type RepoStatus struct {
+	Path      string      `json:"path"`
+	Branch    string      `json:"branch"`
+	Oid       string      `json:"oid,omitempty"`
+	Upstream  string      `json:"upstream,omitempty"`
+	Gone      bool        `json:"upstream_gone,omitempty"` // the upstream branch no longer exists
+	Ahead     int         `json:"ahead"`
+	Behind    int         `json:"behind"`
+	Detached  bool        `json:"detached,omitempty"`
+	Staged    int         `json:"staged"`
+	Modified  int         `json:"modified"`
+	Untracked int         `json:"untracked"`
+	Conflicts int         `json:"conflicts"`
+	Stashes   int         `json:"stashes"`
+	Entries   []FileEntry `json:"entries"`
+	Err       string      `json:"error,omitempty"`
+
+	// UntrackedSkipped is set when --fast or status.showUntrackedFiles=no
+	// left untracked files out, so Untracked is 0 without meaning the
+	// worktree has none.
+	UntrackedSkipped bool `json:"untracked_skipped,omitempty"`
+
+	// Unpushed is only filled in when requested (see collectUnpushed).
+	Unpushed []UnpushedBranch `json:"unpushed_branches,omitempty"`
+
+	// FetchErr is set when `gits scan --fetch` could not fetch the repo.
+	FetchErr string `json:"fetch_error,omitempty"`
+
+	// LastCommit and Stale are only filled in when a staleness threshold
+	// applies (see fillStaleness).
+	LastCommit time.Time `json:"last_commit,omitzero"`
+	Stale      bool      `json:"stale,omitempty"`
+}
+
+// Dirty reports whether the worktree or index has any change.
+func (r *RepoStatus) Dirty() bool {
+	return r.Staged+r.Modified+r.Untracked+r.Conflicts > 0
+}
+
+// NeedsAttention reports whether the repository has uncommitted work,
+// commits not yet pushed to its upstream, or could not be read at all.
+func (r *RepoStatus) NeedsAttention() bool {
+	return r.Err != "" || r.Dirty() || r.Ahead > 0 || len(r.Unpushed) > 0
+}
+
+// CollectStatus is Collector.Status, for the many places that need no
+// more than the status of one repository.
+func CollectStatus(dir string) *RepoStatus {
+	return Collector{}.Status(dir)
+}
+
+// parsePorcelainV2 fills rs from NUL-separated porcelain v2 output.
+func parsePorcelainV2(out string, rs *RepoStatus) {
+	records := strings.Split(out, "\x00")
+	for i := 0; i < len(records); i++ {
+		rec := records[i]
+		if rec == "" {
+			continue
+		}
+		switch rec[0] {
+		case '#':
+			parseBranchHeader(rec, rs)
+		case '1':
+			f := strings.SplitN(rec, " ", 9)
+			if len(f) < 9 {
+				continue
+			}
+			rs.addEntry(FileEntry{Path: f[8], Index: f[1][:1], Worktree: f[1][1:], Kind: "changed"})
+		case '2':
+			f := strings.SplitN(rec, " ", 10)
+			if len(f) < 10 {
+				continue
+			}
+			e := FileEntry{Path: f[9], Index: f[1][:1], Worktree: f[1][1:], Kind: "renamed"}
+			// With -z the original path is the next record.
+			if i+1 < len(records) {
+				i++
+				e.OrigPath = records[i]
+			}
+			rs.addEntry(e)
+		case 'u':
+			f := strings.SplitN(rec, " ", 11)
+			if len(f) < 11 {
+				continue
+			}
+			rs.addEntry(FileEntry{Path: f[10], Index: f[1][:1], Worktree: f[1][1:], Kind: "unmerged"})
+		case '?':
+			rs.addEntry(FileEntry{Path: rec[2:], Index: "?", Worktree: "?", Kind: "untracked"})
+		case '!':
+			rs.addEntry(FileEntry{Path: rec[2:], Index: "!", Worktree: "!", Kind: "ignored"})
+		}
+	}
+}
+
+func parseBranchHeader(rec string, rs *RepoStatus) {
+	f := strings.Fields(rec)
+	if len(f) < 3 {
+		return
+	}
+	switch f[1] {
+	case "branch.oid":
+		if f[2] != "(initial)" {
+			rs.Oid = f[2]
+		}
+	case "branch.head":
+		rs.Branch = f[2]
+		rs.Detached = f[2] == "(detached)"
+	case "branch.upstream":
+		// Without a branch.ab line to follow, it is gone: git leaves
+		// the line out on an unborn branch too.
+		rs.Upstream, rs.Gone = f[2], rs.Oid != ""
+	case "branch.ab":
+		rs.Gone = false
+		if len(f) >= 4 {
+			rs.Ahead, _ = strconv.Atoi(strings.TrimPrefix(f[2], "+"))
+			rs.Behind, _ = strconv.Atoi(strings.TrimPrefix(f[3], "-"))
+		}
+	case "stash":
+		rs.Stashes, _ = strconv.Atoi(f[2])
+	}
+}
+
+func (rs *RepoStatus) addEntry(e FileEntry) {
+	switch {
+	case e.Kind == "untracked":
+		rs.Untracked++
+	case e.Kind == "unmerged":
+		rs.Conflicts++
+	case e.Kind == "ignored":
+	default:
+		if e.Staged() {
+			rs.Staged++
+		}
+		if e.Unstaged() {
+			rs.Modified++
+		}
+	}
+	rs.Entries = append(rs.Entries, e)
+}
+
+// parallelEach calls fn(i) for every i in [0, n) using a bounded pool of
+// goroutines (one per CPU).
+func parallelEach(n int, fn func(i int)) {
+	parallelEachN(n, runtime.NumCPU(), fn)
+}
+
+// parallelEachN is parallelEach with an explicit worker count, for
+// network-bound work where more concurrency than CPUs pays off.
+func parallelEachN(n, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// collectAll gathers the status of every repo concurrently, from the status
+// cache where the repository has not changed.  Results keep the order of
+// repos.
+func collectAll(repos []string) []*RepoStatus {
+	results := make([]*RepoStatus, len(repos))
+	if statusCacheTTL <= 0 {
+		var col Collector
+		parallelEach(len(repos), func(i int) {
+			results[i] = col.Status(repos[i])
+		})
+		return results
+	}
+	sc := loadStatusCache()
+	parallelEach(len(repos), func(i int) {
+		results[i] = sc.status(repos[i])
+	})
+	sc.save()
+	return results
+}
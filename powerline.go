@@ -0,0 +1,118 @@
+// File: powerline.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: powerline-style segments for `gits prompt --powerline`
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Powerline glyphs; they need a patched (Nerd or Powerline) font.
+const (
+	powerlineSep    = ""
+	powerlineBranch = ""
+)
+
+// powerlineDefault is the background of a segment whose color is unset.
+const powerlineDefault = "#444444"
+
+// powerlineSegment is one colored block of the prompt.
+type powerlineSegment struct {
+	text string
+	bg   string // hex
+}
+
+// powerlineSegments picks the segments of rs: the branch, then only the
+// counts that are not zero, each on the background of its [colors] entry.
+func powerlineSegments(rs *RepoStatus, c ColorConfig) []powerlineSegment {
+	segs := []powerlineSegment{{powerlineBranch + " " + rs.Branch, c.Branch}}
+	ab := ""
+	if rs.Ahead > 0 {
+		ab += "↑" + strconv.Itoa(rs.Ahead)
+	}
+	if rs.Behind > 0 {
+		ab += "↓" + strconv.Itoa(rs.Behind)
+	}
+	if ab != "" {
+		segs = append(segs, powerlineSegment{ab, c.AheadBehind})
+	}
+	for _, s := range []struct {
+		sign string
+		n    int
+		bg   string
+	}{
+		{"+", rs.Staged, c.Staged},
+		{"~", rs.Modified, c.Modified},
+		{"?", rs.Untracked, c.Untracked},
+		{"!", rs.Conflicts, c.Deleted},
+		{"$", rs.Stashes, c.Hint},
+	} {
+		if s.n > 0 {
+			segs = append(segs, powerlineSegment{s.sign + strconv.Itoa(s.n), s.bg})
+		}
+	}
+	return segs
+}
+
+// renderPowerline draws segs as powerline blocks: dark or light text,
+// whichever reads better on each background, and an arrow in the color of
+// one block over the background of the next.  shell wraps the escape
+// sequences so bash ("bash") or zsh ("zsh") does not count them towards
+// the prompt's width, and escapes what that shell would expand in the text.
+func renderPowerline(segs []powerlineSegment, shell string) string {
+	esc := func(seq string) string {
+		switch shell {
+		case "bash":
+			return `\[` + seq + `\]`
+		case "zsh":
+			return "%{" + seq + "%}"
+		}
+		return seq
+	}
+	quote := func(text string) string {
+		switch shell {
+		case "bash":
+			return strings.NewReplacer(`\`, `\\`, "$", `\\$`, "`", "\\`").Replace(text)
+		case "zsh":
+			return strings.ReplaceAll(text, "%", "%%")
+		}
+		return text
+	}
+	type rgb struct{ r, g, b int64 }
+	colors := make([]rgb, len(segs))
+	for i, s := range segs {
+		r, g, b, ok := hexRGB(s.bg)
+		if !ok {
+			r, g, b, _ = hexRGB(powerlineDefault)
+		}
+		colors[i] = rgb{r, g, b}
+	}
+
+	var sb strings.Builder
+	for i, s := range segs {
+		bg := colors[i]
+		fg := "\033[38;2;16;16;16m"
+		// Perceived brightness (ITU-R BT.601); light text on dark blocks.
+		if (299*bg.r+587*bg.g+114*bg.b)/1000 < 128 {
+			fg = "\033[38;2;240;240;240m"
+		}
+		sb.WriteString(esc(fmt.Sprintf("\033[48;2;%d;%d;%dm", bg.r, bg.g, bg.b) + fg))
+		sb.WriteString(" " + quote(s.text) + " ")
+		arrow := fmt.Sprintf("\033[38;2;%d;%d;%dm", bg.r, bg.g, bg.b)
+		if i+1 < len(segs) {
+			next := colors[i+1]
+			arrow += fmt.Sprintf("\033[48;2;%d;%d;%dm", next.r, next.g, next.b)
+		} else {
+			arrow = "\033[49m" + arrow
+		}
+		sb.WriteString(esc(arrow))
+		sb.WriteString(powerlineSep)
+	}
+	sb.WriteString(esc(Reset))
+	return sb.String()
+}
@@ -0,0 +1,47 @@
+// File: procgroup.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: stopping git and the helpers it spawned (Unix)
+// License: MIT
+
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+)
+
+// controllingTerminal reports whether gits runs with a terminal, whose
+// Ctrl+C already reaches every process of the foreground group.
+var controllingTerminal = sync.OnceValue(func() bool {
+	f, err := os.Open("/dev/tty")
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+})
+
+// gitProcessGroup gives git a process group of its own when there is no
+// terminal (cron, systemd, `gits daemon`), so stopping it also stops the
+// ssh and remote helpers it started.  On a terminal git stays in gits'
+// group: it may need to prompt for credentials there, which a background
+// group cannot.
+func gitProcessGroup(cmd *exec.Cmd) {
+	if !controllingTerminal() {
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	}
+}
+
+// stopGit sends SIGTERM to git, or to its whole process group when it
+// leads one; git removes its lock files and stops its own children.
+func stopGit(cmd *exec.Cmd) error {
+	if cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	}
+	return cmd.Process.Signal(syscall.SIGTERM)
+}
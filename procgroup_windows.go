@@ -0,0 +1,18 @@
+// File: procgroup_windows.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: stopping git and the helpers it spawned (Windows)
+// License: MIT
+
+package main
+
+import "os/exec"
+
+// gitProcessGroup leaves git in the console's process group, which Ctrl+C
+// reaches as a whole.
+func gitProcessGroup(cmd *exec.Cmd) {}
+
+// stopGit terminates git; Windows has no SIGTERM to send.
+func stopGit(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
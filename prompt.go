@@ -0,0 +1,229 @@
+// File: prompt.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: `gits prompt`: a one-line status for shell prompts, optionally served from a warm cache
+// License: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// promptFresh is how old a cached prompt may be before --async starts a
+// refresh; prompts drawn in quick succession share one.
+const promptFresh = 2 * time.Second
+
+// promptLockStale is when a refresh lock is taken to belong to a refresh
+// that died.
+const promptLockStale = time.Minute
+
+// promptCache is what `gits prompt` keeps in the git directory of each
+// repository: the last status read, without its entries.
+type promptCache struct {
+	Time   time.Time  `json:"time"`
+	Status RepoStatus `json:"status"`
+}
+
+func promptCachePath(gitDir string) string {
+	return filepath.Join(gitDir, "gits-prompt.json")
+}
+
+func readPromptCache(gitDir string) (*promptCache, error) {
+	data, err := os.ReadFile(promptCachePath(gitDir))
+	if err != nil {
+		return nil, err
+	}
+	var pc promptCache
+	if err := json.Unmarshal(data, &pc); err != nil {
+		return nil, err
+	}
+	return &pc, nil
+}
+
+// refreshPromptCache reads the status of root and stores it for the next
+// prompt.  The file is replaced atomically so a prompt never reads half.
+func refreshPromptCache(root, gitDir string) (*promptCache, error) {
+	rs := CollectStatus(root)
+	if rs.Err != "" {
+		return nil, errors.New(rs.Err)
+	}
+	rs.Entries = nil
+	pc := &promptCache{Time: time.Now(), Status: *rs}
+	data, err := json.Marshal(pc)
+	if err != nil {
+		return pc, err
+	}
+	return pc, writeFileAtomic(promptCachePath(gitDir), data, 0o644)
+}
+
+// enclosingRepo walks up from dir to the worktree holding it without running
+// git, which is what a prompt on a slow mount cannot afford.
+func enclosingRepo(dir string) (root, gitDir string, ok bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", false
+	}
+	for {
+		if Exists(filepath.Join(dir, ".git")) {
+			if gitDir, _, ok := locateGitDir(dir); ok {
+				return dir, gitDir, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", false
+		}
+		dir = parent
+	}
+}
+
+// headBranch is the branch HEAD names, or its short hash when detached,
+// read straight from the git directory.
+func headBranch(gitDir string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	head := strings.TrimSpace(string(data))
+	if ref, ok := strings.CutPrefix(head, "ref: "); ok {
+		return strings.TrimPrefix(ref, "refs/heads/")
+	}
+	if len(head) > 7 {
+		head = head[:7]
+	}
+	return "(" + head + ")"
+}
+
+// promptSummary renders rs the way a prompt shows it: the branch, then only
+// the counts that are not zero.
+func promptSummary(rs *RepoStatus) string {
+	parts := []string{rs.Branch}
+	add := func(sign string, n int) {
+		if n > 0 {
+			parts = append(parts, sign+strconv.Itoa(n))
+		}
+	}
+	add("↑", rs.Ahead)
+	add("↓", rs.Behind)
+	add("+", rs.Staged)
+	add("~", rs.Modified)
+	add("?", rs.Untracked)
+	add("!", rs.Conflicts)
+	add("$", rs.Stashes)
+	return strings.Join(parts, " ")
+}
+
+// startPromptRefresh starts `gits prompt --refresh` for root in a process
+// of its own that outlives the prompt, unless one is already running.
+func startPromptRefresh(root, gitDir string) {
+	lock := filepath.Join(gitDir, "gits-prompt.lock")
+	if info, err := os.Stat(lock); err == nil && time.Since(info.ModTime()) < promptLockStale {
+		return
+	}
+	os.Remove(lock)
+	f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	f.Close()
+
+	exe, err := os.Executable()
+	if err != nil {
+		os.Remove(lock)
+		return
+	}
+	args := []string{"--backend", statusBackend}
+	if fastStatus {
+		args = append(args, "--fast")
+	}
+	cmd := exec.Command(exe, append(args, "prompt", "--refresh", root)...)
+	cmd.Dir = root
+	detach(cmd)
+	if err := cmd.Start(); err != nil {
+		os.Remove(lock)
+		return
+	}
+	cmd.Process.Release()
+}
+
+// runPrompt implements `gits prompt [--async] [--powerline [--shell SH]]
+// [DIR]`.  Without --async it reads the status and prints it.  With --async it prints the last cached
+// line at once and leaves a detached process to bring the cache up to date
+// for the next prompt.  Outside a repository it prints nothing.
+func runPrompt(status *Status, args []string) {
+	async, refresh, powerline := false, false, false
+	dir, shell := ".", ""
+	for i := 0; i < len(args); i++ {
+		switch a := args[i]; a {
+		case "--async":
+			async = true
+		case "--refresh":
+			refresh = true
+		case "--powerline":
+			powerline = true
+		case "--shell":
+			if i+1 < len(args) {
+				i++
+				shell = args[i]
+			}
+		default:
+			dir = a
+		}
+	}
+	show := func(rs *RepoStatus) {
+		if powerline {
+			fmt.Println(renderPowerline(powerlineSegments(rs, status.cfg.Colors), shell))
+		} else {
+			fmt.Println(promptSummary(rs))
+		}
+	}
+	root, gitDir, ok := enclosingRepo(dir)
+	if !ok {
+		return
+	}
+
+	if refresh {
+		// The refresh runs while the user works in the repository, so it
+		// must not hold index.lock and make their own git commands fail.
+		os.Setenv("GIT_OPTIONAL_LOCKS", "0")
+		defer os.Remove(filepath.Join(gitDir, "gits-prompt.lock"))
+		if _, err := refreshPromptCache(root, gitDir); err != nil && debugMode {
+			fmt.Fprintf(os.Stderr, "prompt refresh: %v\n", err)
+		}
+		return
+	}
+
+	if !async {
+		pc, err := refreshPromptCache(root, gitDir)
+		if pc == nil {
+			if debugMode {
+				fmt.Fprintf(os.Stderr, "prompt: %v\n", err)
+			}
+			return
+		}
+		show(&pc.Status)
+		return
+	}
+
+	pc, err := readPromptCache(gitDir)
+	switch {
+	case err == nil:
+		show(&pc.Status)
+	case errors.Is(err, fs.ErrNotExist):
+		// Nothing cached yet: the branch is all that can be had for free.
+		show(&RepoStatus{Branch: headBranch(gitDir)})
+	}
+	if pc == nil || time.Since(pc.Time) > promptFresh {
+		startPromptRefresh(root, gitDir)
+	}
+}
@@ -0,0 +1,125 @@
+// File: pull.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: pull with a post-pull summary (`gits pull`)
+// License: MIT
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// pullSummaryCommits is how many received commits are listed individually.
+const pullSummaryCommits = 10
+
+// printConflicts lists unmerged paths of rs with a hint on how to continue.
+func printConflicts(rs *RepoStatus, c ColorConfig, hint string) {
+	if rs.Conflicts == 0 {
+		return
+	}
+	fmt.Printf("%s %s%d conflict(s) to resolve:%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), rs.Conflicts, Reset)
+	for _, e := range rs.Entries {
+		if e.Kind == "unmerged" {
+			fmt.Printf("    %s%s%s %s\n", Bold+resolveColor(c.Deleted), e.Index+e.Worktree, Reset, e.Path)
+		}
+	}
+	fmt.Printf("    %s%s%s\n", Dim, hint, Reset)
+}
+
+// runPull implements `gits pull [--rebase] [git pull args...]`.
+func runPull(status *Status, args []string) {
+	c := status.cfg.Colors
+	rebase := false
+	for _, a := range args {
+		if a == "--rebase" || a == "-r" || strings.HasPrefix(a, "--rebase=") {
+			rebase = true
+		}
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		exitWithError(err, c)
+	}
+	before, _ := gitOutput(root, "rev-parse", "--verify", "--quiet", "HEAD")
+
+	cmd := gitCmd(root, append([]string{"pull", "--progress"}, args...)...)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	stderr, err := cmd.StderrPipe()
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		exitWithError(err, c)
+	}
+	streamGitProgress(stderr, os.Stderr, c)
+	pullErr := cmd.Wait()
+
+	rs := CollectStatus(root)
+	if pullErr != nil {
+		if rs.Conflicts > 0 {
+			hint := "fix the files, `git add` them, then `git commit` (or `git merge --abort`)"
+			if rebase {
+				hint = "fix the files, `git add` them, then `git rebase --continue` (or `git rebase --abort`)"
+			}
+			printConflicts(rs, c, hint)
+		} else if out := strings.TrimSpace(stdout.String()); out != "" {
+			fmt.Println(out)
+		}
+		fmt.Printf("%s %sPull failed%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), Reset)
+		exit(1)
+	}
+
+	after, _ := gitOutput(root, "rev-parse", "--verify", "--quiet", "HEAD")
+	if before == after {
+		fmt.Printf("%s %sAlready up to date%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+		return
+	}
+
+	upstream, _ := gitOutput(root, "rev-parse", "--abbrev-ref", "@{u}")
+	received := ""
+	if before != "" && upstream != "" {
+		received, _ = gitOutput(root, "log", "--format=%h%x00%an%x00%s", before+"..@{u}")
+	} else if before == "" {
+		received, _ = gitOutput(root, "log", "--format=%h%x00%an%x00%s", "HEAD")
+	}
+	var commits []string
+	if received != "" {
+		commits = strings.Split(received, "\n")
+	}
+
+	from := upstream
+	if from == "" {
+		from = "remote"
+	}
+	fmt.Printf("%s %sReceived %d commit(s)%s from %s%s%s\n", Icons.SUCCESS, Bold+resolveColor(c.UpToDate),
+		len(commits), Reset, Bold+resolveColor(c.Branch), from, Reset)
+	palette := lanePalette(c)
+	for i, line := range commits {
+		if i == pullSummaryCommits {
+			fmt.Printf("    %s… and %d more%s\n", Dim, len(commits)-i, Reset)
+			break
+		}
+		f := strings.SplitN(line, "\x00", 3)
+		if len(f) < 3 {
+			continue
+		}
+		fmt.Printf("    %s%s%s %s%s%s %s\n", Bold+resolveColor(c.AheadBehind), f[0], Reset,
+			authorColor(f[1], palette), f[1], Reset, f[2])
+	}
+
+	if before != "" {
+		if numstat, err := gitOutput(root, "diff", "--numstat", before, after); err == nil && numstat != "" {
+			fmt.Printf("%s %sFiles changed%s\n", Icons.INFO, Bold+resolveColor(c.Header), Reset)
+			fmt.Print(renderNumstat(numstat, c, "    "))
+		}
+	}
+	if rs.Ahead > 0 {
+		fmt.Printf("%s %sYour branch is %d commit(s) ahead of %s%s %s(gits push)%s\n",
+			Icons.WARNING, resolveColor(c.AheadBehind), rs.Ahead, from, Reset, Dim, Reset)
+	}
+}
@@ -0,0 +1,276 @@
+// File: push.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: push with pre-flight checks and a colored summary (`gits push`)
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// protectedBranchPrefixes are branch names (or name prefixes ending in /)
+// that `gits push` refuses to force-push without confirmation.
+var protectedBranchPrefixes = []string{"main", "master", "develop", "trunk", "release/", "production"}
+
+// isProtectedPushTarget reports whether a force push to branch deserves a warning.
+func isProtectedPushTarget(branch string) bool {
+	for _, p := range protectedBranchPrefixes {
+		if branch == p || (strings.HasSuffix(p, "/") && strings.HasPrefix(branch, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// streamGitProgress copies git's stderr (progress meters and messages) to
+// w with colors, keeping carriage returns so meters update in place.  The
+// meters also go to the taskbar, cleared once git closes its stderr.
+func streamGitProgress(r io.Reader, w io.Writer, c ColorConfig) {
+	tp := newTermProgress()
+	defer tp.done()
+	br := bufio.NewReader(r)
+	var line []byte
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			if len(line) > 0 {
+				fmt.Fprint(w, colorProgressLine(string(line), c)+"\n")
+			}
+			return
+		}
+		if b == '\r' || b == '\n' {
+			if m := progressPhase.FindStringSubmatch(string(line)); m != nil {
+				pct, _ := strconv.Atoi(m[2])
+				tp.set(pct)
+			}
+			fmt.Fprint(w, colorProgressLine(string(line), c)+string(b))
+			line = line[:0]
+			continue
+		}
+		line = append(line, b)
+	}
+}
+
+// colorProgressLine colors one line of git's progress output.
+func colorProgressLine(line string, c ColorConfig) string {
+	switch {
+	case line == "":
+		return ""
+	case strings.HasPrefix(line, "remote: "):
+		return Dim + "remote: " + Reset + line[len("remote: "):]
+	case strings.HasPrefix(line, "To ") || strings.HasPrefix(line, "From "):
+		verb, url, _ := strings.Cut(line, " ")
+		return Dim + verb + Reset + " " + resolveColor(c.RemoteURL) + url + Reset
+	case strings.HasPrefix(line, "error:"), strings.HasPrefix(line, "fatal:"),
+		strings.Contains(line, "[rejected]"), strings.Contains(line, "[remote rejected]"):
+		return Bold + resolveColor(c.Deleted) + line + Reset
+	case strings.HasPrefix(line, "hint:"):
+		return Dim + line + Reset
+	case strings.Contains(line, "%"):
+		return resolveColor(c.Hint) + line + Reset
+	}
+	return line
+}
+
+// pushRemoteFor returns the remote a new upstream for branch should use.
+func pushRemoteFor(dir, branch string) string {
+	for _, key := range []string{"branch." + branch + ".pushRemote", "remote.pushDefault"} {
+		if r, err := gitOutput(dir, "config", "--get", key); err == nil && r != "" {
+			return r
+		}
+	}
+	remotes, _ := gitOutput(dir, "remote")
+	list := strings.Fields(remotes)
+	for _, r := range list {
+		if r == "origin" {
+			return r
+		}
+	}
+	if len(list) > 0 {
+		return list[0]
+	}
+	return ""
+}
+
+// printPushSummary renders the ref lines of `git push --porcelain`.
+func printPushSummary(dir, porcelain string, c ColorConfig) {
+	for _, line := range strings.Split(porcelain, "\n") {
+		if strings.HasPrefix(line, "To ") {
+			fmt.Printf("%s %s%s%s\n", Icons.REMOTE, resolveColor(c.RemoteURL), line[3:], Reset)
+			continue
+		}
+		f := strings.Split(line, "\t")
+		if len(f) < 3 || len(f[0]) != 1 {
+			continue
+		}
+		flag, refs, summary := f[0], f[1], f[2]
+		_, dst, _ := strings.Cut(refs, ":")
+		dst = strings.TrimPrefix(strings.TrimPrefix(dst, "refs/heads/"), "refs/tags/")
+
+		icon, style, what := Icons.SUCCESS, resolveColor(c.UpToDate), summary
+		switch flag {
+		case " ", "+":
+			rangeSpec := strings.Fields(summary)[0]
+			count := ""
+			if n, err := gitOutput(dir, "rev-list", "--count", strings.Replace(rangeSpec, "...", "..", 1)); err == nil {
+				count = n + " commit(s) "
+			}
+			what = count + rangeSpec
+			if flag == "+" {
+				icon, style, what = Icons.WARNING, Bold+resolveColor(c.AheadBehind), what+" (forced)"
+			}
+		case "*":
+			what = strings.Trim(summary, "[]")
+		case "-":
+			icon, style = Icons.WARNING, Bold+resolveColor(c.Deleted)
+		case "=":
+			what = "up to date"
+			style = Dim
+		case "!":
+			icon, style = Icons.ERROR, Bold+resolveColor(c.Deleted)
+		}
+		fmt.Printf("   %s %s%s%s  %s%s%s\n", icon, Bold+resolveColor(c.Branch), dst, Reset, style, what, Reset)
+	}
+}
+
+// runPush implements `gits push [-u|--set-upstream] [--force|--force-with-lease]
+// [--yes] [remote [refspec...]]`.
+func runPush(status *Status, args []string) {
+	c := status.cfg.Colors
+	yes, setUpstream, force := false, false, false
+	var gitArgs, positional []string
+	for _, a := range args {
+		switch {
+		case a == "--yes" || a == "-y":
+			yes = true
+			continue
+		case a == "-u" || a == "--set-upstream":
+			setUpstream = true
+		case a == "-f" || a == "--force" || strings.HasPrefix(a, "--force-with-lease") || a == "--force-if-includes":
+			force = true
+		case !strings.HasPrefix(a, "-"):
+			positional = append(positional, a)
+		}
+		gitArgs = append(gitArgs, a)
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		exitWithError(err, c)
+	}
+	branch, _ := gitOutput(root, "symbolic-ref", "--quiet", "--short", "HEAD")
+	ask := func(question string) bool {
+		if yes {
+			return true
+		}
+		ok, err := confirm(question)
+		if err != nil {
+			fmt.Printf("%s %s%v (use --yes)%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), err, Reset)
+			exit(1)
+		}
+		return ok
+	}
+
+	// Pre-flight: with no explicit remote, the current branch needs an upstream.
+	if len(positional) == 0 {
+		if branch == "" {
+			fmt.Printf("%s %sHEAD is detached — name a remote and refspec to push%s\n",
+				Icons.ERROR, Bold+resolveColor(c.Deleted), Reset)
+			exit(1)
+		}
+		if _, err := gitOutput(root, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"); err != nil {
+			remote := pushRemoteFor(root, branch)
+			if remote == "" {
+				fmt.Printf("%s %sNo remote configured — add one with `git remote add origin URL`%s\n",
+					Icons.ERROR, Bold+resolveColor(c.Deleted), Reset)
+				exit(1)
+			}
+			if !setUpstream {
+				fmt.Printf("%s Branch %s%s%s has no upstream\n", Icons.WARNING, Bold+resolveColor(c.Branch), branch, Reset)
+				if !ask(fmt.Sprintf("Push and set upstream to %s/%s?", remote, branch)) {
+					return
+				}
+				gitArgs = append(gitArgs, "--set-upstream")
+			}
+			gitArgs = append(gitArgs, remote, branch)
+		}
+	}
+
+	if force {
+		targets := positional
+		if len(targets) <= 1 {
+			targets = []string{branch}
+		} else {
+			targets = targets[1:]
+		}
+		for _, t := range targets {
+			_, dst, found := strings.Cut(strings.TrimPrefix(t, "+"), ":")
+			if !found {
+				dst = strings.TrimPrefix(t, "+")
+			}
+			dst = strings.TrimPrefix(dst, "refs/heads/")
+			if isProtectedPushTarget(dst) {
+				fmt.Printf("%s %sForce-pushing to protected branch %s rewrites shared history%s\n",
+					Icons.WARNING, Bold+resolveColor(c.Deleted), dst, Reset)
+				if !ask("Force-push anyway?") {
+					return
+				}
+			}
+		}
+	}
+
+	// The .gitignore profile follows the remote being pushed to.
+	remote := ""
+	if len(positional) > 0 {
+		remote = positional[0]
+	} else if remote, _ = gitOutput(root, "config", "--get", "branch."+branch+".remote"); remote == "" {
+		remote = pushRemoteFor(root, branch)
+	}
+	// A configured profile for that remote is swapped in for the push (so
+	// pre-push hooks see it) and the original put back afterward.
+	ignores, _ := NewGitIgnoreManager(root)
+	if ignores != nil {
+		if err := ignores.CheckGitignore(remote); err != nil {
+			fmt.Printf("%s %s.gitignore backup failed: %v%s\n", Icons.WARNING, resolveColor(c.AheadBehind), err, Reset)
+		} else if p, ok := status.cfg.Ignore.Profiles[remote]; ok {
+			if swapped, err := ignores.ApplyProfile(remote, p); err != nil {
+				ignores.RestoreGitignore(remote)
+				exitWithError(err, c)
+			} else if swapped {
+				fmt.Printf("🙈 %sUsing the %s .gitignore profile for this push%s\n", Dim, remote, Reset)
+			}
+		}
+	}
+
+	cmd := gitCmd(root, append([]string{"push", "--progress", "--porcelain"}, gitArgs...)...)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		exitWithError(err, c)
+	}
+	if err := cmd.Start(); err != nil {
+		exitWithError(err, c)
+	}
+	streamGitProgress(stderr, os.Stderr, c)
+	err = cmd.Wait()
+
+	printPushSummary(root, stdout.String(), c)
+	if ignores != nil {
+		if err := ignores.RestoreGitignore(remote); err != nil {
+			fmt.Printf("%s %s.gitignore restore failed: %v%s\n", Icons.WARNING, resolveColor(c.AheadBehind), err, Reset)
+		}
+	}
+	if err != nil {
+		fmt.Printf("%s %sPush failed%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), Reset)
+		exit(1)
+	}
+}
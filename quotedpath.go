@@ -0,0 +1,149 @@
+// File: quotedpath.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: paths with spaces, quotes, dashes and control characters, read from git and passed on
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// unquoteGitPath undoes the C-style quoting git gives a path with special
+// characters in its readable output: "tab\tx.txt", "\303\274.txt".  It
+// reads what git writes byte by byte, as strconv.Unquote would not: bytes
+// that are not UTF-8 stay as they are.  Any other path, or one quoted in a
+// way git does not, comes back as it is.
+func unquoteGitPath(p string) string {
+	if len(p) < 2 || p[0] != '"' || p[len(p)-1] != '"' {
+		return p
+	}
+	var sb strings.Builder
+	for i := 1; i < len(p)-1; i++ {
+		b := p[i]
+		if b == '"' {
+			return p
+		}
+		if b != '\\' {
+			sb.WriteByte(b)
+			continue
+		}
+		if i++; i == len(p)-1 {
+			return p
+		}
+		if c, ok := gitUnescapes[p[i]]; ok {
+			sb.WriteByte(c)
+			continue
+		}
+		// \ooo, at most \377.
+		if i+2 >= len(p)-1 || p[i] < '0' || p[i] > '3' || !isOctal(p[i+1]) || !isOctal(p[i+2]) {
+			return p
+		}
+		sb.WriteByte((p[i]-'0')<<6 | (p[i+1]-'0')<<3 | (p[i+2] - '0'))
+		i += 2
+	}
+	return sb.String()
+}
+
+func isOctal(b byte) bool { return b >= '0' && b <= '7' }
+
+// quotedPrefix returns the length of the quoted string s starts with, or 0
+// when it does not start with one.
+func quotedPrefix(s string) int {
+	if !strings.HasPrefix(s, `"`) {
+		return 0
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// splitRename splits the "from -> to" of a rename in the long status.  A
+// quoted side is skipped as a whole, so an arrow inside a name does not
+// count.
+func splitRename(s string) (from, to string, ok bool) {
+	if n := quotedPrefix(s); n > 0 {
+		if rest, found := strings.CutPrefix(s[n:], " -> "); found {
+			return unquoteGitPath(s[:n]), unquoteGitPath(rest), true
+		}
+		return "", "", false
+	}
+	from, to, ok = strings.Cut(s, " -> ")
+	return unquoteGitPath(from), unquoteGitPath(to), ok
+}
+
+// visiblePath is p as it can be printed on a terminal: control characters,
+// which would break the line or be taken as escape sequences, are shown
+// escaped.
+func visiblePath(p string) string {
+	if !strings.ContainsFunc(p, unicode.IsControl) {
+		return p
+	}
+	var sb strings.Builder
+	for _, r := range p {
+		if unicode.IsControl(r) {
+			q := strconv.QuoteRune(r)
+			sb.WriteString(q[1 : len(q)-1])
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// gitEscapes are the C escapes git writes, and reads back, in a quoted
+// path.
+var gitEscapes = map[byte]string{'\a': `\a`, '\b': `\b`, '\f': `\f`, '\n': `\n`, '\r': `\r`, '\t': `\t`, '\v': `\v`, '"': `\"`, '\\': `\\`}
+
+// gitUnescapes maps the letter after the backslash of each of gitEscapes
+// back to its byte.
+var gitUnescapes = func() map[byte]byte {
+	m := map[byte]byte{}
+	for b, e := range gitEscapes {
+		m[e[1]] = b
+	}
+	return m
+}()
+
+// quoteGitPath quotes p the way git does with core.quotePath off, for
+// output with a path per line: only a path with a control character, a
+// double quote or a backslash is quoted, so every other one reads as is.
+func quoteGitPath(p string) string {
+	if !strings.ContainsFunc(p, func(r rune) bool { return r < 0x20 || r == 0x7f || r == '"' || r == '\\' }) {
+		return p
+	}
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(p); i++ {
+		b := p[i]
+		switch {
+		case gitEscapes[b] != "":
+			sb.WriteString(gitEscapes[b])
+		case b < 0x20 || b == 0x7f:
+			fmt.Fprintf(&sb, `\%03o`, b)
+		default:
+			sb.WriteByte(b)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// argPath keeps a relative path that starts with a dash from being taken
+// for an option when it is passed to a command.
+func argPath(p string) string {
+	if strings.HasPrefix(p, "-") {
+		return "./" + p
+	}
+	return p
+}
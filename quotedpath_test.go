@@ -0,0 +1,182 @@
+// File: quotedpath_test.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: awkward file names read back unchanged from git, both backends and the long status
+// License: MIT
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// pathCorpus are file names that trip up path handling: spaces at either
+// end, quotes, a leading dash, shell and glob characters, control
+// characters and an arrow like the one in a rename.  windows marks the
+// ones Windows allows.
+var pathCorpus = []struct {
+	name    string
+	windows bool
+}{
+	{"with space.txt", true},
+	{" lead.txt", true},
+	{"trail .txt", false},
+	{`"quoted".txt`, false},
+	{"it's.txt", true},
+	{"-dash.txt", true},
+	{"--double.txt", true},
+	{`back\slash.txt`, false},
+	{"tab\tx.txt", false},
+	{"new\nline.txt", false},
+	{"esc\x1b[31m.txt", false},
+	{"ü-ñ.txt", true},
+	{"日本.txt", true},
+	{"semi;colon.txt", true},
+	{"$dollar.txt", true},
+	{"*star?.txt", false},
+	{"arrow -> x.txt", true},
+	{"dir with space/in side.txt", true},
+}
+
+func TestQuoteGitPath(t *testing.T) {
+	for _, tc := range []struct{ path, quoted string }{
+		{"plain.txt", "plain.txt"},
+		{"with space.txt", "with space.txt"},
+		{"ü.txt", "ü.txt"},
+		{"tab\tx.txt", `"tab\tx.txt"`},
+		{"new\nline.txt", `"new\nline.txt"`},
+		{`"quoted".txt`, `"\"quoted\".txt"`},
+		{`back\slash.txt`, `"back\\slash.txt"`},
+		{"esc\x1b.txt", `"esc\033.txt"`},
+	} {
+		if got := quoteGitPath(tc.path); got != tc.quoted {
+			t.Errorf("quoteGitPath(%q) = %s, want %s", tc.path, got, tc.quoted)
+		}
+		if got := unquoteGitPath(tc.quoted); got != tc.path {
+			t.Errorf("unquoteGitPath(%s) = %q, want %q", tc.quoted, got, tc.path)
+		}
+	}
+}
+
+func TestUnquoteGitPath(t *testing.T) {
+	for _, tc := range []struct{ quoted, path string }{
+		{`"\303\274.txt"`, "ü.txt"},
+		{"\"\xff\\tx\"", "\xff\tx"},
+		{`"a\\b\"c"`, `a\b"c`},
+		{`"bell\a"`, "bell\a"},
+		{`"\400"`, `"\400"`},
+		{`"\x41"`, `"\x41"`},
+		{`"trailing\"`, `"trailing\"`},
+		{`"a"b"`, `"a"b"`},
+		{`plain "quoted".txt`, `plain "quoted".txt`},
+	} {
+		if got := unquoteGitPath(tc.quoted); got != tc.path {
+			t.Errorf("unquoteGitPath(%q) = %q, want %q", tc.quoted, got, tc.path)
+		}
+	}
+}
+
+func TestSplitRename(t *testing.T) {
+	for _, tc := range []struct{ line, from, to string }{
+		{"a.txt -> b.txt", "a.txt", "b.txt"},
+		{`"arrow -> x.txt" -> y.txt`, "arrow -> x.txt", "y.txt"},
+		{`"tab\tx.txt" -> "new\nline.txt"`, "tab\tx.txt", "new\nline.txt"},
+	} {
+		from, to, ok := splitRename(tc.line)
+		if !ok || from != tc.from || to != tc.to {
+			t.Errorf("splitRename(%s) = %q, %q, %v; want %q, %q", tc.line, from, to, ok, tc.from, tc.to)
+		}
+	}
+}
+
+// TestPathCorpus creates a repository whose files carry the names of
+// pathCorpus, modifies them and adds an untracked twin of each, then
+// checks that every name comes back unchanged: from both backends, from
+// the long status gits renders, and through the quoting of `gits files`.
+func TestPathCorpus(t *testing.T) {
+	dir := testRepo(t, benchRepoSpec{})
+	write := func(name, text string) {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var corpus []string
+	for _, tc := range pathCorpus {
+		if runtime.GOOS != "windows" || tc.windows {
+			corpus = append(corpus, tc.name)
+			write(tc.name, "one\n")
+		}
+	}
+	for _, args := range [][]string{{"add", "--all"}, {"-c", "user.name=gits", "-c", "user.email=gits@localhost", "commit", "--quiet", "-m", "corpus"}} {
+		if _, err := gitOutput(dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := map[string]string{}
+	for _, name := range corpus {
+		want[name] = "changed"
+		write(name, "two\n")
+		if !strings.Contains(name, "/") {
+			want["u"+name] = "untracked"
+			write("u"+name, "new\n")
+		}
+	}
+
+	cli := cliStatus(dir)
+	if cli.Err != "" {
+		t.Fatal(cli.Err)
+	}
+	native, nativeErr := nativeStatus(dir)
+	if nativeErr != nil && !strings.Contains(nativeErr.Error(), "control character") {
+		t.Fatal(nativeErr)
+	}
+	rep := Collector{}.Report(dir)
+	if rep.Err != nil {
+		t.Fatal(rep.Err)
+	}
+	kinds := func(rs *RepoStatus) map[string]string {
+		m := map[string]string{}
+		for _, e := range rs.Entries {
+			m[e.Path] = e.Kind
+		}
+		return m
+	}
+	fromCLI := kinds(cli)
+	fromNative := map[string]string{}
+	if nativeErr == nil {
+		fromNative = kinds(native)
+	}
+	listed := map[string]bool{}
+	for _, l := range rep.Lines {
+		if l.Path != "" {
+			listed[l.Path] = true
+		}
+	}
+
+	for name, kind := range want {
+		t.Run(name, func(t *testing.T) {
+			if fromCLI[name] != kind {
+				t.Errorf("cli backend: %q is %q, not %s", name, fromCLI[name], kind)
+			}
+			// go-git refuses names with control characters; gits reads
+			// such repositories with git instead.
+			if nativeErr == nil && fromNative[name] != kind {
+				t.Errorf("native backend: %q is %q, not %s", name, fromNative[name], kind)
+			}
+			if !listed[name] {
+				t.Errorf("long status: %q not read back", name)
+			}
+			if q := quoteGitPath(name); unquoteGitPath(q) != name {
+				t.Errorf("quoting: %q comes back as %q", name, unquoteGitPath(q))
+			}
+		})
+	}
+}
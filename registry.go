@@ -0,0 +1,286 @@
+// File: registry.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: persistent registry of repositories (`gits repos`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cumulus13/go-config-get/configget"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ---------------------------------------------------------------------------
+// Registry
+// ---------------------------------------------------------------------------
+
+// RegistryEntry is one bookmarked repository.
+type RegistryEntry struct {
+	Path string   `toml:"path"`
+	Name string   `toml:"name,omitempty"`
+	Tags []string `toml:"tags,omitempty"`
+}
+
+// HasTag reports whether the entry carries tag (case-insensitive).
+func (e RegistryEntry) HasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Registry is the list of repositories the user cares about, stored as
+// repos.toml next to the gits config.
+type Registry struct {
+	Repos []RegistryEntry `toml:"repos"`
+
+	path string
+}
+
+// stateFile resolves a gits data file (e.g. "repos.toml") using the same
+// discovery rules as the main config file.
+func stateFile(name string) string {
+	path, err := configget.GetConfigFile(name, "gits", configget.Options{
+		Create:     true,
+		Extensions: []string{filepath.Ext(name)},
+	})
+	if err != nil {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, ".config", "gits", name)
+	}
+	return path
+}
+
+// LoadRegistry reads the registry file.  A missing file yields an empty
+// registry that will be created on the first Save.
+func LoadRegistry() (*Registry, error) {
+	reg := &Registry{path: stateFile("repos.toml")}
+	data, err := os.ReadFile(reg.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return reg, nil
+		}
+		return reg, err
+	}
+	if err := toml.Unmarshal(data, reg); err != nil {
+		return reg, fmt.Errorf("parsing %s: %w", reg.path, err)
+	}
+	return reg, nil
+}
+
+// Save writes the registry atomically (temp file + rename).
+func (r *Registry) Save() error {
+	sort.Slice(r.Repos, func(i, j int) bool { return r.Repos[i].Path < r.Repos[j].Path })
+	data, err := toml.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(r.path, data, 0o644)
+}
+
+// Lookup returns the index of the entry whose path or name equals key.
+func (r *Registry) Lookup(key string) int {
+	abs := key
+	if a, err := filepath.Abs(key); err == nil {
+		abs = a
+	}
+	for i, e := range r.Repos {
+		if e.Path == abs || (e.Name != "" && e.Name == key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Add registers path (or merges tags into an existing entry).  It returns
+// true when a new entry was created.
+func (r *Registry) Add(path, name string, tags []string) bool {
+	if i := r.Lookup(path); i >= 0 {
+		for _, t := range tags {
+			if !r.Repos[i].HasTag(t) {
+				r.Repos[i].Tags = append(r.Repos[i].Tags, t)
+			}
+		}
+		if name != "" {
+			r.Repos[i].Name = name
+		}
+		return false
+	}
+	r.Repos = append(r.Repos, RegistryEntry{Path: path, Name: name, Tags: tags})
+	return true
+}
+
+// Remove deletes the entry matching a path or name.
+func (r *Registry) Remove(key string) bool {
+	i := r.Lookup(key)
+	if i < 0 {
+		return false
+	}
+	r.Repos = append(r.Repos[:i], r.Repos[i+1:]...)
+	return true
+}
+
+// Select returns the entries matching "@tag" / "@name".  "@all" (or a bare
+// "@") selects every entry.
+func (r *Registry) Select(selector string) []RegistryEntry {
+	sel := strings.TrimPrefix(selector, "@")
+	var out []RegistryEntry
+	for _, e := range r.Repos {
+		if sel == "" || sel == "all" || e.Name == sel || e.HasTag(sel) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// writeFileAtomic writes data to a temp file in the target directory and
+// renames it into place so readers never observe a half-written file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return os.Rename(tmpName, path)
+}
+
+// ---------------------------------------------------------------------------
+// `gits repos` subcommand
+// ---------------------------------------------------------------------------
+
+func printReposUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  gits repos add [path] [--tag TAG]... [--name NAME]")
+	fmt.Println("  gits repos remove <path|name>")
+	fmt.Println("  gits repos list [@tag]")
+}
+
+// runRepos implements `gits repos add|remove|list`.
+func runRepos(cfg AppConfig, args []string) {
+	c := cfg.Colors
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	reg, err := LoadRegistry()
+	if err != nil {
+		exitWithError(err, c)
+	}
+
+	switch args[0] {
+	case "add":
+		target := "."
+		name := ""
+		var tags []string
+		rest := args[1:]
+		for i := 0; i < len(rest); i++ {
+			switch rest[i] {
+			case "-t", "--tag":
+				if i+1 < len(rest) {
+					i++
+					for _, t := range strings.Split(rest[i], ",") {
+						if t = strings.TrimSpace(t); t != "" {
+							tags = append(tags, t)
+						}
+					}
+				}
+			case "-n", "--name":
+				if i+1 < len(rest) {
+					i++
+					name = rest[i]
+				}
+			default:
+				target = rest[i]
+			}
+		}
+		root, err := repoRoot(target)
+		if err != nil {
+			fmt.Printf("%s %s%s is not inside a git repository%s\n",
+				Icons.ERROR, Bold+resolveColor(c.Deleted), target, Reset)
+			exit(1)
+		}
+		added := reg.Add(root, name, tags)
+		if err := reg.Save(); err != nil {
+			exitWithError(err, c)
+		}
+		verb := "Updated"
+		if added {
+			verb = "Added"
+		}
+		fmt.Printf("%s %s %s%s%s\n", Icons.SUCCESS, verb, Bold+resolveColor(c.CwdPath), root, Reset)
+
+	case "remove", "rm":
+		if len(args) < 2 {
+			printReposUsage()
+			exit(1)
+		}
+		key := args[1]
+		if reg.Lookup(key) < 0 && IsDir(key) {
+			if root, err := repoRoot(key); err == nil {
+				key = root
+			}
+		}
+		if !reg.Remove(key) {
+			fmt.Printf("%s %s%s is not registered%s\n", Icons.WARNING, Bold+resolveColor(c.AheadBehind), args[1], Reset)
+			exit(1)
+		}
+		if err := reg.Save(); err != nil {
+			exitWithError(err, c)
+		}
+		fmt.Printf("%s Removed %s%s%s\n", Icons.SUCCESS, Bold+resolveColor(c.CwdPath), args[1], Reset)
+
+	case "list", "ls":
+		entries := reg.Repos
+		if len(args) > 1 {
+			entries = reg.Select(args[1])
+		}
+		if len(entries) == 0 {
+			fmt.Printf("   %s(no repositories registered — use `gits repos add`)%s\n", Dim, Reset)
+			return
+		}
+		for _, e := range entries {
+			mark := Icons.FOLDER
+			if !isGitDir(e.Path) {
+				mark = Icons.WARNING
+			}
+			fmt.Printf("%s %s%s%s", mark, Bold+resolveColor(c.CwdPath), e.Path, Reset)
+			if e.Name != "" {
+				fmt.Printf(" %s(%s)%s", Bold+resolveColor(c.Branch), e.Name, Reset)
+			}
+			for _, t := range e.Tags {
+				fmt.Printf(" %s@%s%s", resolveColor(c.Header), t, Reset)
+			}
+			fmt.Println()
+		}
+
+	default:
+		printReposUsage()
+		exit(1)
+	}
+}
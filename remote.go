@@ -0,0 +1,220 @@
+// File: remote.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: remote overview and management (`gits remote`)
+// License: MIT
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// remoteProbeTimeout bounds each reachability check.
+const remoteProbeTimeout = 5 * time.Second
+
+// remoteProtocol classifies a remote URL by transport.
+func remoteProtocol(url string) string {
+	switch {
+	case strings.HasPrefix(url, "https://"):
+		return "https"
+	case strings.HasPrefix(url, "http://"):
+		return "http"
+	case strings.HasPrefix(url, "ssh://"), strings.HasPrefix(url, "git+ssh://"):
+		return "ssh"
+	case strings.HasPrefix(url, "git://"):
+		return "git"
+	case strings.HasPrefix(url, "file://"):
+		return "file"
+	case strings.Contains(url, "::"):
+		return strings.SplitN(url, "::", 2)[0] // remote helper, e.g. "persistent-https::"
+	}
+	// scp-like "user@host:path"; a colon before any slash means ssh.
+	if i := strings.Index(url, ":"); i > 0 && !strings.Contains(url[:i], "/") {
+		return "ssh"
+	}
+	return "file"
+}
+
+// probeRemote runs `git ls-remote --symref NAME HEAD` with a timeout and
+// returns the remote's default branch.
+func probeRemote(dir, remote string) (defaultBranch string, err error) {
+	cmd := gitCmd(dir, "ls-remote", "--symref", remote, "HEAD")
+	cmd.Env = nonInteractiveGitEnv()
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Start(); err != nil {
+		return "", err
+	}
+	timer := time.AfterFunc(remoteProbeTimeout, func() { cmd.Process.Kill() })
+	err = cmd.Wait()
+	if !timer.Stop() {
+		return "", fmt.Errorf("timed out after %s", remoteProbeTimeout)
+	}
+	if err != nil {
+		return "", errors.New(classifyFetchError(stderr.String()))
+	}
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if ref, ok := strings.CutPrefix(line, "ref: refs/heads/"); ok {
+			return strings.TrimSuffix(ref, "\tHEAD"), nil
+		}
+	}
+	return "", nil
+}
+
+// remoteInfo is one row of `gits remote`.
+type remoteInfo struct {
+	Name, FetchURL, PushURL string
+	DefaultBranch           string // from the probe, or the local origin/HEAD
+	ProbeErr                error
+	Probed                  bool
+}
+
+// runRemote implements `gits remote [list [--no-probe]] | add NAME URL |
+// rename OLD NEW | remove NAME` (the last three ask for confirmation unless
+// --yes is given).
+func runRemote(status *Status, args []string) {
+	c := status.cfg.Colors
+	yes, probe := false, true
+	var rest []string
+	for _, a := range args {
+		switch a {
+		case "--yes", "-y":
+			yes = true
+		case "--no-probe":
+			probe = false
+		default:
+			rest = append(rest, a)
+		}
+	}
+	action := "list"
+	if len(rest) > 0 {
+		action, rest = rest[0], rest[1:]
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	ask := func(question string) bool {
+		if yes {
+			return true
+		}
+		ok, err := confirm(question)
+		if err != nil {
+			fail(fmt.Errorf("%v (use --yes)", err))
+		}
+		return ok
+	}
+
+	switch action {
+	case "list", "ls", "-v":
+		out, err := gitOutput(".", "remote")
+		if err != nil {
+			fail(err)
+		}
+		names := strings.Fields(out)
+		if len(names) == 0 {
+			fmt.Printf("%s %sNo remotes configured%s %s(gits remote add origin URL)%s\n",
+				Icons.INFO, resolveColor(c.AheadBehind), Reset, Dim, Reset)
+			return
+		}
+		infos := make([]remoteInfo, len(names))
+		parallelEach(len(names), func(i int) {
+			r := &infos[i]
+			r.Name = names[i]
+			r.FetchURL, _ = gitOutput(".", "remote", "get-url", r.Name)
+			r.PushURL, _ = gitOutput(".", "remote", "get-url", "--push", r.Name)
+			if probe {
+				r.Probed = true
+				r.DefaultBranch, r.ProbeErr = probeRemote(".", r.Name)
+			}
+			if r.DefaultBranch == "" {
+				if ref, err := gitOutput(".", "symbolic-ref", "--quiet", "--short", "refs/remotes/"+r.Name+"/HEAD"); err == nil {
+					r.DefaultBranch = strings.TrimPrefix(ref, r.Name+"/")
+				}
+			}
+		})
+
+		for _, r := range infos {
+			fmt.Printf("%s %s%s%s  %s%s%s %s[%s]%s", Icons.REMOTE, Bold+resolveColor(c.Header), r.Name, Reset,
+				resolveColor(c.RemoteURL), r.FetchURL, Reset, Dim, remoteProtocol(r.FetchURL), Reset)
+			switch {
+			case !r.Probed:
+				fmt.Println()
+			case r.ProbeErr != nil:
+				fmt.Printf("  %s %s%v%s\n", Icons.ERROR, resolveColor(c.Deleted), r.ProbeErr, Reset)
+			default:
+				fmt.Printf("  %s %sreachable%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+			}
+			if r.PushURL != "" && r.PushURL != r.FetchURL {
+				fmt.Printf("     %spush%s %s%s%s %s[%s]%s\n", Dim, Reset,
+					resolveColor(c.RemoteURL), r.PushURL, Reset, Dim, remoteProtocol(r.PushURL), Reset)
+			}
+			if r.DefaultBranch != "" {
+				fmt.Printf("     %sdefault branch%s %s%s %s%s\n", Dim, Reset,
+					Bold+resolveColor(c.Branch), Icons.GIT, r.DefaultBranch, Reset)
+			}
+		}
+
+	case "add":
+		if len(rest) != 2 {
+			fail(errors.New("usage: gits remote add NAME URL"))
+		}
+		name, url := rest[0], rest[1]
+		fmt.Printf("%s %s%s%s → %s%s%s %s[%s]%s\n", Icons.REMOTE, Bold+resolveColor(c.Header), name, Reset,
+			resolveColor(c.RemoteURL), url, Reset, Dim, remoteProtocol(url), Reset)
+		if !ask("Add this remote?") {
+			return
+		}
+		if _, err := gitOutput(".", "remote", "add", name, url); err != nil {
+			fail(err)
+		}
+		if branch, err := probeRemote(".", name); err != nil {
+			fmt.Printf("%s %sAdded, but the remote is not reachable: %v%s\n", Icons.WARNING, resolveColor(c.AheadBehind), err, Reset)
+		} else {
+			fmt.Printf("%s %sAdded %s%s (default branch %s)\n", Icons.SUCCESS, resolveColor(c.UpToDate), name, Reset, branch)
+		}
+
+	case "rename":
+		if len(rest) != 2 {
+			fail(errors.New("usage: gits remote rename OLD NEW"))
+		}
+		if !ask(fmt.Sprintf("Rename remote %s to %s (remote-tracking branches move too)?", rest[0], rest[1])) {
+			return
+		}
+		if _, err := gitOutput(".", "remote", "rename", rest[0], rest[1]); err != nil {
+			fail(err)
+		}
+		fmt.Printf("%s %sRenamed %s → %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), rest[0], rest[1], Reset)
+
+	case "remove", "rm":
+		if len(rest) != 1 {
+			fail(errors.New("usage: gits remote remove NAME"))
+		}
+		name := rest[0]
+		url, err := gitOutput(".", "remote", "get-url", name)
+		if err != nil {
+			fail(err)
+		}
+		refs, _ := gitOutput(".", "for-each-ref", "--format=%(refname:short)", "refs/remotes/"+name)
+		n := 0
+		if refs != "" {
+			n = len(strings.Split(refs, "\n"))
+		}
+		fmt.Printf("%s %s%s%s %s%s%s — %d remote-tracking ref(s) will be deleted\n", Icons.WARNING,
+			Bold+resolveColor(c.Header), name, Reset, resolveColor(c.RemoteURL), url, Reset, n)
+		if !ask("Remove this remote?") {
+			return
+		}
+		if _, err := gitOutput(".", "remote", "remove", name); err != nil {
+			fail(err)
+		}
+		fmt.Printf("%s %sRemoved %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), name, Reset)
+
+	default:
+		fail(fmt.Errorf("unknown remote action %q (list, add, rename, remove)", action))
+	}
+}
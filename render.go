@@ -0,0 +1,161 @@
+// File: render.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: Renderer: a StatusReport drawn in color, as plain text or as a document
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Renderer draws a StatusReport in one format onto the sink it was made
+// with.  Renderers only read the report and keep no state between calls,
+// so a new format needs no change to how the status is read.
+type Renderer interface {
+	RenderStatus(*StatusReport) error
+}
+
+// statusFormats are the formats `gits --format` draws the status in.
+var statusFormats = []string{"ansi", "plain", "json", "markdown", "html"}
+
+// newRenderer returns the Renderer for format, writing to w; "" is ansi.
+func newRenderer(format string, w io.Writer, cfg AppConfig) (Renderer, error) {
+	switch format {
+	case "", "ansi":
+		return newANSIRenderer(w, cfg), nil
+	case "plain":
+		return plainRenderer{w}, nil
+	case "json":
+		return jsonRenderer{w}, nil
+	case "markdown", "md":
+		return markdownRenderer{w}, nil
+	case "html":
+		return htmlRenderer{w}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q (use %s)", format, strings.Join(statusFormats, ", "))
+}
+
+// ansiRenderer draws the status in color, with icons, hyperlinks and the
+// untracked tree: what `gits` shows on a terminal.  Its styles are
+// resolved when it is made and never change.
+type ansiRenderer struct {
+	w      io.Writer
+	cfg    AppConfig
+	styles *lineStyles
+}
+
+// newANSIRenderer returns an ansiRenderer for cfg's colors and layout.
+func newANSIRenderer(w io.Writer, cfg AppConfig) *ansiRenderer {
+	return &ansiRenderer{w: w, cfg: cfg, styles: newLineStyles(cfg.Colors)}
+}
+
+// RenderStatus writes the working directory, then the long status with
+// the last commit under the branch line, what git said on stderr and the
+// notes that follow.  A directory outside any repository, or a status
+// that failed, gets the working directory alone: the command explains
+// those, as it may ask what to do about them.
+func (r *ansiRenderer) RenderStatus(rep *StatusReport) error {
+	c := r.cfg.Colors
+	w := bufio.NewWriterSize(r.w, 64<<10)
+	switch {
+	case rep.ProfileErr != "":
+		fmt.Fprintf(w, "%s %s%s%s\n", Icons.WARNING, resolveColor(c.AheadBehind), rep.ProfileErr, Reset)
+	case rep.Profile != "":
+		fmt.Fprintf(w, "🙈 %sShowing status with the %s .gitignore profile%s\n", Dim, rep.Profile, Reset)
+	}
+	fmt.Fprintf(w, "%s %schdir:%s %s%s%s\n",
+		Icons.FOLDER,
+		Bold+resolveColor(c.CwdLabel), Reset,
+		Bold+resolveColor(c.CwdPath), nativePath(rep.Dir), Reset)
+	if rep.NotRepo || rep.Err != nil {
+		return w.Flush()
+	}
+
+	r.renderLines(w, rep)
+	printGitMessages(w, rep.Stderr, c)
+	if rep.Filter != "" {
+		fmt.Fprintf(w, "%s %sfilter \"%s\" hid %d path(s)%s\n", Icons.INFO, Dim, rep.Filter, rep.Hidden, Reset)
+	}
+	if len(rep.TrackedIgnored) > 0 {
+		writeTrackedIgnored(w, rep.TrackedIgnored, c)
+	}
+	if rep.SuggestAccel {
+		fmt.Fprintf(w, "%s %sgit status took %s; `gits accel enable` turns on git's fsmonitor and untracked cache%s\n",
+			Icons.INFO, Dim, rep.Took.Round(10*time.Millisecond), Reset)
+	}
+	return w.Flush()
+}
+
+// plainRenderer writes the status in git's words without color or icons,
+// for logs and pipes: untracked directories still carry their size.
+type plainRenderer struct {
+	w io.Writer
+}
+
+func (r plainRenderer) RenderStatus(rep *StatusReport) error {
+	w := bufio.NewWriterSize(r.w, 64<<10)
+	switch {
+	case rep.ProfileErr != "":
+		fmt.Fprintf(w, "warning: %s\n", rep.ProfileErr)
+	case rep.Profile != "":
+		fmt.Fprintf(w, "Showing status with the %s .gitignore profile\n", rep.Profile)
+	}
+	switch {
+	case rep.NotRepo:
+		fmt.Fprintf(w, "%s is not in a git repository\n", displayPath(rep.Dir))
+		return w.Flush()
+	case rep.Err != nil:
+		fmt.Fprintf(w, "error: %v\n", rep.Err)
+		return w.Flush()
+	}
+	for _, l := range rep.Lines {
+		w.WriteString(l.Text)
+		if l.Dir != nil {
+			fmt.Fprintf(w, " (%s)", l.Dir.summary())
+		}
+		w.WriteByte('\n')
+		switch {
+		case l.Dir != nil && expandUntracked:
+			for _, f := range l.Dir.files {
+				fmt.Fprintf(w, "\t  %s\n", quoteGitPath(f))
+			}
+		case (l.Kind == lineBranch || l.Kind == lineDetached) && rep.HasHead:
+			hc := rep.Head
+			fmt.Fprintf(w, "Last commit: %s %s (%s, %s)\n", hc.Hash, hc.Subject, relativeAge(hc.Time), hc.Author)
+		}
+	}
+	for _, m := range splitGitStderr(rep.Stderr) {
+		switch {
+		case m.hint && gitMessages.Hints:
+			for _, line := range strings.Split(m.text, "\n") {
+				fmt.Fprintf(w, "hint: %s\n", line)
+			}
+		case !m.hint && gitMessages.Warnings:
+			fmt.Fprintf(w, "git: %s\n", m.text)
+		}
+	}
+	if rep.Filter != "" {
+		fmt.Fprintf(w, "filter \"%s\" hid %d path(s)\n", rep.Filter, rep.Hidden)
+	}
+	if len(rep.TrackedIgnored) > 0 {
+		fmt.Fprintln(w, "Tracked but ignored:")
+		for _, it := range rep.TrackedIgnored {
+			fmt.Fprintf(w, "\t%s", quoteGitPath(it.Path))
+			if it.Rule != "" {
+				fmt.Fprintf(w, "  %s %s", it.Source, it.Rule)
+			}
+			w.WriteByte('\n')
+		}
+	}
+	if rep.SuggestAccel {
+		fmt.Fprintf(w, "git status took %s; `gits accel enable` turns on git's fsmonitor and untracked cache\n",
+			rep.Took.Round(10*time.Millisecond))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,120 @@
+// File: render_test.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: rendering a synthetic huge status within its memory ceiling
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/metrics"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// hugeStatus is the number of paths of the synthetic status.
+const hugeStatus = 100000
+
+// renderMaxHeap is the ceiling on the heap rendering hugeStatus paths may
+// grow by, and renderMaxAllocs on its allocations per path.
+const (
+	renderMaxHeap   = 32 << 20
+	renderMaxAllocs = 4
+)
+
+// syntheticStatus builds the long `git status` text of a repository with n
+// changed paths: a third staged, a third modified, a third untracked.
+func syntheticStatus(n int) string {
+	var sb strings.Builder
+	sb.Grow(n * 48)
+	sb.WriteString("On branch main\nYour branch is up to date with 'origin/main'.\n\n")
+	section := func(title, hint string, from, to int, label string) {
+		fmt.Fprintf(&sb, "%s\n  (%s)\n", title, hint)
+		for i := from; i < to; i++ {
+			sb.WriteString("\t" + label)
+			sb.WriteString("pkg")
+			sb.WriteString(strconv.Itoa(i / 100))
+			sb.WriteString("/file")
+			sb.WriteString(strconv.Itoa(i))
+			sb.WriteString(".go\n")
+		}
+		sb.WriteString("\n")
+	}
+	section("Changes to be committed:", `use "git restore --staged <file>..." to unstage`, 0, n/3, "modified:   ")
+	section("Changes not staged for commit:", `use "git add <file>..." to update what will be committed`, n/3, 2*n/3, "modified:   ")
+	section("Untracked files:", `use "git add <file>..." to include in what will be committed`, 2*n/3, n, "")
+	sb.WriteString("no changes added to commit (use \"git add\" and/or \"git commit -a\")\n")
+	return sb.String()
+}
+
+// renderSynthetic renders text the way `gits` renders a real status,
+// without printing it.
+func renderSynthetic(r Renderer, text string) {
+	r.RenderStatus(&StatusReport{Dir: ".", Text: text, Lines: parseLongStatus(text)})
+}
+
+// heapBytes is the memory held by live and not yet swept heap objects.
+func heapBytes() uint64 {
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	return sample[0].Value.Uint64()
+}
+
+func TestRenderHeapCeiling(t *testing.T) {
+	text := syntheticStatus(hugeStatus)
+	r := newANSIRenderer(io.Discard, DefaultConfig())
+	runtime.GC()
+	base := heapBytes()
+
+	// Sample the heap while rendering to catch its peak.
+	var peak atomic.Uint64
+	done := make(chan struct{})
+	sampled := make(chan struct{})
+	go func() {
+		defer close(sampled)
+		tick := time.NewTicker(time.Millisecond)
+		defer tick.Stop()
+		for {
+			if h := heapBytes(); h > peak.Load() {
+				peak.Store(h)
+			}
+			select {
+			case <-done:
+				return
+			case <-tick.C:
+			}
+		}
+	}()
+	renderSynthetic(r, text)
+	close(done)
+	<-sampled
+
+	if grew := int64(peak.Load()) - int64(base); grew > renderMaxHeap {
+		t.Errorf("rendering %d paths grew the heap by %.1f MB, over the %d MB ceiling",
+			hugeStatus, float64(grew)/(1<<20), renderMaxHeap>>20)
+	}
+}
+
+func TestRenderAllocsPerPath(t *testing.T) {
+	text := syntheticStatus(hugeStatus)
+	r := newANSIRenderer(io.Discard, DefaultConfig())
+	allocs := testing.AllocsPerRun(1, func() { renderSynthetic(r, text) })
+	if perPath := allocs / hugeStatus; perPath > renderMaxAllocs {
+		t.Errorf("rendering %d paths made %.1f allocations per path, over %d", hugeStatus, perPath, renderMaxAllocs)
+	}
+}
+
+func BenchmarkRender(b *testing.B) {
+	text := syntheticStatus(hugeStatus)
+	r := newANSIRenderer(io.Discard, DefaultConfig())
+	b.ReportAllocs()
+	for b.Loop() {
+		renderSynthetic(r, text)
+	}
+}
@@ -0,0 +1,165 @@
+// File: renderformats.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: the document formats of `gits --format`: JSON, Markdown and HTML
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// jsonRenderer writes the status as one JSON document, untracked
+// directory contents included.
+type jsonRenderer struct {
+	w io.Writer
+}
+
+func (r jsonRenderer) RenderStatus(rep *StatusReport) error {
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rep.document(true))
+}
+
+// markdownRenderer writes the status as Markdown, for issues, chat and
+// notes: a heading per section and a list of its paths.
+type markdownRenderer struct {
+	w io.Writer
+}
+
+// mdText escapes the characters Markdown would read as formatting.
+var mdText = strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, "#", `\#`)
+
+// mdCode is s as a code span, fenced with more backticks than s holds in
+// a row.
+func mdCode(s string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", longest+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
+
+func (r markdownRenderer) RenderStatus(rep *StatusReport) error {
+	doc := rep.document(expandUntracked)
+	w := bufio.NewWriter(r.w)
+	fmt.Fprintf(w, "# gits status: %s\n\n", mdCode(displayPath(doc.Dir)))
+	if doc.Error != "" {
+		fmt.Fprintf(w, "**error:** %s\n", mdText.Replace(doc.Error))
+		return w.Flush()
+	}
+	if doc.Profile != "" {
+		fmt.Fprintf(w, "Showing status with the %s .gitignore profile.\n\n", mdCode(doc.Profile))
+	}
+	if doc.Branch != "" {
+		fmt.Fprintf(w, "On branch %s", mdCode(doc.Branch))
+		if doc.Head != nil {
+			fmt.Fprintf(w, " · %s %s · %s · %s", mdCode(doc.Head.Hash), mdText.Replace(doc.Head.Subject),
+				doc.Head.Age, mdText.Replace(doc.Head.Author))
+		}
+		fmt.Fprint(w, "\n\n")
+	}
+	for _, n := range doc.Notes {
+		fmt.Fprintf(w, "> %s\n", mdText.Replace(n))
+	}
+	if len(doc.Notes) > 0 {
+		fmt.Fprintln(w)
+	}
+	for _, s := range doc.Sections {
+		fmt.Fprintf(w, "## %s\n\n", mdText.Replace(s.Title))
+		for _, e := range s.Entries {
+			fmt.Fprint(w, "- ")
+			if e.Status != "" {
+				fmt.Fprintf(w, "%s: ", e.Status)
+			}
+			if e.From != "" {
+				fmt.Fprintf(w, "%s → ", mdCode(visiblePath(e.From)))
+			}
+			fmt.Fprint(w, mdCode(visiblePath(e.Path)))
+			if e.Count > 0 {
+				fmt.Fprintf(w, " (%s)", untrackedSummary(e.Count, e.Size))
+			}
+			fmt.Fprintln(w)
+			for _, f := range e.Files {
+				fmt.Fprintf(w, "  - %s\n", mdCode(visiblePath(f)))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	if len(doc.TrackedIgnored) > 0 {
+		fmt.Fprint(w, "## Tracked but ignored\n\n")
+		for _, it := range doc.TrackedIgnored {
+			fmt.Fprintf(w, "- %s", mdCode(visiblePath(it.Path)))
+			if it.Rule != "" {
+				fmt.Fprintf(w, " (%s %s)", it.Source, mdCode(it.Rule))
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w)
+	}
+	for _, m := range doc.GitMessages {
+		fmt.Fprintf(w, "> git: %s\n", mdText.Replace(strings.ReplaceAll(m, "\n", " ")))
+	}
+	if doc.Filter != "" {
+		fmt.Fprintf(w, "\nFilter %s hid %d path(s).\n", mdCode(doc.Filter), doc.Hidden)
+	}
+	return w.Flush()
+}
+
+// htmlRenderer writes the status as a standalone HTML page in the colors
+// of the `gits serve --http` dashboard.
+type htmlRenderer struct {
+	w io.Writer
+}
+
+// statusPage is the page htmlRenderer writes.
+var statusPage = template.Must(template.New("status").Funcs(template.FuncMap{
+	"path":    visiblePath,
+	"summary": untrackedSummary,
+	"display": displayPath,
+}).Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8">
+<title>gits status — {{display .Dir}}</title>
+<style>
+body{font-family:ui-monospace,monospace;background:#111;color:#ddd;margin:2em}
+h2{color:#ff0;font-size:1em;margin-top:1.5em}ul{list-style:none;padding-left:1.5em;margin:.25em 0}
+.branch{color:#0ff}.err{color:#f44}.dim{color:#777}.label{color:#ff0}
+.modified{color:#0f0}.deleted{color:#f44}.new{color:#0ff}.renamed{color:#f0f}.untracked{color:#f80}
+</style></head><body>
+<h1>gits status — {{display .Dir}}</h1>
+{{if .Error}}<p class="err">{{.Error}}</p>
+{{else}}{{if .Branch}}<p>On branch <span class="branch">{{.Branch}}</span>{{with .Head}}
+<span class="dim">· {{.Hash}} {{.Subject}} · {{.Age}} · {{.Author}}</span>{{end}}</p>
+{{end}}{{range .Notes}}<p class="dim">{{.}}</p>
+{{end}}{{range .Sections}}<h2>{{.Title}}</h2>
+<ul>
+{{range .Entries}}<li>{{if .Status}}<span class="label">{{.Status}}:</span> {{end}}{{if .From}}<span class="renamed">{{path .From}}</span> → {{end}}<span class="{{if eq .Status "modified" "deleted" "renamed"}}{{.Status}}{{else if .Status}}new{{else}}untracked{{end}}">{{path .Path}}</span>{{if .Count}} <span class="dim">({{summary .Count .Size}})</span>{{end}}{{if .Files}}
+<ul>{{range .Files}}<li class="untracked">{{path .}}</li>{{end}}</ul>{{end}}</li>
+{{end}}</ul>
+{{end}}{{if .TrackedIgnored}}<h2>Tracked but ignored</h2>
+<ul>
+{{range .TrackedIgnored}}<li>{{path .Path}}{{if .Rule}} <span class="dim">{{.Source}} {{.Rule}}</span>{{end}}</li>
+{{end}}</ul>
+{{end}}{{range .GitMessages}}<p class="dim">git: {{.}}</p>
+{{end}}{{if .Filter}}<p class="dim">filter “{{.Filter}}” hid {{.Hidden}} path(s)</p>
+{{end}}{{end}}</body></html>
+`))
+
+func (r htmlRenderer) RenderStatus(rep *StatusReport) error {
+	return statusPage.Execute(r.w, rep.document(expandUntracked))
+}
@@ -0,0 +1,18 @@
+// File: replaceexe.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: putting a new gits executable in place (Unix)
+// License: MIT
+
+//go:build !windows
+
+package main
+
+import "os"
+
+// replaceExecutable moves the new build at tmp over exe.  The rename is
+// atomic: whatever runs exe gets the old binary or the new one, and the
+// running gits keeps the old one open.
+func replaceExecutable(tmp, exe string) error {
+	return os.Rename(tmp, exe)
+}
@@ -0,0 +1,26 @@
+// File: replaceexe_windows.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: putting a new gits executable in place (Windows)
+// License: MIT
+
+package main
+
+import "os"
+
+// replaceExecutable moves the new build at tmp over exe.  Windows will not
+// overwrite a running executable but lets it be renamed, so the old one
+// steps aside as exe.old, removed by the next self-update, and is put
+// back should the new one fail to move in.
+func replaceExecutable(tmp, exe string) error {
+	old := exe + ".old"
+	os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, exe); err != nil {
+		os.Rename(old, exe)
+		return err
+	}
+	return nil
+}
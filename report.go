@@ -0,0 +1,72 @@
+// File: report.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: aggregated, machine-readable fleet report (`gits scan --json`)
+// License: MIT
+
+package main
+
+import (
+	"os"
+	"time"
+)
+
+// FleetAggregate summarises a whole scan for audit jobs.
+type FleetAggregate struct {
+	TotalRepos        int `json:"total_repos"`
+	DirtyRepos        int `json:"dirty_repos"`
+	ErrorRepos        int `json:"error_repos"`
+	ReposWithUnpushed int `json:"repos_with_unpushed"`
+	UnpushedBranches  int `json:"unpushed_branches"`
+	UnpushedCommits   int `json:"unpushed_commits"`
+	Stashes           int `json:"stashes"`
+}
+
+// FleetReport is the document produced by `gits scan --json`.
+type FleetReport struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	Host        string         `json:"host"`
+	Aggregate   FleetAggregate `json:"aggregate"`
+	Repos       []*RepoStatus  `json:"repos"`
+}
+
+// fillUnpushed populates RepoStatus.Unpushed for every healthy result.
+func fillUnpushed(results []*RepoStatus) {
+	parallelEach(len(results), func(i int) {
+		r := results[i]
+		if r.Err != "" {
+			return
+		}
+		r.Unpushed, _ = collectUnpushed(r.Path)
+	})
+}
+
+// buildFleetReport computes the aggregate over every scanned repository.
+func buildFleetReport(results []*RepoStatus) *FleetReport {
+	host, _ := os.Hostname()
+	rep := &FleetReport{
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		Host:        host,
+		Repos:       results,
+	}
+	agg := &rep.Aggregate
+	agg.TotalRepos = len(results)
+	for _, r := range results {
+		if r.Err != "" {
+			agg.ErrorRepos++
+			continue
+		}
+		if r.Dirty() {
+			agg.DirtyRepos++
+		}
+		if len(r.Unpushed) > 0 {
+			agg.ReposWithUnpushed++
+		}
+		agg.UnpushedBranches += len(r.Unpushed)
+		for _, u := range r.Unpushed {
+			agg.UnpushedCommits += u.Commits
+		}
+		agg.Stashes += r.Stashes
+	}
+	return rep
+}
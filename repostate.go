@@ -0,0 +1,388 @@
+// File: repostate.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: the merge, rebase, cherry-pick, revert, am and bisect state the long status reports
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// repoState is what `git status` reads from the git directory besides
+// the status proper: an operation in progress and where a detached HEAD
+// was checked out from.  The porcelain format leaves it out, so it is
+// read from the files git keeps it in.  The share of files a sparse
+// checkout leaves out is not read.
+type repoState struct {
+	Merge    bool // MERGE_HEAD: a merge waits to be concluded
+	MergeMsg bool // MERGE_MSG: a commit message is waiting
+
+	AM      bool // rebase-apply/applying: `git am` stopped
+	AMEmpty bool // on an empty patch
+
+	Rebase      bool     // rebase-apply or rebase-merge
+	Interactive bool     // rebase-merge/interactive
+	Branch      string   // the branch being rebased, "" when it was detached
+	Onto        string   // what it is rebased onto
+	Done, Todo  []string // an interactive rebase's commands, hashes shortened
+	DoneFile    string   // where the commands done are, as git names it
+	Split       bool     // a commit is being split while rebasing
+
+	CherryPick bool   // CHERRY_PICK_HEAD, or a sequence of picks
+	PickHead   bool   // CHERRY_PICK_HEAD exists
+	PickOid    string // the commit being picked, "" in a sequence
+	Revert     bool   // REVERT_HEAD, or a sequence of reverts
+	RevertOid  string // the commit being reverted, "" in a sequence
+
+	Bisect     bool   // BISECT_LOG
+	BisectFrom string // the branch the bisection started on
+
+	DetachedFrom string // what a detached HEAD was checked out as
+	DetachedAt   bool   // HEAD has not moved since
+}
+
+// readRepoState reads the state of the repository at root, with its git
+// directory gitDir, whose status is rs.
+func readRepoState(root, gitDir string, rs *RepoStatus) *repoState {
+	ws := &repoState{}
+	path := func(name string) string { return filepath.Join(gitDir, filepath.FromSlash(name)) }
+	line := func(name string) string {
+		data, err := os.ReadFile(path(name))
+		if err != nil {
+			return ""
+		}
+		first, _, _ := strings.Cut(string(data), "\n")
+		return strings.TrimSpace(first)
+	}
+
+	rebase := func() bool {
+		switch {
+		case IsDir(path("rebase-apply")):
+			if Exists(path("rebase-apply/applying")) {
+				ws.AM = true
+				if info, err := os.Stat(path("rebase-apply/patch")); err == nil && info.Size() == 0 {
+					ws.AMEmpty = true
+				}
+				return true
+			}
+			ws.Rebase = true
+			ws.Branch, ws.Onto = stateBranch(line("rebase-apply/head-name")), stateBranch(line("rebase-apply/onto"))
+		case IsDir(path("rebase-merge")):
+			ws.Rebase = true
+			ws.Interactive = Exists(path("rebase-merge/interactive"))
+			ws.Branch, ws.Onto = stateBranch(line("rebase-merge/head-name")), stateBranch(line("rebase-merge/onto"))
+		default:
+			return false
+		}
+		return true
+	}
+	ws.MergeMsg = IsFile(path("MERGE_MSG"))
+	ws.PickHead = IsFile(path("CHERRY_PICK_HEAD"))
+	switch {
+	case IsFile(path("MERGE_HEAD")):
+		rebase()
+		ws.Merge = true
+	case rebase():
+	default:
+		if oid := line("CHERRY_PICK_HEAD"); oid != "" {
+			ws.CherryPick, ws.PickOid = true, shortOid(oid)
+		}
+	}
+	if IsFile(path("BISECT_LOG")) {
+		ws.Bisect = true
+		ws.BisectFrom = stateBranch(line("BISECT_START"))
+	}
+	if oid := line("REVERT_HEAD"); oid != "" {
+		ws.Revert, ws.RevertOid = true, shortOid(oid)
+	}
+	// A sequence of picks or reverts: which one it is, not which commit.
+	switch cmd, _, _ := strings.Cut(line("sequencer/todo"), " "); {
+	case (cmd == "pick" || cmd == "p") && !ws.Interactive:
+		ws.CherryPick, ws.PickOid = true, ""
+	case cmd == "pick" || cmd == "p" || cmd == "revert":
+		ws.Revert, ws.RevertOid = true, ""
+	}
+
+	if ws.Interactive {
+		ws.Done = readTodoList(path("rebase-merge/done"))
+		ws.Todo = readTodoList(path("rebase-merge/git-rebase-todo"))
+		// git names it from the top level of the worktree.
+		ws.DoneFile = filepath.ToSlash(path("rebase-merge/done"))
+		if rel, err := filepath.Rel(root, ws.DoneFile); err == nil && !strings.HasPrefix(rel, "..") {
+			ws.DoneFile = filepath.ToSlash(rel)
+		}
+	}
+	if ws.Rebase && rs.Detached && rs.Modified > 0 {
+		// A commit reset to be split: HEAD moved off the commit marked
+		// for amending, or ORIG_HEAD off the one the rebase recorded.
+		amend, orig := line("rebase-merge/amend"), line("rebase-merge/orig-head")
+		switch {
+		case amend == "" || orig == "":
+		case amend == orig:
+			ws.Split = rs.Oid != amend
+		default:
+			ws.Split = line("ORIG_HEAD") != orig
+		}
+	}
+	if rs.Detached {
+		ws.DetachedFrom, ws.DetachedAt = detachedFrom(root, path("logs/HEAD"), rs.Oid)
+	}
+	return ws
+}
+
+// stateBranch reads a branch git recorded for an operation the way git
+// reports it: without refs/heads/, a hash shortened, and nothing for a
+// detached HEAD.
+func stateBranch(s string) string {
+	switch {
+	case strings.HasPrefix(s, "refs/heads/"):
+		return strings.TrimPrefix(s, "refs/heads/")
+	case s == "detached HEAD":
+		return ""
+	case isFullOid(s):
+		return shortOid(s)
+	}
+	return s
+}
+
+// isFullOid reports whether s is a whole SHA-1 or SHA-256 hash.
+func isFullOid(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	return strings.Trim(s, "0123456789abcdef") == ""
+}
+
+// readTodoList reads the commands of a rebase todo list, with their
+// hashes shortened, as `git status` lists them.
+func readTodoList(file string) []string {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	var cmds []string
+	for _, l := range strings.Split(string(data), "\n") {
+		l = strings.TrimSpace(l)
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		if f := strings.SplitN(l, " ", 3); len(f) >= 2 && len(f[1]) > 7 && strings.Trim(f[1], "0123456789abcdef") == "" {
+			f[1] = shortOid(f[1])
+			l = strings.Join(f, " ")
+		}
+		cmds = append(cmds, l)
+	}
+	return cmds
+}
+
+// detachedFrom reads what HEAD was last checked out as from its reflog,
+// as `git status` names it: a tag or remote-tracking branch that still
+// points there, or else the commit.  at reports whether HEAD, at oid, is
+// still there.  from is "" without such a checkout.
+func detachedFrom(root, reflog, oid string) (from string, at bool) {
+	data, err := os.ReadFile(reflog)
+	if err != nil {
+		return "", false
+	}
+	entries := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	for i := len(entries) - 1; i >= 0; i-- {
+		ids, msg, ok := strings.Cut(entries[i], "\t")
+		moved, found := strings.CutPrefix(msg, "checkout: moving from ")
+		f := strings.Fields(ids)
+		if !ok || !found || len(f) < 2 {
+			continue
+		}
+		_, target, ok := strings.Cut(moved, " to ")
+		if !ok {
+			continue
+		}
+		to := f[1]
+		from = shortOid(to)
+		if name := checkoutRef(root, target, to); name != "" {
+			from = name
+		}
+		return from, to == oid
+	}
+	return "", false
+}
+
+// checkoutRef is the short name of the one ref name resolves to, as git
+// reads a name given to checkout, when it still points at oid.
+func checkoutRef(root, name, oid string) string {
+	if name == "HEAD" {
+		return ""
+	}
+	repo, _, err := openNative(root)
+	if err != nil {
+		return ""
+	}
+	var found []*plumbing.Reference
+	for _, rule := range []string{"refs/%s", "refs/tags/%s", "refs/heads/%s", "refs/remotes/%s", "refs/remotes/%s/HEAD"} {
+		if ref, err := repo.Reference(plumbing.ReferenceName(fmt.Sprintf(rule, name)), true); err == nil {
+			found = append(found, ref)
+		}
+	}
+	if len(found) != 1 {
+		return ""
+	}
+	hash := found[0].Hash()
+	if tag, err := repo.TagObject(hash); err == nil {
+		if c, err := tag.Commit(); err == nil {
+			hash = c.Hash
+		}
+	}
+	if hash.String() != oid {
+		return ""
+	}
+	ref := found[0].Name().String()
+	if short, ok := strings.CutPrefix(ref, "refs/tags/"); ok {
+		return short
+	}
+	return strings.TrimPrefix(ref, "refs/remotes/")
+}
+
+// long draws the state as `git status` does between the branch and the
+// changes; unmerged reports whether any path is.
+func (ws *repoState) long(unmerged bool) string {
+	var sb strings.Builder
+	hint := func(h string) { fmt.Fprintf(&sb, "  (%s)\n", h) }
+	rebasing := func(what, during string) {
+		if ws.Branch != "" {
+			fmt.Fprintf(&sb, "You are currently %s branch '%s' on '%s'.\n", what, ws.Branch, ws.Onto)
+		} else {
+			fmt.Fprintf(&sb, "You are currently %s.\n", during)
+		}
+	}
+	switch {
+	case ws.Merge:
+		if ws.Interactive {
+			ws.rebaseCommands(&sb)
+			sb.WriteString("\n")
+		}
+		if unmerged {
+			sb.WriteString("You have unmerged paths.\n")
+			hint(`fix conflicts and run "git commit"`)
+			hint(`use "git merge --abort" to abort the merge`)
+		} else {
+			sb.WriteString("All conflicts fixed but you are still merging.\n")
+			hint(`use "git commit" to conclude merge`)
+		}
+		sb.WriteString("\n")
+	case ws.AM:
+		sb.WriteString("You are in the middle of an am session.\n")
+		if ws.AMEmpty {
+			sb.WriteString("The current patch is empty.\n")
+		} else {
+			hint(`fix conflicts and then run "git am --continue"`)
+		}
+		hint(`use "git am --skip" to skip this patch`)
+		if ws.AMEmpty {
+			hint(`use "git am --allow-empty" to record this patch as an empty commit`)
+		}
+		hint(`use "git am --abort" to restore the original branch`)
+		sb.WriteString("\n")
+	case ws.Rebase:
+		ws.rebaseCommands(&sb)
+		switch {
+		case unmerged:
+			rebasing("rebasing", "rebasing")
+			hint(`fix conflicts and then run "git rebase --continue"`)
+			hint(`use "git rebase --skip" to skip this patch`)
+			hint(`use "git rebase --abort" to check out the original branch`)
+		case !ws.Interactive || ws.MergeMsg:
+			rebasing("rebasing", "rebasing")
+			hint(`all conflicts fixed: run "git rebase --continue"`)
+		case ws.Split:
+			rebasing("splitting a commit while rebasing", "splitting a commit during a rebase")
+			hint(`Once your working directory is clean, run "git rebase --continue"`)
+		default:
+			rebasing("editing a commit while rebasing", "editing a commit during a rebase")
+			hint(`use "git commit --amend" to amend the current commit`)
+			hint(`use "git rebase --continue" once you are satisfied with your changes`)
+		}
+		sb.WriteString("\n")
+	case ws.CherryPick:
+		ws.sequencing(&sb, "cherry-pick", "cherry-picking", ws.PickOid, unmerged)
+	case ws.Revert:
+		ws.sequencing(&sb, "revert", "reverting", ws.RevertOid, unmerged)
+	}
+	if ws.Bisect {
+		if ws.BisectFrom != "" {
+			fmt.Fprintf(&sb, "You are currently bisecting, started from branch '%s'.\n", ws.BisectFrom)
+		} else {
+			sb.WriteString("You are currently bisecting.\n")
+		}
+		hint(`use "git bisect reset" to get back to the original branch`)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// rebaseCommands lists the last commands an interactive rebase ran and
+// the next it will.
+func (ws *repoState) rebaseCommands(sb *strings.Builder) {
+	if !ws.Interactive {
+		return
+	}
+	const show = 2
+	if len(ws.Done) == 0 {
+		sb.WriteString("No commands done.\n")
+	} else {
+		if len(ws.Done) == 1 {
+			sb.WriteString("Last command done (1 command done):\n")
+		} else {
+			fmt.Fprintf(sb, "Last commands done (%d commands done):\n", len(ws.Done))
+		}
+		for _, c := range ws.Done[max(0, len(ws.Done)-show):] {
+			fmt.Fprintf(sb, "   %s\n", c)
+		}
+		if len(ws.Done) > show {
+			fmt.Fprintf(sb, "  (see more in file %s)\n", ws.DoneFile)
+		}
+	}
+	if len(ws.Todo) == 0 {
+		sb.WriteString("No commands remaining.\n")
+		return
+	}
+	if len(ws.Todo) == 1 {
+		sb.WriteString("Next command to do (1 remaining command):\n")
+	} else {
+		fmt.Fprintf(sb, "Next commands to do (%d remaining commands):\n", len(ws.Todo))
+	}
+	for _, c := range ws.Todo[:min(show, len(ws.Todo))] {
+		fmt.Fprintf(sb, "   %s\n", c)
+	}
+	sb.WriteString("  (use \"git rebase --edit-todo\" to view and edit)\n")
+}
+
+// sequencing draws a cherry-pick or revert in progress: cmd names the
+// command, doing what it does, and oid the commit, "" in a sequence.
+func (ws *repoState) sequencing(sb *strings.Builder, cmd, doing, oid string, unmerged bool) {
+	if oid == "" {
+		what := "Cherry-pick"
+		if cmd == "revert" {
+			what = "Revert"
+		}
+		fmt.Fprintf(sb, "%s currently in progress.\n", what)
+	} else {
+		fmt.Fprintf(sb, "You are currently %s commit %s.\n", doing, oid)
+	}
+	switch {
+	case unmerged:
+		fmt.Fprintf(sb, "  (fix conflicts and run \"git %s --continue\")\n", cmd)
+	case oid == "":
+		fmt.Fprintf(sb, "  (run \"git %s --continue\" to continue)\n", cmd)
+	default:
+		fmt.Fprintf(sb, "  (all conflicts fixed: run \"git %s --continue\")\n", cmd)
+	}
+	fmt.Fprintf(sb, "  (use \"git %s --skip\" to skip this patch)\n", cmd)
+	fmt.Fprintf(sb, "  (use \"git %s --abort\" to cancel the %s operation)\n", cmd, cmd)
+	sb.WriteString("\n")
+}
@@ -0,0 +1,168 @@
+// File: restore.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: guarded discard and unstage (`gits restore`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// underPaths reports whether the root-relative path p is one of, or inside
+// one of, the root-relative paths in filter (an empty filter matches all).
+func underPaths(p string, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		if f == "." || p == f || strings.HasPrefix(p, f+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// restorePaths runs `git restore` for root-relative paths, passing them on
+// stdin like stagePaths.
+func restorePaths(root string, staged bool, paths []string) error {
+	args := []string{"restore", "--pathspec-from-file=-", "--pathspec-file-nul"}
+	if staged {
+		args = append(args, "--staged")
+	}
+	cmd := gitCmd(root, args...)
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\x00"))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git restore: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// runRestore implements `gits restore [--staged] [--yes] [paths]`: pick
+// files, preview the diff that goes away, then discard worktree changes
+// (or unstage with --staged).
+func runRestore(status *Status, args []string) {
+	c := status.cfg.Colors
+	staged, yes := false, false
+	var paths []string
+	for _, a := range args {
+		switch a {
+		case "-S", "--staged":
+			staged = true
+		case "-y", "--yes":
+			yes = true
+		default:
+			paths = append(paths, a)
+		}
+	}
+	fail := func(err error) {
+		reportError(err, c)
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+		return
+	}
+	// Arguments are relative to the current directory, entries to the root.
+	prefix, _ := gitOutput(".", "rev-parse", "--show-prefix")
+	for i, p := range paths {
+		paths[i] = filepath.ToSlash(filepath.Clean(filepath.Join(prefix, p)))
+	}
+	rs := CollectStatus(root)
+	if rs.Err != "" {
+		fail(fmt.Errorf("%s", rs.Err))
+		return
+	}
+
+	var entries []FileEntry
+	var items []pickItem
+	for _, e := range rs.Entries {
+		if (staged && !e.Staged()) || (!staged && !e.Unstaged()) || !underPaths(e.Path, paths) {
+			continue
+		}
+		entries = append(entries, e)
+		items = append(items, pickItem{
+			Tag:      entryCode(e),
+			TagStyle: entryStyle(e, c),
+			Label:    entryLabel(e),
+			Checked:  len(paths) > 0,
+		})
+	}
+	what := "worktree changes"
+	if staged {
+		what = "staged changes"
+	}
+	if len(items) == 0 {
+		fmt.Printf("%s %sNo %s to restore%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), what, Reset)
+		return
+	}
+
+	var selected []string
+	if yes && len(paths) > 0 {
+		for _, e := range entries {
+			selected = append(selected, e.Path)
+		}
+	} else {
+		title := "Discard changes in " + displayPath(root)
+		if staged {
+			title = "Unstage files in " + displayPath(root)
+		}
+		checked, ok, err := runPicker(title, items, c)
+		if err != nil {
+			fail(err)
+			return
+		}
+		if !ok {
+			fmt.Printf("%s Cancelled, nothing restored\n", Icons.INFO)
+			return
+		}
+		for i, v := range checked {
+			if v {
+				selected = append(selected, entries[i].Path)
+				if staged && entries[i].OrigPath != "" {
+					// Unstage both halves of a staged rename.
+					selected = append(selected, entries[i].OrigPath)
+				}
+			}
+		}
+	}
+	if len(selected) == 0 {
+		fmt.Printf("%s Nothing selected\n", Icons.INFO)
+		return
+	}
+
+	diffArgs := []string{"diff", "--no-color", "--no-ext-diff"}
+	if staged {
+		diffArgs = append(diffArgs, "--cached")
+	}
+	if patch, err := gitRaw(root, append(append(diffArgs, "--"), selected...)...); err == nil && patch != "" {
+		fmt.Print(renderDiff(patch, c))
+		fmt.Println()
+	}
+
+	// Unstaging keeps the changes in the worktree; only discarding loses work.
+	if !staged && !yes {
+		ok, err := confirm(fmt.Sprintf("Discard the changes above in %d file(s)? This cannot be undone", len(selected)))
+		if err != nil {
+			fail(fmt.Errorf("%v (use --yes)", err))
+			return
+		}
+		if !ok {
+			return
+		}
+	}
+	if err := restorePaths(root, staged, selected); err != nil {
+		fail(err)
+		return
+	}
+	done := "Discarded changes in"
+	if staged {
+		done = "Unstaged"
+	}
+	fmt.Printf("%s %s%s %d file(s)%s\n\n", Icons.SUCCESS, resolveColor(c.UpToDate), done, len(selected), Reset)
+	status.ColorizeGitStatus(root, "")
+}
@@ -0,0 +1,42 @@
+// File: safedir.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: --trust: adding a repository owned by someone else to safe.directory
+// License: MIT
+
+package main
+
+import "fmt"
+
+// trustRepos is set by --trust: a repository git refuses for its owner
+// (a container, a mounted volume, a checkout made by another user) is
+// added to safe.directory, and the command is run again.
+var trustRepos bool
+
+// safeDirectoryCommand is the command that makes git trust path.
+func safeDirectoryCommand(path string) string {
+	return "git config --global --add safe.directory " + shellQuote(path)
+}
+
+// trustDirectory adds path to the global safe.directory once the user
+// agrees; without a terminal --trust itself is the consent.  It reports
+// whether path is now trusted.
+func trustDirectory(path string, c ColorConfig) bool {
+	ok, err := confirm(fmt.Sprintf("Trust %s, which belongs to another user?", displayPath(path)))
+	switch {
+	case err == errNotTerminal:
+		ok = true
+	case err != nil:
+		return false
+	}
+	if !ok {
+		return false
+	}
+	if _, err := gitOutput("", "config", "--global", "--add", "safe.directory", path); err != nil {
+		fmt.Printf("%s %s%v%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), err, Reset)
+		return false
+	}
+	fmt.Printf("%s %sTrusted %s%s %s(safe.directory in the global git config)%s\n",
+		Icons.SUCCESS, resolveColor(c.UpToDate), displayPath(path), Reset, Dim, Reset)
+	return true
+}
@@ -0,0 +1,359 @@
+// File: scan.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: multi-repository scanning (`gits scan`)
+// License: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// skipScanDirs are directory names never descended into while discovering
+// repositories — they are huge and never contain repos worth reporting.
+var skipScanDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	".cache":       true,
+	"__pycache__":  true,
+	".venv":        true,
+	"venv":         true,
+	"target":       true,
+}
+
+// GroupConfig is a config-defined set of repositories ([group.<name>])
+// with scan defaults applied whenever the group is targeted.
+type GroupConfig struct {
+	Repos     []string `toml:"repos"`      // paths, directories to walk, or @tags/@groups
+	Depth     int      `toml:"depth"`      // discovery depth for directories (0 = scan default)
+	DirtyOnly bool     `toml:"dirty_only"` // behave as if --dirty-only was given
+	Fetch     bool     `toml:"fetch"`      // behave as if --fetch was given
+	StaleDays int      `toml:"stale_days"` // flag repos whose last commit is older than this
+}
+
+// resolveScanTargets turns scan arguments into a de-duplicated, sorted list
+// of repository paths.  "@name" selects a config group, or registered
+// repositories by tag/name; anything else is a directory to walk.  With no
+// targets the whole registry is used, falling back to the current
+// directory when the registry is empty.
+func resolveScanTargets(groups map[string]GroupConfig, targets []string, maxDepth int) ([]string, error) {
+	reg, err := LoadRegistry()
+	if err != nil {
+		return nil, err
+	}
+	discovery := loadDiscoveryCache()
+	defer discovery.save()
+
+	if len(targets) == 0 {
+		if len(reg.Repos) > 0 {
+			targets = []string{"@all"}
+		} else {
+			targets = []string{"."}
+		}
+	}
+
+	seen := map[string]bool{}
+	var repos []string
+	add := func(p string) {
+		if !seen[p] {
+			seen[p] = true
+			repos = append(repos, p)
+		}
+	}
+
+	var expand func(t string, depth, nesting int) error
+	expand = func(t string, depth, nesting int) error {
+		if strings.HasPrefix(t, "@") {
+			if g, ok := groups[t[1:]]; ok {
+				if nesting > 8 {
+					return fmt.Errorf("group %s: groups nested too deeply", t)
+				}
+				if g.Depth > 0 {
+					depth = g.Depth
+				}
+				for _, member := range g.Repos {
+					if err := expand(member, depth, nesting+1); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			entries := reg.Select(t)
+			if len(entries) == 0 {
+				return fmt.Errorf("no group or registered repositories match %s", t)
+			}
+			for _, e := range entries {
+				add(e.Path)
+			}
+			return nil
+		}
+		dir := expandHome(t)
+		if !IsDir(dir) {
+			return fmt.Errorf("%s is not a directory", t)
+		}
+		for _, r := range discovery.discoverRepos(dir, depth) {
+			add(r)
+		}
+		return nil
+	}
+
+	for _, t := range targets {
+		if err := expand(t, maxDepth, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(repos)
+	return repos, nil
+}
+
+// fillStaleness records the last commit time of every result that has a
+// staleness threshold and marks it stale when older than that.
+func fillStaleness(results []*RepoStatus, staleDays map[string]int) {
+	parallelEach(len(results), func(i int) {
+		r := results[i]
+		days, ok := staleDays[r.Path]
+		if !ok || r.Err != "" {
+			return
+		}
+		out, err := gitOutput(r.Path, "log", "-1", "--format=%ct")
+		if err != nil || out == "" {
+			return
+		}
+		if ts, err := strconv.ParseInt(out, 10, 64); err == nil {
+			r.LastCommit = time.Unix(ts, 0)
+			r.Stale = time.Since(r.LastCommit) > time.Duration(days)*24*time.Hour
+		}
+	})
+}
+
+// ---------------------------------------------------------------------------
+// `gits scan` subcommand
+// ---------------------------------------------------------------------------
+
+// runScan implements `gits scan [@tag|dir]... [--depth N] [--full]
+// [--dirty-only] [--json] [--cached] [--go] [--stale DAYS] [--refresh]
+// [--fetch [--fetch-jobs N] [--fetch-timeout DUR]]`.  Results are shown as a dashboard table unless
+// --full asks for the complete colorized status of every repository, or
+// --json for a single machine-readable report.  --cached shows the latest
+// results written by `gits daemon` without touching any repository.
+func runScan(status *Status, args []string) {
+	c := status.cfg.Colors
+	maxDepth := 6
+	full := false
+	dirtyOnly := false
+	asJSON := false
+	cached := false
+	staleFlag := 0
+	doFetch := false
+	fetchJobs := 8
+	fetchTimeout := 30 * time.Second
+	var targets []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--full":
+			full = true
+		case "--dirty-only", "--dirty":
+			dirtyOnly = true
+		case "--json":
+			asJSON = true
+		case "--cached":
+			cached = true
+		case "--refresh":
+			forceRediscovery = true
+		case "--fetch":
+			doFetch = true
+		case "--fetch-jobs":
+			if i+1 < len(args) {
+				i++
+				fmt.Sscanf(args[i], "%d", &fetchJobs)
+			}
+		case "--fetch-timeout":
+			if i+1 < len(args) {
+				i++
+				fetchTimeout = parseDurationOr(args[i], fetchTimeout)
+			}
+		case "--go":
+			targets = append(targets, goScanTargets()...)
+		case "--depth":
+			if i+1 < len(args) {
+				i++
+				fmt.Sscanf(args[i], "%d", &maxDepth)
+			}
+		case "--stale":
+			if i+1 < len(args) {
+				i++
+				fmt.Sscanf(args[i], "%d", &staleFlag)
+			}
+		default:
+			targets = append(targets, args[i])
+		}
+	}
+
+	// Targeted config groups contribute their defaults.
+	groups := status.cfg.Groups
+	staleDays := map[string]int{}
+	for _, t := range targets {
+		g, ok := groups[strings.TrimPrefix(t, "@")]
+		if !ok || !strings.HasPrefix(t, "@") {
+			continue
+		}
+		if g.DirtyOnly {
+			dirtyOnly = true
+		}
+		if g.Fetch {
+			doFetch = true
+		}
+		if g.StaleDays > 0 && !cached {
+			members, _ := resolveScanTargets(groups, []string{t}, maxDepth)
+			for _, m := range members {
+				staleDays[m] = g.StaleDays
+			}
+		}
+	}
+
+	var repos []string
+	var results []*RepoStatus
+	var report *FleetReport
+	var err error
+
+	if cached {
+		// Serve the daemon's latest results instead of scanning.
+		report, err = readScanCache()
+		if err == nil {
+			results = report.Repos
+			for _, r := range results {
+				repos = append(repos, r.Path)
+			}
+		}
+	} else {
+		repos, err = resolveScanTargets(groups, targets, maxDepth)
+	}
+	if err != nil {
+		if asJSON {
+			fmt.Fprintf(os.Stderr, "gits scan: %v\n", err)
+			exit(1)
+		}
+		exitWithError(err, c)
+	}
+	if len(repos) == 0 && !asJSON {
+		fmt.Printf("%s %sNo git repositories found%s\n", Icons.WARNING, Bold+resolveColor(c.AheadBehind), Reset)
+		return
+	}
+
+	if !cached {
+		var fetchErrs map[string]error
+		if doFetch {
+			fetchErrs = fetchAll(repos, fetchJobs, fetchTimeout)
+		}
+		results = collectAll(repos)
+		for _, r := range results {
+			if err := fetchErrs[r.Path]; err != nil {
+				r.FetchErr = err.Error()
+			}
+		}
+		if staleFlag > 0 {
+			for _, r := range repos {
+				if _, ok := staleDays[r]; !ok {
+					staleDays[r] = staleFlag
+				}
+			}
+		}
+		if len(staleDays) > 0 {
+			fillStaleness(results, staleDays)
+		}
+		if asJSON {
+			// The aggregate always covers every scanned repository;
+			// --dirty-only only trims the per-repo list below.
+			fillUnpushed(results)
+			report = buildFleetReport(append([]*RepoStatus(nil), results...))
+		}
+	}
+
+	hidden := 0
+	if dirtyOnly {
+		kept := results[:0]
+		for _, r := range results {
+			if r.NeedsAttention() {
+				kept = append(kept, r)
+			}
+		}
+		hidden = len(results) - len(kept)
+		results = kept
+	}
+
+	if asJSON {
+		report.Repos = results
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	summary := fmt.Sprintf("%d repositories scanned", len(repos))
+	if cached {
+		summary = fmt.Sprintf("%d repositories, cached %s ago", len(repos),
+			time.Since(report.GeneratedAt).Round(time.Second))
+	}
+	if dirtyOnly {
+		summary += fmt.Sprintf(", %d clean hidden", hidden)
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("%s %sEverything is committed and pushed%s (%s)\n",
+			Icons.SUCCESS, resolveColor(c.UpToDate), Reset, summary)
+		return
+	}
+
+	defer timings.phase("render", time.Now())
+	if !full {
+		status.renderDashboard(results)
+		status.printFetchErrors(results)
+		fmt.Printf("\n%s %s\n", Icons.INFO, summary)
+		return
+	}
+
+	renderer, err := newRenderer(status.format, os.Stdout, status.cfg)
+	if err != nil {
+		exitWithError(err, c)
+	}
+	for i, r := range results {
+		if i > 0 {
+			fmt.Println()
+		}
+		if !isGitDir(r.Path) {
+			fmt.Printf("%s %s%s (missing)%s\n", Icons.WARNING, Bold+resolveColor(c.Deleted), r.Path, Reset)
+			continue
+		}
+		// The status the dashboard was drawn from, not read again.
+		status.renderReport(renderer, status.collector().ReportOf(r))
+	}
+	status.printFetchErrors(results)
+	fmt.Printf("\n%s %s\n", Icons.INFO, summary)
+}
+
+// printFetchErrors lists repositories that could not be fetched; their
+// ahead/behind numbers may be stale.
+func (s *Status) printFetchErrors(results []*RepoStatus) {
+	c := s.cfg.Colors
+	first := true
+	for _, r := range results {
+		if r.FetchErr == "" {
+			continue
+		}
+		if first {
+			fmt.Printf("\n%s %sNot fetched (ahead/behind may be stale):%s\n",
+				Icons.WARNING, Bold+resolveColor(c.AheadBehind), Reset)
+			first = false
+		}
+		fmt.Printf("   %s%s%s %s— %s%s\n", Bold+resolveColor(c.CwdPath), displayPath(r.Path), Reset,
+			Dim, r.FetchErr, Reset)
+	}
+}
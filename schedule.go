@@ -0,0 +1,152 @@
+// File: schedule.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: minimal cron-style schedule parsing for `gits daemon`
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule computes the next activation time after a given instant.
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// everySchedule fires at a fixed interval ("@every 10m").
+type everySchedule time.Duration
+
+func (e everySchedule) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(e))
+}
+
+// cronSchedule is a classic five-field spec: minute hour day-of-month month
+// day-of-week.  Each field is a set of allowed values.
+type cronSchedule struct {
+	minute, hour, dom, month, dow map[int]bool
+	domStar, dowStar              bool
+}
+
+// Next returns the first whole minute after `after` matching the spec.
+func (c *cronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	// A year of minutes is the longest any valid spec needs to search.
+	for i := 0; i < 366*24*60; i++ {
+		if c.month[int(t.Month())] && c.hour[t.Hour()] && c.minute[t.Minute()] && c.dayMatches(t) {
+			return t
+		}
+		t = t.Add(time.Minute)
+	}
+	return time.Time{}
+}
+
+// dayMatches follows cron's rule: when both day fields are restricted, a
+// day matching either of them qualifies.
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	dom, dow := c.dom[t.Day()], c.dow[int(t.Weekday())]
+	switch {
+	case c.domStar && c.dowStar:
+		return true
+	case c.domStar:
+		return dow
+	case c.dowStar:
+		return dom
+	}
+	return dom || dow
+}
+
+var scheduleAliases = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// parseSchedule accepts "@every <duration>", the @hourly/@daily/@weekly/
+// @monthly aliases, or a five-field cron expression supporting *, lists,
+// ranges and steps (e.g. "*/15 8-18 * * 1-5").
+func parseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(spec[len("@every "):]))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid interval in %q", spec)
+		}
+		return everySchedule(d), nil
+	}
+	if alias, ok := scheduleAliases[spec]; ok {
+		spec = alias
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule %q: expected 5 fields (min hour dom month dow)", spec)
+	}
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	sets := make([]map[int]bool, 5)
+	for i, f := range fields {
+		set, err := parseCronField(f, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", spec, err)
+		}
+		sets[i] = set
+	}
+	// Sunday may be written as 0 or 7.
+	if sets[4][7] {
+		sets[4][0] = true
+	}
+	return &cronSchedule{
+		minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4],
+		domStar: fields[2] == "*", dowStar: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField expands one comma-separated cron field into its values.
+func parseCronField(field string, lo, hi int) (map[int]bool, error) {
+	set := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("bad step in %q", part)
+			}
+			step = n
+			part = part[:i]
+		}
+		from, to := lo, hi
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			ends := strings.SplitN(part, "-", 2)
+			a, err1 := strconv.Atoi(ends[0])
+			b, err2 := strconv.Atoi(ends[1])
+			if err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("bad range %q", part)
+			}
+			from, to = a, b
+		default:
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("bad value %q", part)
+			}
+			from = n
+			if step == 1 {
+				to = n
+			}
+		}
+		if from < lo || to > hi || from > to {
+			return nil, fmt.Errorf("%q out of range %d-%d", part, lo, hi)
+		}
+		for v := from; v <= to; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
@@ -0,0 +1,285 @@
+// File: selfupdate.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: `gits self-update`: install the latest release over the running binary
+// License: MIT
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// version is the release gits was built from, set by the release workflow
+// with -ldflags "-X main.version=...".  A build from source is "dev", and
+// does not update itself.
+var version = "dev"
+
+// latestReleaseAPI answers with the latest release of gits.
+const latestReleaseAPI = "https://api.github.com/repos/cumulus13/gits-go/releases/latest"
+
+// updateClient downloads releases; a binary is a few MB.
+var updateClient = &http.Client{Timeout: 5 * time.Minute}
+
+// ghRelease is the part of a GitHub release self-update reads.
+type ghRelease struct {
+	TagName string         `json:"tag_name"`
+	HTMLURL string         `json:"html_url"`
+	Assets  []releaseAsset `json:"assets"`
+}
+
+type releaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+// asset returns the release file called name.
+func (r *ghRelease) asset(name string) (releaseAsset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return releaseAsset{}, false
+}
+
+// binaryName is the name of the build for this platform in release v, as
+// the release workflow names it: gits_1.0.8_linux_amd64.
+func binaryName(v string) string {
+	name := fmt.Sprintf("gits_%s_%s_%s", v, runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// versionParts reads "v1.2.3" or "1.2.3-rc1" as [1 2 3]; what is not a
+// number counts as 0.
+func versionParts(v string) []int {
+	v, _, _ = strings.Cut(strings.TrimPrefix(v, "v"), "-")
+	var parts []int
+	for _, f := range strings.Split(v, ".") {
+		n, _ := strconv.Atoi(f)
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// newerVersion reports whether version a comes after b.
+func newerVersion(a, b string) bool {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := range max(len(pa), len(pb)) {
+		x, y := 0, 0
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return false
+}
+
+// updateGet fetches url, failing on any answer but 200 OK.  GITHUB_TOKEN,
+// when set, is sent to the GitHub API only.
+func updateGet(url string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "gits-go/"+version)
+	if strings.HasPrefix(url, "https://api.github.com/") {
+		req.Header.Set("Accept", "application/vnd.github+json")
+		if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+	resp, err := updateClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+			return nil, fmt.Errorf("%s: %s (rate limited? set GITHUB_TOKEN)", url, resp.Status)
+		}
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return resp, nil
+}
+
+// latestRelease asks GitHub for the latest release.
+func latestRelease() (*ghRelease, error) {
+	resp, err := updateGet(latestReleaseAPI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var rel ghRelease
+	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+		return nil, fmt.Errorf("reading the latest release: %w", err)
+	}
+	if rel.TagName == "" {
+		return nil, errors.New("the latest release has no tag")
+	}
+	return &rel, nil
+}
+
+// releaseChecksum reads the SHA-256 of name from the release's
+// checksums.txt, written by sha256sum.
+func releaseChecksum(rel *ghRelease, name string) (string, error) {
+	sums, ok := rel.asset("checksums.txt")
+	if !ok {
+		return "", fmt.Errorf("release %s has no checksums.txt to verify %s against", rel.TagName, name)
+	}
+	resp, err := updateGet(sums.URL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		// "<hex>  name", or "<hex> *name" for binary mode.
+		sum, file, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if ok && strings.TrimLeft(file, " *") == name && len(sum) == sha256.Size*2 {
+			return strings.ToLower(sum), nil
+		}
+	}
+	return "", fmt.Errorf("checksums.txt of %s does not list %s", rel.TagName, name)
+}
+
+// downloadVerified writes asset to f and checks it against the SHA-256
+// want, so a truncated or corrupted download is never installed.  want
+// comes from the same release, unsigned: it does not stand up to a
+// compromised release or account.
+func downloadVerified(asset releaseAsset, f *os.File, want string) error {
+	resp, err := updateGet(asset.URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), resp.Body)
+	if err != nil {
+		return fmt.Errorf("downloading %s: %w", asset.Name, err)
+	}
+	if asset.Size > 0 && n != asset.Size {
+		return fmt.Errorf("downloading %s: got %d of %d bytes", asset.Name, n, asset.Size)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		return fmt.Errorf("%s does not match its checksum (sha256 %s, expected %s); nothing was installed", asset.Name, got, want)
+	}
+	return f.Sync()
+}
+
+// runSelfUpdate implements `gits self-update [--check]`: it compares the
+// running gits with the latest GitHub release and, unless --check, puts
+// that release's build for this platform in its place once its checksum
+// is verified.  The executable is swapped in one rename, so an
+// interrupted update leaves the old one working.
+func runSelfUpdate(status *Status, args []string) {
+	c := status.cfg.Colors
+	check := false
+	for _, a := range args {
+		switch a {
+		case "--check":
+			check = true
+		default:
+			exitWithError(fmt.Errorf("usage: gits self-update [--check]"), c)
+		}
+	}
+	if version == "dev" {
+		exitWithError(errors.New("this gits was built from source, not from a release: update it with git pull and go build, or install a release from https://github.com/cumulus13/gits-go/releases"), c)
+	}
+
+	exe, err := os.Executable()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		exitWithError(fmt.Errorf("cannot find the gits executable: %w", err), c)
+	}
+	// What Windows had to leave behind last time.
+	os.Remove(exe + ".old")
+
+	rel, err := latestRelease()
+	if err != nil {
+		exitWithError(err, c)
+	}
+	latest := strings.TrimPrefix(rel.TagName, "v")
+	if !newerVersion(latest, version) {
+		fmt.Printf("%s %sgits %s is the latest release%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), version, Reset)
+		return
+	}
+	name := binaryName(latest)
+	asset, ok := rel.asset(name)
+	if !ok {
+		exitWithError(fmt.Errorf("gits %s has no build for %s/%s (%s); see %s", latest, runtime.GOOS, runtime.GOARCH, name, rel.HTMLURL), c)
+	}
+	fmt.Printf("%s gits %s%s%s is out, %s is installed %s(%s)%s\n",
+		Icons.INFO, Bold, latest, Reset, version, Dim, rel.HTMLURL, Reset)
+	if strings.Contains(strings.ToLower(filepath.ToSlash(exe)), "/scoop/apps/") {
+		fmt.Printf("   %sgits was installed by scoop: update it with%s scoop update gits\n", Dim, Reset)
+		return
+	}
+	want, err := releaseChecksum(rel, name)
+	if err != nil {
+		exitWithError(err, c)
+	}
+	// Nothing signs checksums.txt, so say what the check is worth before
+	// anything is installed on its word.
+	fmt.Printf("   %sintegrity is checksum-only: checksums.txt comes unsigned from the same release, so it catches a corrupted download, not a tampered release%s\n", Dim, Reset)
+	if check {
+		fmt.Printf("   %swould replace %s with %s (%s, sha256 %s…)%s\n", Dim, exe, name, humanSize(asset.Size), want[:12], Reset)
+		return
+	}
+	info, err := os.Stat(exe)
+	if err != nil {
+		exitWithError(err, c)
+	}
+	// Next to the executable, so the rename stays on one file system.
+	f, err := os.CreateTemp(filepath.Dir(exe), ".gits-update-*")
+	if err != nil {
+		exitWithError(fmt.Errorf("cannot write next to %s: %w", exe, err), c)
+	}
+	tmp := f.Name()
+	release := onInterrupt(func() { os.Remove(tmp) })
+	defer release()
+
+	fmt.Printf("   %sdownloading %s (%s)%s\n", Dim, name, humanSize(asset.Size), Reset)
+	err = downloadVerified(asset, f, want)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, info.Mode().Perm())
+	}
+	if err == nil {
+		err = replaceExecutable(tmp, exe)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		exitWithError(err, c)
+	}
+	fmt.Printf("%s %sgits %s → %s%s %s(sha256 %s… matches checksums.txt, %s)%s\n",
+		Icons.SUCCESS, resolveColor(c.UpToDate), version, latest, Reset, Dim, want[:12], exe, Reset)
+}
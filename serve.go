@@ -0,0 +1,502 @@
+// File: serve.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: `gits serve`: statuses kept warm by file watching, answered over a JSON-RPC socket or HTTP
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// serveDebounce is how long the server waits for a burst of changes to a
+// repository to settle before dropping its cached status.  It is short
+// because until then the old status is served.
+const serveDebounce = 50 * time.Millisecond
+
+// serveMaxWatched caps the repositories the server watches, each with a
+// watch per directory.  Past it, statuses are read on every request.
+const serveMaxWatched = 128
+
+// serveEntry is the cached status of one repository.  gen counts the
+// changes seen, so a status read while the worktree changed is not kept.
+type serveEntry struct {
+	status   *RepoStatus
+	gen      int
+	watching bool
+}
+
+// statusServer answers status, summary and scan requests from a cache that
+// file watching keeps current.
+type statusServer struct {
+	cfg     AppConfig
+	roots   []string // what may be asked about, symlinks resolved
+	targets []string // what a scan without targets reads: the roots given, or the registry
+	mu      sync.Mutex
+	repos   map[string]*serveEntry
+
+	hits, reads, watched int
+}
+
+// newStatusServer returns a server for the repositories below roots.  With
+// no roots, those are the registered repositories and the directories of
+// the configured groups, or else the directory the server starts in.
+func newStatusServer(cfg AppConfig, roots []string) *statusServer {
+	s := &statusServer{cfg: cfg, repos: map[string]*serveEntry{}, targets: roots}
+	if len(roots) == 0 {
+		if reg, err := LoadRegistry(); err == nil {
+			for _, e := range reg.Repos {
+				roots = append(roots, e.Path)
+			}
+		}
+		for _, g := range cfg.Groups {
+			for _, m := range g.Repos {
+				if !strings.HasPrefix(m, "@") {
+					roots = append(roots, expandHome(m))
+				}
+			}
+		}
+	}
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+	for _, r := range roots {
+		s.roots = append(s.roots, physicalPath(r))
+	}
+	return s
+}
+
+// physicalPath is the absolute form of p with every symlink resolved, so
+// that a link cannot lead out of a root.
+func physicalPath(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		p = abs
+	}
+	if real, err := filepath.EvalSymlinks(p); err == nil {
+		return real
+	}
+	return p
+}
+
+// serves reports whether path is below one of the server's roots.
+func (s *statusServer) serves(path string) bool {
+	path = physicalPath(path)
+	for _, r := range s.roots {
+		if rel, err := filepath.Rel(r, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// watch starts dropping the cached status of root whenever it changes.
+// Repositories that cannot be watched are read on every request.
+func (s *statusServer) watch(root string, e *serveEntry) {
+	// Our own git runs must not write the index, or every read would look
+	// like a change.
+	primeStatusAccel(root)
+	s.mu.Lock()
+	full := s.watched >= serveMaxWatched
+	if !full {
+		s.watched++
+	}
+	s.mu.Unlock()
+	if full {
+		if debugMode {
+			fmt.Fprintf(os.Stderr, "serve: not watching %s: already watching %d repositories\n", root, serveMaxWatched)
+		}
+		return
+	}
+	changes, err := watchNotify(root, serveDebounce)
+	if err != nil {
+		if debugMode {
+			fmt.Fprintf(os.Stderr, "serve: not watching %s: %v\n", root, err)
+		}
+		s.mu.Lock()
+		s.watched--
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Lock()
+	e.watching = true
+	s.mu.Unlock()
+	go func() {
+		for range changes {
+			s.mu.Lock()
+			e.status = nil
+			e.gen++
+			s.mu.Unlock()
+		}
+	}()
+}
+
+// status returns the status of the repository holding path, from the cache
+// while nothing in it has changed.  That repository must itself lie below
+// a root: a directory under one may belong to a repository above it.
+func (s *statusServer) status(path string) (*RepoStatus, error) {
+	if !s.serves(path) {
+		return nil, fmt.Errorf("%s is %w", path, errForbidden)
+	}
+	root, _, ok := enclosingRepo(path)
+	if !ok {
+		return nil, fmt.Errorf("%s is %w", path, errNotRepo)
+	}
+	if !s.serves(root) {
+		return nil, fmt.Errorf("%s is in %s, %w", path, root, errForbidden)
+	}
+	s.mu.Lock()
+	e, known := s.repos[root]
+	if !known {
+		e = &serveEntry{}
+		s.repos[root] = e
+	}
+	if e.status != nil {
+		s.hits++
+		rs := e.status
+		s.mu.Unlock()
+		return rs, nil
+	}
+	gen := e.gen
+	s.reads++
+	s.mu.Unlock()
+
+	if !known {
+		s.watch(root, e)
+	}
+	rs := CollectStatus(root)
+	if rs.Err != "" {
+		return nil, errors.New(rs.Err)
+	}
+	s.mu.Lock()
+	if e.watching && e.gen == gen {
+		e.status = rs
+	}
+	s.mu.Unlock()
+	return rs, nil
+}
+
+// serveSummary is the answer to summary: the counts of a status without
+// its entries, and the line `gits prompt` would print.
+type serveSummary struct {
+	Path      string `json:"path"`
+	Branch    string `json:"branch"`
+	Upstream  string `json:"upstream,omitempty"`
+	Ahead     int    `json:"ahead"`
+	Behind    int    `json:"behind"`
+	Staged    int    `json:"staged"`
+	Modified  int    `json:"modified"`
+	Untracked int    `json:"untracked"`
+	Conflicts int    `json:"conflicts"`
+	Stashes   int    `json:"stashes"`
+	Line      string `json:"line"`
+}
+
+func (s *statusServer) summary(path string) (*serveSummary, error) {
+	rs, err := s.status(path)
+	if err != nil {
+		return nil, err
+	}
+	return &serveSummary{Path: rs.Path, Branch: rs.Branch, Upstream: rs.Upstream, Ahead: rs.Ahead, Behind: rs.Behind,
+		Staged: rs.Staged, Modified: rs.Modified, Untracked: rs.Untracked, Conflicts: rs.Conflicts,
+		Stashes: rs.Stashes, Line: promptSummary(rs)}, nil
+}
+
+// scan returns the status of every repository in targets, like `gits
+// scan`: directories to walk below the server's roots or @groups, the
+// registry when there are none.
+func (s *statusServer) scan(targets []string) ([]*RepoStatus, error) {
+	for _, t := range targets {
+		if !strings.HasPrefix(t, "@") && !s.serves(expandHome(t)) {
+			return nil, fmt.Errorf("%s is %w", t, errForbidden)
+		}
+	}
+	if len(targets) == 0 {
+		targets = s.targets
+	}
+	repos, err := resolveScanTargets(s.cfg.Groups, targets, 6)
+	if err != nil {
+		return nil, err
+	}
+	// Groups may name repositories outside the roots.
+	repos = slices.DeleteFunc(repos, func(r string) bool { return !s.serves(r) })
+	results := make([]*RepoStatus, len(repos))
+	parallelEach(len(repos), func(i int) {
+		rs, err := s.status(repos[i])
+		if err != nil {
+			rs = &RepoStatus{Path: repos[i], Err: err.Error()}
+		}
+		results[i] = rs
+	})
+	return results, nil
+}
+
+// call dispatches one request by method name.  Only scan may go without a
+// path.
+func (s *statusServer) call(method, path string) (any, error) {
+	switch method {
+	case "status", "summary":
+		if path == "" {
+			return nil, errRPCParams
+		}
+		if method == "status" {
+			return s.status(path)
+		}
+		return s.summary(path)
+	case "scan":
+		var targets []string
+		if path != "" {
+			targets = []string{path}
+		}
+		return s.scan(targets)
+	}
+	return nil, errRPCMethod
+}
+
+var (
+	errRPCMethod = errors.New("method not found")
+	errRPCParams = errors.New("invalid params")
+	errNotRepo   = errors.New("not in a git repository")
+	errForbidden = errors.New("outside the directories this server serves")
+)
+
+const rpcParamsHint = `params must be {"path": DIR} or [DIR]`
+
+// rpcRequest is a JSON-RPC 2.0 request.  Params are {"path": ...} (or
+// {"root": ...} for scan) or a one-element array; scan takes none to scan
+// the registry.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcPath reads the path argument out of params, "" when there is none.
+func rpcPath(params json.RawMessage) (string, bool) {
+	if len(params) == 0 || string(params) == "null" {
+		return "", true
+	}
+	var named struct {
+		Path string `json:"path"`
+		Root string `json:"root"`
+	}
+	if json.Unmarshal(params, &named) == nil {
+		if named.Path != "" {
+			return named.Path, true
+		}
+		return named.Root, true
+	}
+	var list []string
+	if json.Unmarshal(params, &list) == nil && len(list) <= 1 {
+		if len(list) == 0 {
+			return "", true
+		}
+		return list[0], true
+	}
+	return "", false
+}
+
+// handle answers one line of input.  Notifications (no id) get no answer.
+func (s *statusServer) handle(line []byte) *rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{-32700, "parse error"}}
+	}
+	resp := &rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	path, ok := rpcPath(req.Params)
+	switch {
+	case req.JSONRPC != "2.0" || req.Method == "":
+		resp.Error = &rpcError{-32600, "invalid request"}
+	case !ok:
+		resp.Error = &rpcError{-32602, rpcParamsHint}
+	default:
+		result, err := s.call(req.Method, path)
+		switch {
+		case errors.Is(err, errRPCParams):
+			resp.Error = &rpcError{-32602, rpcParamsHint}
+		case errors.Is(err, errRPCMethod):
+			resp.Error = &rpcError{-32601, fmt.Sprintf("method %q not found (status, summary, scan)", req.Method)}
+		case err != nil:
+			resp.Error = &rpcError{-32000, err.Error()}
+		default:
+			resp.Result = result
+		}
+	}
+	if len(req.ID) == 0 {
+		return nil
+	}
+	return resp
+}
+
+// serveConn answers newline-delimited requests on conn until it closes.
+func (s *statusServer) serveConn(conn net.Conn) {
+	defer conn.Close()
+	in := bufio.NewScanner(conn)
+	in.Buffer(make([]byte, 64<<10), 1<<20)
+	out := json.NewEncoder(conn)
+	for in.Scan() {
+		if len(in.Bytes()) == 0 {
+			continue
+		}
+		start := time.Now()
+		resp := s.handle(in.Bytes())
+		if debugMode {
+			fmt.Fprintf(os.Stderr, "serve: %s in %s\n", in.Bytes(), time.Since(start))
+		}
+		if resp != nil {
+			if err := out.Encode(resp); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// listenSocket listens on the Unix socket at path, replacing a socket left
+// behind by a server that is gone but refusing to steal a live one's.
+func listenSocket(path string) (net.Listener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("a server is already listening on %s", path)
+		}
+		os.Remove(path)
+	}
+	return net.Listen("unix", path)
+}
+
+// httpAddr is addr with the host 127.0.0.1 when it names none (":8080"),
+// so the server is only reachable from elsewhere when asked to be.
+func httpAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		// A bare port.
+		return net.JoinHostPort("127.0.0.1", addr)
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// runServe implements `gits serve [--socket PATH] [--http ADDR] [--root
+// DIR]...`: a long-running server answering JSON-RPC requests, one per
+// line, on a Unix socket, and with --http the same over REST with an HTML
+// dashboard.  The socket is opened unless only --http is given.  Only the
+// repositories below the roots are served.
+func runServe(status *Status, args []string) {
+	c := status.cfg.Colors
+	socket, addr := "", ""
+	var roots []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--socket":
+			if i+1 < len(args) {
+				i++
+				socket = args[i]
+			}
+		case "--http":
+			if i+1 < len(args) {
+				i++
+				addr = httpAddr(args[i])
+			}
+		case "--root":
+			if i+1 < len(args) {
+				i++
+				if !IsDir(args[i]) {
+					exitWithError(fmt.Errorf("%s is not a directory", args[i]), c)
+				}
+				roots = append(roots, args[i])
+			}
+		}
+	}
+	if socket == "" && addr == "" {
+		socket = stateFile("gits.sock")
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	// The server's own git runs must not take index.lock from under the
+	// user, nor trigger its own watchers.
+	os.Setenv("GIT_OPTIONAL_LOCKS", "0")
+	srv := newStatusServer(status.cfg, roots)
+	var listeners []net.Listener
+	if socket != "" {
+		ln, err := listenSocket(socket)
+		if err != nil {
+			fail(err)
+		}
+		listeners = append(listeners, ln)
+		go func() {
+			for {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				go srv.serveConn(conn)
+			}
+		}()
+		fmt.Printf("%s %sgits serve%s listening on %s%s%s (status, summary, scan)\n",
+			Icons.INFO, Bold+resolveColor(c.Header), Reset, Bold+resolveColor(c.RemoteURL), socket, Reset)
+	}
+	if addr != "" {
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			fail(err)
+		}
+		listeners = append(listeners, ln)
+		hs := &http.Server{
+			Handler:           srv.httpHandler(),
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       10 * time.Second,
+			// A scan of a large fleet takes a while the first time.
+			WriteTimeout: 2 * time.Minute,
+			IdleTimeout:  time.Minute,
+		}
+		go hs.Serve(ln)
+		fmt.Printf("%s %sgits serve%s on %shttp://%s/%s (/status, /summary, /scan)\n",
+			Icons.INFO, Bold+resolveColor(c.Header), Reset, Bold+resolveColor(c.RemoteURL), ln.Addr(), Reset)
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+	// Closing a Unix listener removes its socket file.
+	for _, l := range listeners {
+		l.Close()
+	}
+	if debugMode {
+		srv.mu.Lock()
+		fmt.Fprintf(os.Stderr, "serve: %d read, %d served from cache\n", srv.reads, srv.hits)
+		srv.mu.Unlock()
+	}
+}
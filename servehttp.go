@@ -0,0 +1,139 @@
+// File: servehttp.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: `gits serve --http`: the status server as a REST API and an HTML dashboard
+// License: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+)
+
+// dashboardPage is the page `gits serve --http` shows at /: the scan as a
+// table that reloads itself.
+var dashboardPage = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"ab": func(r *RepoStatus) string {
+		s := ""
+		if r.Ahead > 0 {
+			s += "↑" + strconv.Itoa(r.Ahead)
+		}
+		if r.Behind > 0 {
+			s += "↓" + strconv.Itoa(r.Behind)
+		}
+		return s
+	},
+}).Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta http-equiv="refresh" content="30">
+<title>gits — {{.Host}}</title>
+<style>
+body{font-family:ui-monospace,monospace;background:#111;color:#ddd;margin:2em}
+table{border-collapse:collapse}th,td{padding:.25em .8em;text-align:left}
+th{border-bottom:1px solid #444;color:#ff0}tr.attn td:first-child{color:#f80}
+td.err{color:#f44}a{color:#0ff;text-decoration:none}.dim{color:#777}
+</style></head><body>
+<h1>gits — {{.Host}}</h1>
+<p>{{.Aggregate.TotalRepos}} repositories, {{.Aggregate.DirtyRepos}} dirty, {{.Aggregate.ErrorRepos}} unreadable, {{.Aggregate.Stashes}} stashes
+<span class="dim">— {{.GeneratedAt.Format "2006-01-02 15:04:05"}} UTC, <a href="/scan">JSON</a></span></p>
+<table>
+<tr><th>repo</th><th>branch</th><th>↑↓</th><th>staged</th><th>modified</th><th>untracked</th><th>conflicts</th><th>stash</th></tr>
+{{range .Repos}}{{if .Err}}<tr class="attn"><td>{{.Path}}</td><td class="err" colspan="7">{{.Err}}</td></tr>
+{{else}}<tr{{if .NeedsAttention}} class="attn"{{end}}><td><a href="/status?path={{.Path}}">{{.Path}}</a></td><td>{{.Branch}}</td><td>{{ab .}}</td>
+<td>{{.Staged}}</td><td>{{.Modified}}</td><td>{{if .UntrackedSkipped}}–{{else}}{{.Untracked}}{{end}}</td><td>{{.Conflicts}}</td><td>{{.Stashes}}</td></tr>
+{{end}}{{end}}</table>
+</body></html>
+`))
+
+// writeJSON answers with v, or with {"error": ...} and code.
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+// writeJSONError answers with err, as a 400 for a missing path, a 403 for
+// one outside the server's roots and a 404 for one outside any repository.
+func writeJSONError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, errRPCParams):
+		code = http.StatusBadRequest
+	case errors.Is(err, errForbidden):
+		code = http.StatusForbidden
+	case errors.Is(err, errNotRepo):
+		code = http.StatusNotFound
+	}
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
+
+// httpHandler serves /status?path=DIR, /summary?path=DIR and
+// /scan[?root=DIR...] with the JSON of `gits scan --json`, and the
+// dashboard at /.
+func (s *statusServer) httpHandler() http.Handler {
+	report := func(r *http.Request) (*FleetReport, error) {
+		results, err := s.scan(r.URL.Query()["root"])
+		if err != nil {
+			return nil, err
+		}
+		return buildFleetReport(results), nil
+	}
+	path := func(r *http.Request) (string, error) {
+		if p := r.URL.Query().Get("path"); p != "" {
+			return p, nil
+		}
+		return "", fmt.Errorf("%w: missing ?path=DIR", errRPCParams)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, r *http.Request) {
+		p, err := path(r)
+		if err == nil {
+			var rs *RepoStatus
+			if rs, err = s.status(p); err == nil {
+				writeJSON(w, http.StatusOK, rs)
+				return
+			}
+		}
+		writeJSONError(w, err)
+	})
+	mux.HandleFunc("GET /summary", func(w http.ResponseWriter, r *http.Request) {
+		p, err := path(r)
+		if err == nil {
+			var sum *serveSummary
+			if sum, err = s.summary(p); err == nil {
+				writeJSON(w, http.StatusOK, sum)
+				return
+			}
+		}
+		writeJSONError(w, err)
+	})
+	mux.HandleFunc("GET /scan", func(w http.ResponseWriter, r *http.Request) {
+		rep, err := report(r)
+		if err != nil {
+			writeJSONError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, rep)
+	})
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		rep, err := report(r)
+		if errors.Is(err, errForbidden) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		dashboardPage.Execute(w, rep)
+	})
+	return mux
+}
@@ -0,0 +1,149 @@
+// File: show.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: commit inspection (`gits show`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// signatureStatus describes git's %G? code for display, with whether it
+// counts as a good signature.
+func signatureStatus(code, signer string) (string, bool) {
+	switch code {
+	case "G":
+		return "good signature from " + signer, true
+	case "U":
+		return "good signature from " + signer + " (untrusted key)", true
+	case "X", "Y":
+		return "good signature from " + signer + " (expired)", false
+	case "R":
+		return "signed with a revoked key (" + signer + ")", false
+	case "E":
+		return "signed, but the key is not available to verify it", false
+	case "B":
+		return "BAD signature", false
+	}
+	return "", false
+}
+
+// nameStatusStyle colors a --name-status letter.
+func nameStatusStyle(code string, c ColorConfig) string {
+	switch code[:1] {
+	case "A":
+		return Bold + resolveColor(c.NewFile)
+	case "D":
+		return Bold + resolveColor(c.Deleted)
+	case "R", "C":
+		return Bold + resolveColor(c.Renamed)
+	}
+	return Bold + resolveColor(c.Modified)
+}
+
+// runShow implements `gits show [REF] [--stat|--name-only]`.
+func runShow(status *Status, args []string) {
+	c := status.cfg.Colors
+	ref, mode := "HEAD", "patch"
+	for _, a := range args {
+		switch a {
+		case "--stat":
+			mode = "stat"
+		case "--name-only", "--name-status":
+			mode = "names"
+		default:
+			ref = a
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	format := strings.Join([]string{"%H", "%an", "%ae", "%at", "%cn", "%ct", "%P", "%D", "%G?", "%GS", "%B"}, "%x00")
+	out, err := gitOutput(".", "show", "-s", "--format="+format, ref)
+	if err != nil {
+		fail(err)
+	}
+	f := strings.SplitN(out, "\x00", 11)
+	if len(f) < 11 {
+		fail(fmt.Errorf("cannot read commit %s", ref))
+	}
+	sha, author, email, committer := f[0], f[1], f[2], f[4]
+	atime, _ := strconv.ParseInt(f[3], 10, 64)
+	ctime, _ := strconv.ParseInt(f[5], 10, 64)
+	parents := strings.Fields(f[6])
+
+	web := repoWebURL(".")
+	fmt.Printf("%s %s%s%s", Icons.GIT, Bold+resolveColor(c.AheadBehind), hyperlink(commitWebURL(web, sha), sha), Reset)
+	if deco := colorDecorations(f[7], c); deco != "" {
+		fmt.Printf(" %s", strings.TrimSpace(deco))
+	}
+	fmt.Println()
+	palette := lanePalette(c)
+	authored := time.Unix(atime, 0)
+	fmt.Printf("   %sAuthor%s    %s%s%s %s<%s>%s\n", Dim, Reset, authorColor(author, palette), author, Reset, Dim, email, Reset)
+	fmt.Printf("   %sDate%s      %s %s(%s)%s\n", Dim, Reset, authored.Format("2006-01-02 15:04:05 -0700"), Dim, relativeAge(authored), Reset)
+	if committer != author || ctime != atime {
+		fmt.Printf("   %sCommitter%s %s%s%s %s(%s)%s\n", Dim, Reset, authorColor(committer, palette), committer, Reset,
+			Dim, relativeAge(time.Unix(ctime, 0)), Reset)
+	}
+	if len(parents) > 1 {
+		short := make([]string, len(parents))
+		for i, p := range parents {
+			short[i] = shortOid(p)
+		}
+		fmt.Printf("   %sMerge%s     %s\n", Dim, Reset, strings.Join(short, " "))
+	}
+	if text, good := signatureStatus(f[8], f[9]); text != "" {
+		style := resolveColor(c.UpToDate)
+		if !good {
+			style = Bold + resolveColor(c.Deleted)
+		}
+		fmt.Printf("   %sSignature%s %s🔏 %s%s\n", Dim, Reset, style, text, Reset)
+	}
+
+	message := strings.TrimRight(f[10], "\n")
+	subject, body, _ := strings.Cut(message, "\n")
+	fmt.Printf("\n    %s%s%s\n", Bold, subject, Reset)
+	for _, line := range strings.Split(strings.Trim(body, "\n"), "\n") {
+		if line != "" || body != "" {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+	fmt.Println()
+
+	// Merges are shown against their first parent, like a pull request diff.
+	diffArgs := []string{"show", "--format=", "--no-color", "--no-ext-diff", "--diff-merges=first-parent"}
+	switch mode {
+	case "names":
+		names, err := gitOutput(".", append(diffArgs, "--name-status", ref)...)
+		if err != nil {
+			fail(err)
+		}
+		for _, line := range strings.Split(names, "\n") {
+			code, path, ok := strings.Cut(line, "\t")
+			if !ok {
+				continue
+			}
+			fmt.Printf("    %s%-4s%s %s\n", nameStatusStyle(code, c), code, Reset, strings.ReplaceAll(path, "\t", " → "))
+		}
+		return
+	}
+
+	if numstat, err := gitOutput(".", append(diffArgs, "--numstat", ref)...); err == nil {
+		fmt.Print(renderNumstat(numstat, c, "    "))
+	}
+	if mode == "stat" {
+		return
+	}
+	patch, err := gitRaw(".", append(diffArgs, "-p", ref)...)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Print(renderDiff(patch, c))
+}
@@ -0,0 +1,119 @@
+// File: stalelock.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: an index.lock left behind: its age, who holds it, and --remove-stale-lock
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// staleLockAfter is set by --remove-stale-lock[=AGE]: an index.lock at
+// least this old that no git process holds is removed, after asking, and
+// the command runs again.  Zero when the flag was not given.
+var staleLockAfter time.Duration
+
+// defaultStaleLock is the age of a lock --remove-stale-lock takes as left
+// behind when no AGE is given.
+const defaultStaleLock = 10 * time.Minute
+
+// lockHolder is a git process that may hold a repository's lock.
+type lockHolder struct {
+	pid     int
+	command string
+}
+
+// lockHolders lists the git processes working in the worktree at root,
+// from /proc.  Elsewhere they cannot be found, and ok is false.
+func lockHolders(root string) (holders []lockHolder, ok bool) {
+	if runtime.GOOS != "linux" || root == "" {
+		return nil, false
+	}
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, false
+	}
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || pid == os.Getpid() {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join("/proc", e.Name(), "cmdline"))
+		if err != nil || len(raw) == 0 {
+			continue
+		}
+		argv := strings.Split(strings.TrimRight(string(raw), "\x00"), "\x00")
+		if name := filepath.Base(argv[0]); name != "git" && !strings.HasPrefix(name, "git-") {
+			continue
+		}
+		cwd, err := os.Readlink(filepath.Join("/proc", e.Name(), "cwd"))
+		if err != nil {
+			continue
+		}
+		if cwd == root || strings.HasPrefix(cwd, root+string(filepath.Separator)) {
+			holders = append(holders, lockHolder{pid: pid, command: strings.Join(argv, " ")})
+		}
+	}
+	return holders, true
+}
+
+// explainIndexLock says how old lock is and which git holds it, if one can
+// be found, and under --remove-stale-lock removes a lock that is old
+// enough and held by nobody, then runs the command again.  Without a
+// terminal to ask on, the flag is the consent.
+func explainIndexLock(lock string, c ColorConfig) {
+	info, err := os.Stat(lock)
+	if err != nil {
+		fmt.Printf("   %sthe lock is gone now; run the command again%s\n", Dim, Reset)
+		return
+	}
+	age := time.Since(info.ModTime())
+	fmt.Printf("   %screated %s%s\n", Dim, relativeAge(info.ModTime()), Reset)
+
+	root := ""
+	if gitDir := filepath.Dir(lock); filepath.Base(gitDir) == ".git" {
+		root, _ = filepath.EvalSymlinks(filepath.Dir(gitDir))
+	}
+	holders, known := lockHolders(root)
+	for _, h := range holders {
+		fmt.Printf("   %sgit is running here: pid %d, %s%s\n", Dim, h.pid, h.command, Reset)
+	}
+	if known && len(holders) == 0 {
+		fmt.Printf("   %sno git process is running in this repository, so the lock looks stale%s\n", Dim, Reset)
+	}
+
+	switch {
+	case staleLockAfter == 0:
+		if len(holders) == 0 {
+			fmt.Printf("   %srun gits again with --remove-stale-lock to remove it%s\n", Dim, Reset)
+		}
+		return
+	case len(holders) > 0:
+		fmt.Printf("%s %sLeft the lock in place: git still runs in this repository%s\n", Icons.WARNING, resolveColor(c.AheadBehind), Reset)
+		return
+	case age < staleLockAfter:
+		fmt.Printf("%s %sLeft the lock in place: it is younger than %s%s\n", Icons.WARNING, resolveColor(c.AheadBehind), staleLockAfter, Reset)
+		return
+	}
+	ok, err := confirm(fmt.Sprintf("Remove %s?", displayPath(lock)))
+	if err == errNotTerminal {
+		ok, err = true, nil
+	}
+	if err != nil || !ok {
+		return
+	}
+	if err := os.Remove(lock); err != nil {
+		fmt.Printf("%s %s%v%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), err, Reset)
+		return
+	}
+	fmt.Printf("%s %sRemoved the stale lock%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+	rerunWithout("--remove-stale-lock")
+}
@@ -0,0 +1,187 @@
+// File: stash.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: stash browsing and management (`gits stash`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stashEntry is one entry of `git stash list`.
+type stashEntry struct {
+	Ref     string // stash@{N}
+	Time    time.Time
+	Subject string // "WIP on main: 1a2b3c4 message" or "On main: custom message"
+}
+
+// listStashes returns the stashes of dir, newest first.
+func listStashes(dir string) ([]stashEntry, error) {
+	out, err := gitOutput(dir, "stash", "list", "--format=%gd%x00%ct%x00%gs")
+	if err != nil {
+		return nil, err
+	}
+	var stashes []stashEntry
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.SplitN(line, "\x00", 3)
+		if len(f) < 3 {
+			continue
+		}
+		e := stashEntry{Ref: f[0], Subject: f[2]}
+		if ts, err := strconv.ParseInt(f[1], 10, 64); err == nil {
+			e.Time = time.Unix(ts, 0)
+		}
+		stashes = append(stashes, e)
+	}
+	return stashes, nil
+}
+
+// stashDiffstat renders the diffstat of one stash (tracked changes only).
+func stashDiffstat(dir, ref string, c ColorConfig) string {
+	out, err := gitOutput(dir, "stash", "show", "--numstat", ref)
+	if err != nil {
+		return ""
+	}
+	return renderNumstat(out, c, "      ")
+}
+
+// stashRef normalizes "2" to "stash@{2}"; anything else is passed through.
+func stashRef(arg string) string {
+	if _, err := strconv.Atoi(arg); err == nil {
+		return "stash@{" + arg + "}"
+	}
+	return arg
+}
+
+// chooseStash returns the stash named by arg, or lets the user pick one when
+// arg is empty and there is more than one stash on a terminal.  Without a
+// terminal it falls back to stash@{0}, like git.
+func chooseStash(dir, arg, action string, c ColorConfig) (string, bool) {
+	if arg != "" {
+		return stashRef(arg), true
+	}
+	stashes, err := listStashes(dir)
+	if err != nil || len(stashes) == 0 {
+		fmt.Printf("%s %sNo stash entries%s\n", Icons.INFO, resolveColor(c.UpToDate), Reset)
+		return "", false
+	}
+	if len(stashes) == 1 {
+		return stashes[0].Ref, true
+	}
+	items := make([]pickItem, len(stashes))
+	for i, s := range stashes {
+		items[i] = pickItem{
+			Tag:      fmt.Sprintf("%-10s", s.Ref),
+			TagStyle: Bold + resolveColor(c.AheadBehind),
+			Label:    fmt.Sprintf("%s %s(%s)%s", s.Subject, Dim, relativeAge(s.Time), Reset),
+		}
+	}
+	idx, ok, err := pickOne("Stash to "+action, items, c)
+	if err == errNotTerminal {
+		return stashes[0].Ref, true
+	}
+	if err != nil {
+		exitWithError(err, c)
+	}
+	if !ok {
+		fmt.Printf("%s Cancelled\n", Icons.INFO)
+		return "", false
+	}
+	return stashes[idx].Ref, true
+}
+
+// runStash implements `gits stash [list|show|apply|pop|drop|push] [stash]`.
+func runStash(status *Status, args []string) {
+	c := status.cfg.Colors
+	action := "list"
+	if len(args) > 0 {
+		action = args[0]
+		args = args[1:]
+	}
+	yes := false
+	arg := ""
+	for _, a := range args {
+		switch a {
+		case "--yes", "-y":
+			yes = true
+		default:
+			arg = a
+		}
+	}
+
+	switch action {
+	case "list", "ls":
+		stashes, err := listStashes(".")
+		if err != nil {
+			exitWithError(err, c)
+		}
+		if len(stashes) == 0 {
+			fmt.Printf("%s %sNo stash entries%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+			return
+		}
+		for i, s := range stashes {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s%-10s%s %s%-8s%s %s\n",
+				Bold+resolveColor(c.AheadBehind), s.Ref, Reset,
+				Dim, relativeAge(s.Time), Reset, s.Subject)
+			fmt.Print(stashDiffstat(".", s.Ref, c))
+		}
+
+	case "show":
+		ref, ok := chooseStash(".", arg, "show", c)
+		if !ok {
+			return
+		}
+		out, err := gitRaw(".", "stash", "show", "-p", "--no-color", "--no-ext-diff", ref)
+		if err != nil {
+			exitWithError(err, c)
+		}
+		fmt.Printf("%s %s%s%s\n", Icons.INFO, Bold+resolveColor(c.AheadBehind), ref, Reset)
+		fmt.Print(stashDiffstat(".", ref, c))
+		fmt.Print(renderDiff(out, c))
+
+	case "apply", "pop", "drop":
+		ref, ok := chooseStash(".", arg, action, c)
+		if !ok {
+			return
+		}
+		if action == "drop" && !yes {
+			fmt.Print(stashDiffstat(".", ref, c))
+			ok, err := confirm("Drop " + ref + "? Its changes will be lost.")
+			if err != nil {
+				fmt.Printf("%s %s%v (use --yes)%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), err, Reset)
+				exit(1)
+			}
+			if !ok {
+				return
+			}
+		}
+		cmd := gitCmd(".", "stash", action, ref)
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Printf("%s %sgit stash %s %s failed%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), action, ref, Reset)
+			exit(1)
+		}
+		fmt.Printf("%s %s%s %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), action, ref, Reset)
+
+	case "push", "save":
+		cmd := gitCmd(".", append([]string{"stash", "push"}, args...)...)
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			exit(1)
+		}
+
+	default:
+		fmt.Printf("%s %sunknown stash action %q (list, show, apply, pop, drop, push)%s\n",
+			Icons.ERROR, Bold+resolveColor(c.Deleted), action, Reset)
+		exit(1)
+	}
+}
@@ -0,0 +1,226 @@
+// File: statuscache.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: on-disk cache of parsed statuses, invalidated by a fingerprint of the repository
+// License: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StatusCacheConfig controls the status cache used by `gits scan`, the
+// daemon and the exporter.
+type StatusCacheConfig struct {
+	Enabled bool   `toml:"enabled"`
+	TTL     string `toml:"ttl"` // cached statuses older than this are read again
+}
+
+// statusCacheTTL is how long a cached status may be served; 0 turns the
+// cache off.  Set from [cache] and --no-cache.
+var statusCacheTTL time.Duration
+
+// statusCacheEntry is one repository's cached status and the fingerprint
+// it was read under.
+type statusCacheEntry struct {
+	Key    string      `json:"key"`
+	Time   time.Time   `json:"time"`
+	Status *RepoStatus `json:"status"`
+}
+
+// statusCache maps repository paths to their cached status.
+type statusCache struct {
+	mu      sync.Mutex
+	Repos   map[string]statusCacheEntry `json:"repos"`
+	dirty   bool
+	hits    int
+	reads   int
+	touched map[string]bool
+}
+
+func statusCachePath() string {
+	return stateFile("status-cache.json")
+}
+
+func loadStatusCache() *statusCache {
+	sc := &statusCache{Repos: map[string]statusCacheEntry{}, touched: map[string]bool{}}
+	if data, err := os.ReadFile(statusCachePath()); err == nil {
+		json.Unmarshal(data, sc)
+		if sc.Repos == nil {
+			sc.Repos = map[string]statusCacheEntry{}
+		}
+	}
+	return sc
+}
+
+// status returns the status of dir from the cache when its fingerprint is
+// unchanged and the entry is younger than the TTL, and reads it otherwise.
+func (sc *statusCache) status(dir string) *RepoStatus {
+	key, ok := statusFingerprint(dir)
+	sc.mu.Lock()
+	e, cached := sc.Repos[dir]
+	sc.touched[dir] = true
+	sc.mu.Unlock()
+	if ok && cached && e.Key == key && e.Status != nil && time.Since(e.Time) < statusCacheTTL {
+		sc.mu.Lock()
+		sc.hits++
+		sc.mu.Unlock()
+		return e.Status
+	}
+
+	// git status may refresh the index, so the fingerprint is taken again
+	// for the entry.
+	rs := CollectStatus(dir)
+	key, ok = statusFingerprint(dir)
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.reads++
+	switch {
+	case ok && rs.Err == "":
+		sc.Repos[dir] = statusCacheEntry{Key: key, Time: time.Now(), Status: rs}
+		sc.dirty = true
+	case cached:
+		delete(sc.Repos, dir)
+		sc.dirty = true
+	}
+	return rs
+}
+
+// save drops repositories that no longer exist and writes the cache if
+// anything changed.
+func (sc *statusCache) save() {
+	for dir := range sc.Repos {
+		if !sc.touched[dir] && !IsDir(dir) {
+			delete(sc.Repos, dir)
+			sc.dirty = true
+		}
+	}
+	if debugMode {
+		fmt.Fprintf(os.Stderr, "status cache: %d read, %d served from cache\n", sc.reads, sc.hits)
+	}
+	if !sc.dirty {
+		return
+	}
+	if data, err := json.Marshal(sc); err == nil {
+		writeFileAtomic(statusCachePath(), data, 0o644)
+	}
+}
+
+// statusFingerprint sums up everything the status of dir depends on
+// without running git: HEAD and the branch it names, the index, the refs
+// (fetches, upstreams), the stash and the modification times of the whole
+// worktree.  It reports false for repositories it cannot vouch for, such as
+// ones with submodules, whose state lives elsewhere.
+func statusFingerprint(dir string) (string, bool) {
+	gitDir, commonDir, ok := locateGitDir(dir)
+	if !ok || IsFile(filepath.Join(dir, ".gitmodules")) {
+		return "", false
+	}
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s %t\x00", statusBackend, fastStatus)
+	stat := func(path string) {
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(h, "%s %d %d\x00", path, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", false
+	}
+	h.Write(head)
+	if ref, isRef := strings.CutPrefix(strings.TrimSpace(string(head)), "ref: "); isRef {
+		if data, err := os.ReadFile(filepath.Join(commonDir, ref)); err == nil {
+			h.Write(data)
+		}
+	}
+	for _, p := range []string{
+		filepath.Join(gitDir, "index"),
+		filepath.Join(gitDir, "MERGE_HEAD"),
+		filepath.Join(gitDir, "FETCH_HEAD"),
+		filepath.Join(commonDir, "packed-refs"),
+		filepath.Join(commonDir, "config"),
+		filepath.Join(commonDir, "info", "exclude"),
+		filepath.Join(commonDir, "logs", "refs", "stash"),
+	} {
+		stat(p)
+	}
+	// Refs are written through a lock file and renamed into place, which
+	// bumps the mtime of their directory.
+	filepath.WalkDir(filepath.Join(commonDir, "refs"), func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			stat(path)
+		}
+		return nil
+	})
+
+	// The worktree: any file written, added or removed changes an mtime or
+	// the count.  Nested repositories only matter as untracked directories.
+	count := 0
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.Name() == ".git" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if path != dir && d.IsDir() && IsDir(filepath.Join(path, ".git")) {
+			stat(path)
+			return filepath.SkipDir
+		}
+		if info, err := d.Info(); err == nil {
+			count++
+			fmt.Fprintf(h, "%d %d\x00", info.Size(), info.ModTime().UnixNano())
+		}
+		return nil
+	})
+	if err != nil {
+		return "", false
+	}
+	fmt.Fprintf(h, "%d", count)
+	return fmt.Sprintf("%016x", h.Sum64()), true
+}
+
+// locateGitDir finds the git directory of the worktree at dir, following
+// the "gitdir:" file of linked worktrees, and the common directory that
+// holds the refs.
+func locateGitDir(dir string) (gitDir, commonDir string, ok bool) {
+	gitDir = filepath.Join(dir, ".git")
+	if IsFile(gitDir) {
+		data, err := os.ReadFile(gitDir)
+		if err != nil {
+			return "", "", false
+		}
+		target, found := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+		if !found {
+			return "", "", false
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(dir, target)
+		}
+		gitDir = target
+	} else if !IsDir(gitDir) {
+		return "", "", false
+	}
+	commonDir = gitDir
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		common := strings.TrimSpace(string(data))
+		if !filepath.IsAbs(common) {
+			common = filepath.Join(gitDir, common)
+		}
+		commonDir = common
+	}
+	return gitDir, commonDir, true
+}
@@ -0,0 +1,129 @@
+// File: statusconfig.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: the git config that changes what `git status` shows
+// License: MIT
+
+package main
+
+import (
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+)
+
+// statusSettings are the git config values that change what `git status`
+// lists and how.  git applies them itself; gits reads them for the places
+// it lists paths on its own: the native backend, untracked directory
+// contents and hyperlinks.
+type statusSettings struct {
+	Untracked     string // status.showUntrackedFiles: "no", "normal" or "all"
+	Renames       bool   // status.renames, or else diff.renames
+	RelativePaths bool   // status.relativePaths: paths relative to the current directory
+}
+
+// defaultStatusSettings are git's defaults.
+var defaultStatusSettings = statusSettings{Untracked: "normal", Renames: true, RelativePaths: true}
+
+// statusConfigRe selects the keys statusSettings reads.
+const statusConfigRe = `^(status\.(showuntrackedfiles|renames|relativepaths)|diff\.renames)$`
+
+// readStatusSettings reads the status settings of the repository holding
+// dir, from git or, without it, with go-git.
+func readStatusSettings(dir string) statusSettings {
+	if !gitAvailable() {
+		repo, _, err := openNative(dir)
+		if err != nil {
+			return defaultStatusSettings
+		}
+		return nativeStatusSettings(repo)
+	}
+	// Exits 1 when none of the keys is set.
+	out, _ := gitRaw(dir, "config", "-z", "--get-regexp", statusConfigRe)
+	values := map[string]string{}
+	for _, rec := range strings.Split(out, "\x00") {
+		if key, value, ok := strings.Cut(rec, "\n"); ok {
+			values[key] = value
+		} else if rec != "" {
+			// A key with no value at all means true.
+			values[rec] = "true"
+		}
+	}
+	return parseStatusSettings(values)
+}
+
+// fileStatusSettings reads the status settings of the repository holding
+// dir straight from its config files with go-git, sparing the status a
+// `git config` call, or from git should go-git fail to open it.
+func fileStatusSettings(dir string) statusSettings {
+	repo, _, err := openNative(dir)
+	if err != nil {
+		return readStatusSettings(dir)
+	}
+	return nativeStatusSettings(repo)
+}
+
+// nativeStatusSettings reads the status settings from the repository and
+// user config with go-git.
+func nativeStatusSettings(repo *git.Repository) statusSettings {
+	cfg, err := repo.ConfigScoped(config.GlobalScope)
+	if err != nil {
+		return defaultStatusSettings
+	}
+	values := map[string]string{}
+	for _, key := range []string{"status.showUntrackedFiles", "status.renames", "status.relativePaths", "diff.renames"} {
+		section, name, _ := strings.Cut(key, ".")
+		if s := cfg.Raw.Section(section); s.HasOption(name) {
+			value := s.Option(name)
+			if value == "" {
+				value = "true"
+			}
+			values[strings.ToLower(key)] = value
+		}
+	}
+	return parseStatusSettings(values)
+}
+
+// parseStatusSettings reads values, keyed by lower-case config name, the
+// way git does, keeping the defaults for what it cannot read.
+func parseStatusSettings(values map[string]string) statusSettings {
+	st := defaultStatusSettings
+	if v, ok := values["status.showuntrackedfiles"]; ok {
+		switch v = strings.ToLower(v); v {
+		case "no", "normal", "all":
+			st.Untracked = v
+		default:
+			if b, ok := gitBool(v); ok && !b {
+				st.Untracked = "no"
+			}
+		}
+	}
+	renames, ok := values["status.renames"]
+	if !ok {
+		renames, ok = values["diff.renames"]
+	}
+	if ok {
+		// "copies" detects renames too.
+		if b, isBool := gitBool(renames); isBool {
+			st.Renames = b
+		}
+	}
+	if v, ok := values["status.relativepaths"]; ok {
+		if b, isBool := gitBool(v); isBool {
+			st.RelativePaths = b
+		}
+	}
+	return st
+}
+
+// gitBool reads a git boolean, reporting false for what is not one.
+func gitBool(v string) (value, ok bool) {
+	switch strings.ToLower(v) {
+	case "true", "yes", "on", "1":
+		return true, true
+	case "false", "no", "off", "0", "":
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,292 @@
+// File: statuslines.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: the long status read line by line into a StatusReport
+// License: MIT
+
+package main
+
+import (
+	"strings"
+)
+
+// lineKind classifies a line of the long status.
+type lineKind uint8
+
+const (
+	lineText            lineKind = iota // anything else, blank lines included
+	lineBranch                          // "On branch main"; Path is the branch
+	lineDetached                        // "HEAD detached at 1a2b3c4"
+	lineNoCommits                       // "No commits yet"
+	lineUpToDate                        // "Your branch is up to date with ..."
+	lineTracking                        // ahead of, behind or diverged from the upstream
+	lineHeader                          // a section title; Section is the one it opens
+	lineHint                            // (use "git ...")
+	lineFile                            // a changed path: Label, Path and, for renames, From
+	lineUntracked                       // an untracked path; directories end in "/"
+	lineEntry                           // another indented line in a section, e.g. "both modified: x"
+	lineDone                            // "nothing to commit, working tree clean" and its kin
+	lineUntrackedHidden                 // git did not list untracked files
+	lineStash                           // "Your stash currently has 2 entries"
+)
+
+// StatusLine is one line of the long status, read into its parts.
+type StatusLine struct {
+	Kind    lineKind
+	Section string        // "staged", "not_staged", "untracked" or "" for none of them
+	Text    string        // the line as git wrote it
+	Label   string        // lineFile: "modified", "deleted", "new file", "renamed" or "added"
+	Path    string        // lineFile, lineUntracked: unquoted; lineBranch: the branch
+	From    string        // lineFile of a rename: the old path
+	Dir     *untrackedDir // lineUntracked of a directory: what it holds, when known
+}
+
+// parseLongStatus reads the long `git status` output line by line.  Only
+// the lines are allocated; their strings share text.
+func parseLongStatus(text string) []StatusLine {
+	lines := make([]StatusLine, 0, strings.Count(text, "\n")+1)
+	section := ""
+	// Cut off a line at a time; like strings.Split, a trailing newline
+	// leaves a last empty line.
+	for rest, more := text, true; more; {
+		var line string
+		line, rest, more = strings.Cut(rest, "\n")
+		line = strings.TrimRight(line, "\r")
+		l := StatusLine{Text: line}
+		// Paths are indented with a tab: a file named "ahead" or "x:"
+		// is not a message.
+		message := !strings.HasPrefix(line, "\t")
+		lower := ""
+		if message {
+			lower = strings.ToLower(strings.TrimSpace(line))
+		}
+		var branch []string
+		if strings.HasPrefix(line, "On branch ") {
+			branch = branchLineRe.FindStringSubmatch(line)
+		}
+		key, header := "", false
+		if message {
+			key, header = headerKey(line)
+		}
+		switch {
+		case branch != nil:
+			l.Kind, l.Path, section = lineBranch, branch[1], ""
+		case message && strings.Contains(line, "HEAD detached"):
+			l.Kind = lineDetached
+		case strings.HasPrefix(line, "Untracked files not listed"):
+			l.Kind = lineUntrackedHidden
+		case strings.HasPrefix(line, "Your stash currently has"):
+			l.Kind = lineStash
+		case message && strings.Contains(line, "No commits yet"):
+			l.Kind = lineNoCommits
+		case message && strings.Contains(line, "Your branch is up to date"):
+			l.Kind, section = lineUpToDate, ""
+		case message && (strings.Contains(line, "ahead") || strings.Contains(line, "behind") || strings.Contains(line, "diverged")):
+			l.Kind, section = lineTracking, ""
+		case header:
+			l.Kind, section = lineHeader, key
+		case hintLineRe.MatchString(line):
+			l.Kind = lineHint
+		case strings.HasPrefix(lower, "nothing to commit") || strings.HasPrefix(lower, "nothing added to commit") ||
+			strings.HasPrefix(lower, "no changes added to commit") || strings.Contains(lower, "clean working tree"):
+			l.Kind, section = lineDone, ""
+		case section == "untracked":
+			if strings.TrimSpace(line) != "" {
+				l.Kind, l.Path = lineUntracked, unquoteGitPath(strings.TrimPrefix(line, "\t"))
+			}
+		default:
+			if _, status, rest, ok := fileLinePath(line); ok {
+				l.Kind, l.Label = lineFile, status
+				if from, to, found := splitRename(rest); found && status == "renamed" {
+					l.From, l.Path = from, to
+				} else {
+					l.Path = unquoteGitPath(rest)
+				}
+			} else if indentLineRe.MatchString(line) {
+				l.Kind = lineEntry
+			}
+		}
+		l.Section = section
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+// headerKey returns the section a header line opens; ok is false when
+// line is no header.
+func headerKey(line string) (key string, ok bool) {
+	for _, p := range headerPatterns {
+		if p.re.MatchString(line) {
+			return p.key, true
+		}
+	}
+	return "", false
+}
+
+// untrackedDirs are the untracked directories among lines.
+func untrackedDirs(lines []StatusLine) []string {
+	var dirs []string
+	for _, l := range lines {
+		if l.Kind == lineUntracked && strings.HasSuffix(l.Path, "/") {
+			dirs = append(dirs, l.Path)
+		}
+	}
+	return dirs
+}
+
+// filter narrows rep to the paths fuzzy-matching query, as --filter does:
+// file lines by their path, untracked directories file by file.  It
+// records how many paths were left out.
+func (rep *StatusReport) filter(query string) {
+	rep.Filter = query
+	if query == "" {
+		return
+	}
+	matches := func(p string) bool {
+		_, _, ok := fuzzyMatch(query, p)
+		return ok
+	}
+	kept := rep.Lines[:0]
+	for _, l := range rep.Lines {
+		switch {
+		case l.Kind == lineUntracked && l.Dir != nil && len(l.Dir.files) > 0:
+			d := &untrackedDir{}
+			for i, f := range l.Dir.files {
+				if matches(f) {
+					d.add(f, l.Dir.sizes[i])
+				} else {
+					rep.Hidden++
+				}
+			}
+			if len(d.files) == 0 {
+				continue
+			}
+			l.Dir = d
+		case l.Kind == lineUntracked:
+			if !matches(l.Path) {
+				rep.Hidden++
+				continue
+			}
+		case l.Section != "" && (l.Kind == lineFile || l.Kind == lineEntry || l.Kind == lineText) &&
+			strings.TrimSpace(l.Text) != "" && !matches(statusLinePath(l.Text)):
+			rep.Hidden++
+			continue
+		}
+		kept = append(kept, l)
+	}
+	rep.Lines = kept
+}
+
+// statusDoc is a StatusReport arranged for the document formats: JSON,
+// Markdown and HTML.
+type statusDoc struct {
+	Dir            string           `json:"dir"`
+	Branch         string           `json:"branch,omitempty"`
+	Profile        string           `json:"ignore_profile,omitempty"`
+	Head           *docCommit       `json:"head,omitempty"`
+	Notes          []string         `json:"notes,omitempty"`
+	Sections       []docSection     `json:"sections"`
+	TrackedIgnored []ignoredTracked `json:"tracked_ignored,omitempty"`
+	GitMessages    []string         `json:"git_messages,omitempty"`
+	Filter         string           `json:"filter,omitempty"`
+	Hidden         int              `json:"hidden,omitempty"`
+	Error          string           `json:"error,omitempty"`
+}
+
+// docCommit is the last commit.
+type docCommit struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"`
+	Author  string `json:"author"`
+	Age     string `json:"age"`
+}
+
+// docSection is one section of the status, such as the staged changes.
+type docSection struct {
+	Key     string     `json:"key,omitempty"` // "staged", "not_staged", "untracked" or none
+	Title   string     `json:"title"`
+	Entries []docEntry `json:"entries"`
+}
+
+// docEntry is one path of a section.
+type docEntry struct {
+	Status string   `json:"status,omitempty"` // "modified", "both modified", ...; none when untracked
+	Path   string   `json:"path"`
+	From   string   `json:"from,omitempty"`       // the old path of a rename
+	Count  int      `json:"file_count,omitempty"` // an untracked directory's file count
+	Size   int64    `json:"size,omitempty"`       // and its size in bytes
+	Files  []string `json:"files,omitempty"`
+}
+
+// document arranges rep for the document formats; contents lists the
+// files of untracked directories too.
+func (rep *StatusReport) document(contents bool) *statusDoc {
+	doc := &statusDoc{Dir: rep.Dir, Profile: rep.Profile, Sections: []docSection{}, Filter: rep.Filter,
+		Hidden: rep.Hidden, TrackedIgnored: rep.TrackedIgnored}
+	switch {
+	case rep.NotRepo:
+		doc.Error = displayPath(rep.Dir) + " is not in a git repository"
+		return doc
+	case rep.Err != nil:
+		doc.Error = rep.Err.Error()
+		return doc
+	}
+	if rep.HasHead {
+		doc.Head = &docCommit{Hash: rep.Head.Hash, Subject: rep.Head.Subject, Author: rep.Head.Author,
+			Age: relativeAge(rep.Head.Time)}
+	}
+	var cur *docSection
+	for _, l := range rep.Lines {
+		switch l.Kind {
+		case lineBranch:
+			doc.Branch = l.Path
+		case lineDetached, lineNoCommits, lineUpToDate, lineTracking, lineDone, lineStash, lineUntrackedHidden:
+			doc.Notes = append(doc.Notes, strings.TrimSpace(l.Text))
+		case lineHeader:
+			doc.Sections = append(doc.Sections, docSection{Key: l.Section, Title: strings.TrimSuffix(strings.TrimSpace(l.Text), ":")})
+			cur = &doc.Sections[len(doc.Sections)-1]
+		case lineFile:
+			if cur != nil {
+				cur.Entries = append(cur.Entries, docEntry{Status: l.Label, Path: l.Path, From: l.From})
+			}
+		case lineUntracked:
+			if cur == nil {
+				continue
+			}
+			e := docEntry{Path: l.Path}
+			if l.Dir != nil {
+				e.Count, e.Size = len(l.Dir.files), l.Dir.size
+				if contents {
+					e.Files = l.Dir.files
+				}
+			}
+			cur.Entries = append(cur.Entries, e)
+		case lineEntry:
+			if cur == nil {
+				continue
+			}
+			// "both modified:   x": the label before the colon.
+			text := strings.TrimSpace(l.Text)
+			status, _, found := strings.Cut(text, ": ")
+			if !found {
+				status = ""
+			}
+			cur.Entries = append(cur.Entries, docEntry{Status: status, Path: statusLinePath(l.Text)})
+		}
+	}
+	// Drop the sections that only had a title, such as "no changes added
+	// to commit:" when a filter emptied them.
+	sections := doc.Sections[:0]
+	for _, s := range doc.Sections {
+		if len(s.Entries) > 0 {
+			sections = append(sections, s)
+		}
+	}
+	doc.Sections = sections
+	for _, m := range splitGitStderr(rep.Stderr) {
+		if (m.hint && gitMessages.Hints) || (!m.hint && gitMessages.Warnings) {
+			doc.GitMessages = append(doc.GitMessages, m.text)
+		}
+	}
+	return doc
+}
@@ -0,0 +1,157 @@
+// File: submodule.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: submodule overview and update (`gits submodule`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// submoduleInfo describes one submodule of the superproject.
+type submoduleInfo struct {
+	Name        string
+	Path        string
+	Branch      string // tracked branch ("" = the submodule remote's HEAD)
+	Pinned      string // commit recorded in the superproject's HEAD
+	Checkout    string // commit checked out in the submodule ("" = not initialized)
+	Dirty       int
+	Behind      int // tracked-branch commits not in the checkout (-1 = unknown)
+	TrackingRef string
+}
+
+// listSubmodules reads .gitmodules in root and inspects every submodule.
+func listSubmodules(root string) ([]submoduleInfo, error) {
+	if !IsFile(filepath.Join(root, ".gitmodules")) {
+		return nil, nil
+	}
+	out, err := gitOutput(root, "config", "-f", ".gitmodules", "--get-regexp", `^submodule\..*\.path$`)
+	if err != nil {
+		return nil, nil // no path entries
+	}
+	var subs []submoduleInfo
+	for _, line := range strings.Split(out, "\n") {
+		key, path, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "submodule."), ".path")
+		s := submoduleInfo{Name: name, Path: path, Behind: -1}
+		s.Branch, _ = gitOutput(root, "config", "-f", ".gitmodules", "--get", "submodule."+name+".branch")
+		if tree, err := gitOutput(root, "ls-tree", "HEAD", "--", path); err == nil {
+			if f := strings.Fields(tree); len(f) >= 3 && f[1] == "commit" {
+				s.Pinned = f[2]
+			}
+		}
+		subs = append(subs, s)
+	}
+
+	parallelEach(len(subs), func(i int) {
+		s := &subs[i]
+		dir := filepath.Join(root, s.Path)
+		if !Exists(filepath.Join(dir, ".git")) {
+			return
+		}
+		s.Checkout, _ = gitOutput(dir, "rev-parse", "HEAD")
+		if st, err := gitOutput(dir, "status", "--porcelain"); err == nil && st != "" {
+			s.Dirty = len(strings.Split(st, "\n"))
+		}
+		// Compare against the last fetched state of the tracked branch.
+		s.TrackingRef = "origin/HEAD"
+		if s.Branch != "" && s.Branch != "." {
+			s.TrackingRef = "origin/" + s.Branch
+		}
+		if n, err := gitOutput(dir, "rev-list", "--count", "HEAD.."+s.TrackingRef); err == nil {
+			s.Behind, _ = strconv.Atoi(n)
+		}
+	})
+	return subs, nil
+}
+
+// runSubmodule implements `gits submodule [--update [--remote]]`.
+func runSubmodule(status *Status, args []string) {
+	c := status.cfg.Colors
+	update, remote := false, false
+	for _, a := range args {
+		switch a {
+		case "--update", "-u":
+			update = true
+		case "--remote":
+			remote = true
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	if update {
+		// --remote moves each submodule to its tracked branch instead of
+		// the pinned commit.
+		gitArgs := []string{"submodule", "update", "--init", "--recursive", "--progress"}
+		if remote {
+			gitArgs = append(gitArgs, "--remote")
+		}
+		out, err := runWithProgress(root, c, gitArgs...)
+		for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+			if line != "" {
+				fmt.Printf("   %s%s%s\n", Dim, line, Reset)
+			}
+		}
+		if err != nil {
+			fail(fmt.Errorf("git submodule update failed"))
+		}
+		fmt.Printf("%s %sSubmodules updated%s\n\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+	}
+
+	subs, err := listSubmodules(root)
+	if err != nil {
+		fail(err)
+	}
+	if len(subs) == 0 {
+		fmt.Printf("%s No submodules in %s\n", Icons.INFO, displayPath(root))
+		return
+	}
+	row := func(label, style, text string) {
+		fmt.Printf("    %s%-11s%s %s%s%s\n", Dim, label, Reset, style, text, Reset)
+	}
+	for _, s := range subs {
+		fmt.Printf("%s %s%s%s", Icons.FOLDER, Bold+resolveColor(c.TreeDir), s.Path, Reset)
+		if s.Branch != "" {
+			fmt.Printf(" %s%s %s%s", resolveColor(c.Branch), Icons.GIT, s.Branch, Reset)
+		}
+		fmt.Println()
+
+		pinned := "(not in HEAD)"
+		if s.Pinned != "" {
+			pinned = shortOid(s.Pinned)
+		}
+		row("pinned", "", pinned)
+		switch {
+		case s.Checkout == "":
+			row("checked out", Bold+resolveColor(c.AheadBehind), "not initialized (gits submodule --update)")
+		case s.Checkout == s.Pinned:
+			row("checked out", resolveColor(c.UpToDate), shortOid(s.Checkout)+" (matches)")
+		default:
+			row("checked out", Bold+resolveColor(c.Modified), shortOid(s.Checkout)+" (differs; `git add "+s.Path+"` and commit to pin it)")
+		}
+		if s.Dirty > 0 {
+			row("worktree", Bold+resolveColor(c.Modified), fmt.Sprintf("%d uncommitted change(s)", s.Dirty))
+		}
+		switch {
+		case s.Behind > 0:
+			row("upstream", Bold+resolveColor(c.AheadBehind),
+				fmt.Sprintf("%d commit(s) behind %s (gits submodule --update --remote)", s.Behind, s.TrackingRef))
+		case s.Behind == 0:
+			row("upstream", resolveColor(c.UpToDate), "up to date with "+s.TrackingRef)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// File: switch.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: fuzzy branch switcher (`gits switch`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// recentBranches returns branch names in the order they were last checked
+// out, most recent first, from the HEAD reflog.
+func recentBranches(dir string) map[string]int {
+	rank := map[string]int{}
+	out, err := gitOutput(dir, "reflog", "show", "-n", "1000", "--format=%gs", "HEAD")
+	if err != nil {
+		return rank
+	}
+	for _, line := range strings.Split(out, "\n") {
+		rest, ok := strings.CutPrefix(line, "checkout: moving from ")
+		if !ok {
+			continue
+		}
+		from, to, ok := strings.Cut(rest, " to ")
+		if !ok {
+			continue
+		}
+		for _, name := range []string{to, from} {
+			if _, seen := rank[name]; !seen {
+				rank[name] = len(rank)
+			}
+		}
+	}
+	return rank
+}
+
+// switchCandidates lists local branches by checkout recency (then commit
+// date), followed by remote branches that have no local counterpart.
+func switchCandidates(dir string) ([]branchInfo, error) {
+	branches, err := listBranches(dir, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	local := map[string]bool{}
+	for _, b := range branches {
+		if !b.Remote {
+			local[b.Name] = true
+		}
+	}
+	var out []branchInfo
+	for _, b := range branches {
+		if b.Remote {
+			_, name, _ := strings.Cut(b.Name, "/")
+			if local[name] {
+				continue
+			}
+		}
+		out = append(out, b)
+	}
+	// listBranches is newest-commit first; a stable sort keeps that order
+	// among branches the reflog does not mention.
+	rank := recentBranches(dir)
+	position := func(b branchInfo) int {
+		if b.Remote {
+			return 1 << 30
+		}
+		if r, ok := rank[b.Name]; ok {
+			return r
+		}
+		return 1 << 29
+	}
+	sort.SliceStable(out, func(i, j int) bool { return position(out[i]) < position(out[j]) })
+	return out, nil
+}
+
+// worktreeDirty reports whether rs has tracked changes (untracked files do
+// not get in the way of a switch).
+func worktreeDirty(rs *RepoStatus) bool {
+	for _, e := range rs.Entries {
+		if e.Kind == "unmerged" || e.Staged() || e.Unstaged() {
+			return true
+		}
+	}
+	return false
+}
+
+// runSwitch implements `gits switch [--yes] [QUERY]`.
+func runSwitch(status *Status, args []string) {
+	c := status.cfg.Colors
+	yes := false
+	query := ""
+	for _, a := range args {
+		switch a {
+		case "-y", "--yes":
+			yes = true
+		default:
+			query = a
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	branches, err := switchCandidates(root)
+	if err != nil {
+		fail(err)
+	}
+	var items []pickItem
+	for _, b := range branches {
+		tag, style := " ", Dim
+		switch {
+		case b.Current:
+			tag, style = "●", Bold+resolveColor(c.Branch)
+		case b.Remote:
+			tag, style = "↓", resolveColor(c.RemoteURL)
+		}
+		age := ""
+		if !b.LastCommit.IsZero() {
+			age = shortAge(b.LastCommit)
+		}
+		items = append(items, pickItem{Tag: fmt.Sprintf("%s %4s", tag, age), TagStyle: style, Label: b.Name})
+	}
+	if len(items) == 0 {
+		fail(fmt.Errorf("no branches"))
+	}
+
+	// An exact name, or a query with a single match, switches directly.
+	index := -1
+	for i, b := range branches {
+		if b.Name == query {
+			index = i
+		}
+	}
+	if matches := fuzzyFilter(query, items); index < 0 && query != "" && len(matches) == 1 {
+		index = matches[0]
+	}
+	if index < 0 {
+		i, ok, err := fuzzyFind("Switch branch in "+displayPath(root), query, items, c)
+		if err != nil {
+			fail(err)
+		}
+		if !ok {
+			return
+		}
+		index = i
+	}
+	target := branches[index]
+	if target.Current {
+		fmt.Printf("%s Already on %s%s%s\n", Icons.INFO, Bold+resolveColor(c.Branch), target.Name, Reset)
+		return
+	}
+
+	if rs := CollectStatus(root); worktreeDirty(rs) {
+		fmt.Printf("%s %sThe worktree has uncommitted changes; they are carried over to %s, or the switch is refused if they conflict%s\n",
+			Icons.WARNING, resolveColor(c.AheadBehind), target.Name, Reset)
+		if !yes {
+			ok, err := confirm("Switch anyway?")
+			if err != nil {
+				fail(fmt.Errorf("%v (use --yes)", err))
+			}
+			if !ok {
+				return
+			}
+		}
+	}
+
+	gitArgs := []string{"switch", target.Name}
+	if target.Remote {
+		// Picking origin/feature creates a local feature tracking it.
+		gitArgs = []string{"switch", "--track", target.Name}
+	}
+	if out, err := gitCmd(root, gitArgs...).CombinedOutput(); err != nil {
+		fail(fmt.Errorf("git %s: %s", strings.Join(gitArgs, " "), strings.TrimSpace(string(out))))
+	}
+	branch, _ := gitOutput(root, "branch", "--show-current")
+	fmt.Printf("%s %sSwitched to %s%s%s", Icons.SUCCESS, resolveColor(c.UpToDate), Bold+resolveColor(c.Branch), branch, Reset)
+	if target.Remote {
+		fmt.Printf(" %s(tracking %s)%s", Dim, target.Name, Reset)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,101 @@
+// File: symlinks.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: logical or physical paths when the working directory is a symlink
+// License: MIT
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// resolveSymlinks shows and uses physical paths, with every symlink
+// resolved, instead of the logical path the shell was cd'd through.  It is
+// set by --resolve-symlinks or resolve_symlinks = true in the config.
+var resolveSymlinks bool
+
+// workingDir is the absolute form of dir: logical by default, as in $PWD,
+// or physical under --resolve-symlinks.
+func workingDir(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+	if resolveSymlinks {
+		if real, err := filepath.EvalSymlinks(abs); err == nil {
+			return real
+		}
+	}
+	return abs
+}
+
+// samePath reports whether a and b name the same file, however many
+// symlinks either goes through.
+func samePath(a, b string) bool {
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+	fa, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	fb, err := os.Stat(b)
+	return err == nil && os.SameFile(fa, fb)
+}
+
+// logicalRoot maps root, the physical top level git reports, back onto
+// the logical path of dir inside it: the nearest ancestor of dir that is
+// the same directory as root.  Under --resolve-symlinks, or when no
+// ancestor matches, root is returned as is.
+func logicalRoot(root, dir string) string {
+	if resolveSymlinks {
+		return root
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil || abs == root || strings.HasPrefix(abs, root+string(filepath.Separator)) {
+		return root
+	}
+	for d := abs; ; d = filepath.Dir(d) {
+		if samePath(d, root) {
+			return d
+		}
+		if filepath.Dir(d) == d {
+			return root
+		}
+	}
+}
+
+// relPath is filepath.Rel made symlink-safe: when target does not sit
+// below base as written, both are resolved and compared again, so a file
+// reached through ~/work → /mnt/work is not shown as ../../mnt/work/...
+func relPath(base, target string) (string, error) {
+	rel, err := filepath.Rel(base, target)
+	if err == nil && !strings.HasPrefix(rel, "..") {
+		return rel, nil
+	}
+	rb, berr := filepath.EvalSymlinks(base)
+	rt, terr := evalExisting(target)
+	if berr != nil || terr != nil {
+		return rel, err
+	}
+	if r, err := filepath.Rel(rb, rt); err == nil && !strings.HasPrefix(r, "..") {
+		return r, nil
+	}
+	return rel, err
+}
+
+// evalExisting resolves the symlinks in p, or in its parent directory when
+// p itself is gone, as a deleted file in a status is.
+func evalExisting(p string) (string, error) {
+	if real, err := filepath.EvalSymlinks(p); err == nil {
+		return real, nil
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Dir(p))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, filepath.Base(p)), nil
+}
@@ -0,0 +1,162 @@
+// File: sync.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: fetch, integrate and push in one step (`gits sync`)
+// License: MIT
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SyncConfig controls `gits sync`.
+type SyncConfig struct {
+	Strategy string `toml:"strategy"` // rebase (default) or merge
+}
+
+// divergence counts commits HEAD is ahead of and behind its upstream.
+func divergence(dir string) (ahead, behind int, err error) {
+	out, err := gitOutput(dir, "rev-list", "--left-right", "--count", "HEAD...@{u}")
+	if err != nil {
+		return 0, 0, err
+	}
+	f := strings.Fields(out)
+	if len(f) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output %q", out)
+	}
+	ahead, _ = strconv.Atoi(f[0])
+	behind, _ = strconv.Atoi(f[1])
+	return ahead, behind, nil
+}
+
+// runWithProgress runs git in dir, streaming its colored progress to stderr,
+// and returns what it wrote to stdout.
+func runWithProgress(dir string, c ColorConfig, args ...string) (string, error) {
+	cmd := gitCmd(dir, args...)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return "", err
+	}
+	if err := cmd.Start(); err != nil {
+		return "", err
+	}
+	streamGitProgress(stderr, os.Stderr, c)
+	err = cmd.Wait()
+	return stdout.String(), err
+}
+
+// printDivergence prints one "label ↑ahead ↓behind" line.
+func printDivergence(label string, ahead, behind int, c ColorConfig) {
+	style := resolveColor(c.UpToDate)
+	if ahead > 0 || behind > 0 {
+		style = resolveColor(c.AheadBehind)
+	}
+	fmt.Printf("   %s%-7s%s %s↑%d ↓%d%s\n", Dim, label, Reset, Bold+style, ahead, behind, Reset)
+}
+
+// runSync implements `gits sync [--rebase|--merge]`: fetch the upstream's
+// remote, integrate what arrived, then push what is left, reporting the
+// divergence before and after.
+func runSync(status *Status, args []string) {
+	c := status.cfg.Colors
+	strategy := strings.ToLower(status.cfg.Sync.Strategy)
+	for _, a := range args {
+		switch a {
+		case "--rebase", "-r":
+			strategy = "rebase"
+		case "--merge", "-m":
+			strategy = "merge"
+		}
+	}
+	if strategy != "merge" {
+		strategy = "rebase"
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	branch, _ := gitOutput(root, "symbolic-ref", "--quiet", "--short", "HEAD")
+	if branch == "" {
+		fail(fmt.Errorf("HEAD is detached — switch to a branch to sync"))
+	}
+	upstream, err := gitOutput(root, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
+	if err != nil {
+		fail(fmt.Errorf("%s has no upstream (set one with `gits push -u`)", branch))
+	}
+	remote, _ := gitOutput(root, "config", "--get", "branch."+branch+".remote")
+	mergeRef, _ := gitOutput(root, "config", "--get", "branch."+branch+".merge")
+	if rs := CollectStatus(root); rs.Conflicts > 0 {
+		printConflicts(rs, c, "finish or abort the operation in progress first")
+		exit(1)
+	}
+
+	fmt.Printf("%s %sSyncing%s %s%s %s%s ⇄ %s%s%s %s(%s)%s\n", Icons.GIT, Bold+resolveColor(c.Header), Reset,
+		Bold+resolveColor(c.Branch), Icons.GIT, branch, Reset, resolveColor(c.RemoteURL), upstream, Reset, Dim, strategy, Reset)
+	startAhead, startBehind, _ := divergence(root)
+
+	// 1. fetch (a branch tracking a local branch has remote ".")
+	if remote != "." {
+		if _, err := runWithProgress(root, c, "fetch", "--progress", "--prune", remote); err != nil {
+			fail(fmt.Errorf("fetching %s failed", remote))
+		}
+	}
+	ahead, behind, err := divergence(root)
+	if err != nil {
+		fail(err)
+	}
+	printDivergence("before", ahead, behind, c)
+	if ahead != startAhead || behind != startBehind {
+		fmt.Printf("   %s(%d new upstream commit(s) fetched)%s\n", Dim, max(behind-startBehind, 0), Reset)
+	}
+
+	// 2. integrate
+	if behind > 0 {
+		gitArgs := []string{"rebase", "--autostash", "@{u}"}
+		hint := "fix the files, `git add` them, then `git rebase --continue` (or `git rebase --abort`), and run `gits sync` again"
+		if strategy == "merge" {
+			gitArgs = []string{"merge", "--autostash", "--no-edit", "@{u}"}
+			hint = "fix the files, `git add` them, then `git commit` (or `git merge --abort`), and run `gits sync` again"
+		}
+		out, err := runWithProgress(root, c, gitArgs...)
+		if err != nil {
+			rs := CollectStatus(root)
+			if rs.Conflicts > 0 {
+				printConflicts(rs, c, hint)
+			} else if out = strings.TrimSpace(out); out != "" {
+				fmt.Println(out)
+			}
+			fmt.Printf("%s %sSync stopped during %s; nothing was pushed%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), strategy, Reset)
+			exit(1)
+		}
+		fmt.Printf("%s %s%s onto %s: %d commit(s) integrated%s\n", Icons.SUCCESS, resolveColor(c.UpToDate),
+			map[string]string{"rebase": "Rebased", "merge": "Merged"}[strategy], upstream, behind, Reset)
+	}
+
+	// 3. push
+	if ahead, _, _ = divergence(root); ahead > 0 && remote != "." {
+		dst := strings.TrimPrefix(mergeRef, "refs/heads/")
+		out, err := runWithProgress(root, c, "push", "--progress", "--porcelain", remote, "HEAD:refs/heads/"+dst)
+		printPushSummary(root, out, c)
+		if err != nil {
+			fmt.Printf("%s %sPush failed%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), Reset)
+			exit(1)
+		}
+	}
+
+	ahead, behind, _ = divergence(root)
+	printDivergence("after", ahead, behind, c)
+	if ahead == 0 && behind == 0 {
+		fmt.Printf("%s %s%s is in sync with %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), branch, upstream, Reset)
+	}
+}
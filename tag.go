@@ -0,0 +1,310 @@
+// File: tag.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: tag listing and guided tag creation (`gits tag`)
+// License: MIT
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// semverTag matches version tags such as "v1.2.3" or "1.2.3-rc.1".
+var semverTag = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)(.*)$`)
+
+// tagInfo is one row of `gits tag`.
+type tagInfo struct {
+	Name      string
+	Annotated bool
+	Date      time.Time
+	Subject   string
+	Tagger    string
+	Signed    bool
+}
+
+// listTags returns tags sorted by version (default) or by date, newest first.
+func listTags(dir string, byDate bool) ([]tagInfo, error) {
+	sortKey := "-v:refname"
+	if byDate {
+		sortKey = "-creatordate"
+	}
+	format := strings.Join([]string{
+		"%(refname:short)", "%(objecttype)", "%(creatordate:unix)",
+		"%(contents:subject)", "%(taggername)", "%(contents:signature)",
+	}, "%00") + "%01"
+	out, err := gitOutput(dir, "for-each-ref", "--sort="+sortKey, "--format="+format, "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+	var tags []tagInfo
+	for _, rec := range strings.Split(out, "\x01") {
+		f := strings.Split(strings.TrimLeft(rec, "\n"), "\x00")
+		if len(f) < 6 || f[0] == "" {
+			continue
+		}
+		t := tagInfo{Name: f[0], Annotated: f[1] == "tag", Tagger: f[4], Signed: strings.TrimSpace(f[5]) != ""}
+		if t.Annotated {
+			t.Subject = f[3]
+		}
+		if ts, err := strconv.ParseInt(f[2], 10, 64); err == nil {
+			t.Date = time.Unix(ts, 0)
+		}
+		tags = append(tags, t)
+	}
+	return tags, nil
+}
+
+// goodSignature matches the signer in GPG and SSH verification output.
+var goodSignature = []*regexp.Regexp{
+	regexp.MustCompile(`Good signature from "([^"]+)"`),
+	regexp.MustCompile(`Good "git" signature for (\S+)`),
+}
+
+// tagSigner verifies a signed tag and returns who signed it and whether the
+// signature is valid ("unverified" when no signer could be determined).
+func tagSigner(dir, name string) (string, bool) {
+	cmd := gitCmd(dir, "tag", "-v", name)
+	out, err := cmd.CombinedOutput()
+	text := string(out)
+	for _, re := range goodSignature {
+		if m := re.FindStringSubmatch(text); m != nil {
+			return m[1], err == nil
+		}
+	}
+	return "unverified", false
+}
+
+// tagDistance describes where a tag sits relative to HEAD.
+func tagDistance(dir, name string) string {
+	out, err := gitOutput(dir, "rev-list", "--left-right", "--count", name+"^{commit}...HEAD")
+	if err != nil {
+		return ""
+	}
+	f := strings.Fields(out)
+	if len(f) != 2 {
+		return ""
+	}
+	tagOnly, headOnly := f[0], f[1]
+	switch {
+	case tagOnly == "0" && headOnly == "0":
+		return "at HEAD"
+	case tagOnly == "0":
+		return "HEAD +" + headOnly
+	case headOnly == "0":
+		return tagOnly + " ahead of HEAD"
+	}
+	return "not on this branch"
+}
+
+// nextVersions suggests patch, minor and major bumps of the newest semver tag.
+func nextVersions(tags []tagInfo) []string {
+	for _, t := range tags {
+		m := semverTag.FindStringSubmatch(t.Name)
+		if m == nil || m[5] != "" {
+			continue
+		}
+		major, _ := strconv.Atoi(m[2])
+		minor, _ := strconv.Atoi(m[3])
+		patch, _ := strconv.Atoi(m[4])
+		p := m[1]
+		return []string{
+			fmt.Sprintf("%s%d.%d.%d", p, major, minor, patch+1),
+			fmt.Sprintf("%s%d.%d.0", p, major, minor+1),
+			fmt.Sprintf("%s%d.0.0", p, major+1),
+		}
+	}
+	return []string{"v0.1.0", "v1.0.0"}
+}
+
+// runTag implements `gits tag [--date] [-n N|--all]` and
+// `gits tag create [NAME] [-m MSG] [--sign|--no-sign] [--ref REV] [--yes]`.
+func runTag(status *Status, args []string) {
+	if len(args) > 0 && (args[0] == "create" || args[0] == "new") {
+		createTag(status, args[1:])
+		return
+	}
+
+	c := status.cfg.Colors
+	byDate, limit := false, 20
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--date":
+			byDate = true
+		case "--all":
+			limit = 0
+		case "-n":
+			if i+1 < len(args) {
+				i++
+				limit, _ = strconv.Atoi(args[i])
+			}
+		}
+	}
+
+	tags, err := listTags(".", byDate)
+	if err != nil {
+		exitWithError(err, c)
+	}
+	if len(tags) == 0 {
+		fmt.Printf("%s No tags yet %s(gits tag create)%s\n", Icons.INFO, Dim, Reset)
+		return
+	}
+	total := len(tags)
+	if limit > 0 && len(tags) > limit {
+		tags = tags[:limit]
+	}
+
+	type row struct {
+		tag              tagInfo
+		distance, signer string
+		good             bool
+	}
+	rows := make([]row, len(tags))
+	parallelEach(len(tags), func(i int) {
+		rows[i] = row{tag: tags[i], distance: tagDistance(".", tags[i].Name)}
+		if tags[i].Signed {
+			rows[i].signer, rows[i].good = tagSigner(".", tags[i].Name)
+		}
+	})
+
+	width := 0
+	for _, r := range rows {
+		width = max(width, stringCells(r.tag.Name))
+	}
+	for _, r := range rows {
+		t := r.tag
+		fmt.Printf("🏷  %s%s%s  %s%-8s%s  %s%-18s%s", Bold+resolveColor(c.AheadBehind), padCells(t.Name, width, false), Reset,
+			Dim, shortAge(t.Date), Reset, resolveColor(c.Branch), r.distance, Reset)
+		if t.Annotated {
+			fmt.Printf(" %s", t.Subject)
+			if t.Tagger != "" {
+				fmt.Printf(" %s— %s%s", Dim, t.Tagger, Reset)
+			}
+		} else {
+			fmt.Printf(" %s(lightweight)%s", Dim, Reset)
+		}
+		if t.Signed {
+			if r.good {
+				fmt.Printf("  %s🔏 %s%s", resolveColor(c.UpToDate), r.signer, Reset)
+			} else {
+				fmt.Printf("  %s🔏 %s%s", resolveColor(c.Deleted), r.signer, Reset)
+			}
+		}
+		fmt.Println()
+	}
+	if total > len(rows) {
+		fmt.Printf("%s… %d more (--all)%s\n", Dim, total-len(rows), Reset)
+	}
+}
+
+// createTag creates an annotated (or signed) tag, asking for whatever was
+// not given on the command line.
+func createTag(status *Status, args []string) {
+	c := status.cfg.Colors
+	name, message, ref := "", "", "HEAD"
+	sign, signSet, yes := false, false, false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-m", "--message":
+			if i+1 < len(args) {
+				i++
+				message = args[i]
+			}
+		case "--ref":
+			if i+1 < len(args) {
+				i++
+				ref = args[i]
+			}
+		case "-s", "--sign":
+			sign, signSet = true, true
+		case "--no-sign":
+			sign, signSet = false, true
+		case "--yes", "-y":
+			yes = true
+		default:
+			name = args[i]
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	commit, err := gitOutput(".", "log", "-1", "--format=%h %s", ref)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Printf("%s Tagging %s%s%s\n", Icons.INFO, Bold+resolveColor(c.AheadBehind), commit, Reset)
+
+	if name == "" {
+		tags, _ := listTags(".", false)
+		suggestions := nextVersions(tags)
+		items := make([]pickItem, 0, len(suggestions)+1)
+		for _, s := range suggestions {
+			items = append(items, pickItem{Label: s})
+		}
+		items = append(items, pickItem{Label: Dim + "other…" + Reset})
+		idx, ok, err := pickOne("Tag name", items, c)
+		if err != nil {
+			fail(fmt.Errorf("%v (give the tag name as an argument)", err))
+		}
+		if !ok {
+			return
+		}
+		if idx < len(suggestions) {
+			name = suggestions[idx]
+		} else if name, err = promptLine("Tag name:", ""); err != nil || name == "" {
+			return
+		}
+	}
+	if _, err := gitOutput(".", "check-ref-format", "refs/tags/"+name); err != nil {
+		fail(fmt.Errorf("%q is not a valid tag name", name))
+	}
+	if _, err := gitOutput(".", "rev-parse", "--verify", "--quiet", "refs/tags/"+name); err == nil {
+		fail(fmt.Errorf("tag %s already exists", name))
+	}
+
+	if message == "" {
+		if message, err = promptLine("Message:", "Release "+name); err != nil {
+			fail(fmt.Errorf("%v (use -m)", err))
+		}
+	}
+	if !signSet {
+		if key, _ := gitOutput(".", "config", "--get", "user.signingkey"); key != "" {
+			if ok, err := confirm("Sign the tag with " + key + "?"); err == nil {
+				sign = ok
+			}
+		}
+	}
+
+	kind := "annotated"
+	if sign {
+		kind = "signed"
+	}
+	if !yes {
+		ok, err := confirm(fmt.Sprintf("Create %s tag %s?", kind, name))
+		if err != nil {
+			fail(fmt.Errorf("%v (use --yes)", err))
+		}
+		if !ok {
+			return
+		}
+	}
+
+	flag := "-a"
+	if sign {
+		flag = "-s"
+	}
+	cmd := gitCmd(".", "tag", flag, name, "-m", message, ref)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		fail(errors.New("git tag failed"))
+	}
+	fmt.Printf("%s %sCreated %s tag %s%s %s(publish with: gits push origin %s)%s\n",
+		Icons.SUCCESS, resolveColor(c.UpToDate), kind, name, Reset, Dim, name, Reset)
+}
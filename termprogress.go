@@ -0,0 +1,53 @@
+// File: termprogress.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: taskbar progress for long git operations (OSC 9;4)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// termProgress reports the progress of a long operation with the OSC 9;4
+// sequence of ConEmu and Windows Terminal (also understood by WezTerm,
+// Ghostty and others), which shows it in the taskbar or tab.  A nil
+// *termProgress reports nothing, and so does one whose stderr is not a
+// terminal.
+type termProgress struct {
+	last string
+}
+
+// newTermProgress starts reporting on stderr, with the operation's length
+// unknown until the first set.  GITS_NO_TASKBAR turns it off.
+func newTermProgress() *termProgress {
+	if !term.IsTerminal(int(os.Stderr.Fd())) || os.Getenv("GITS_NO_TASKBAR") != "" || os.Getenv("TERM") == "dumb" {
+		return nil
+	}
+	p := &termProgress{}
+	p.send("3;0")
+	return p
+}
+
+// send writes the sequence for state, skipping repeats of the last one.
+func (p *termProgress) send(state string) {
+	if p == nil || state == p.last {
+		return
+	}
+	p.last = state
+	fmt.Fprint(os.Stderr, tmuxPassthrough("\x1b]9;4;"+state+"\a"))
+}
+
+// set shows pct percent done.
+func (p *termProgress) set(pct int) {
+	p.send(fmt.Sprintf("1;%d", min(max(pct, 0), 100)))
+}
+
+// done clears the progress, whether the operation worked or failed.
+func (p *termProgress) done() {
+	p.send("0;0")
+}
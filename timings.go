@@ -0,0 +1,185 @@
+// File: timings.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: --timings and --cpuprofile/--memprofile: where a gits run spends its time
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"sync"
+	"time"
+)
+
+// timings collects the git invocations of this run; nil unless --timings.
+var timings *timingLog
+
+// atExit is run by exit, last added first, before gits ends: the
+// --timings report and the profiles, which a defer would miss on every
+// path that ends the process early.
+var (
+	atExit     []func()
+	atExitOnce sync.Once
+)
+
+// exit ends gits with code once atExit has run.  Call it instead of
+// os.Exit.
+func exit(code int) {
+	atExitOnce.Do(func() {
+		for i := len(atExit) - 1; i >= 0; i-- {
+			atExit[i]()
+		}
+	})
+	os.Exit(code)
+}
+
+// timingPhases are the parts of a run --timings adds up: starting git
+// processes, waiting for their output, parsing it and printing the result.
+var timingPhases = []string{"spawn", "git", "parse", "render"}
+
+// timingLog is every git process started, in order, with how long the ones
+// gits waits on took, and the time spent in each phase.
+type timingLog struct {
+	mu     sync.Mutex
+	start  time.Time
+	calls  []*gitTiming
+	byCmd  map[*exec.Cmd]*gitTiming
+	phases map[string]time.Duration
+}
+
+type gitTiming struct {
+	args  []string
+	start time.Time
+	spawn time.Duration
+	took  time.Duration // 0 while running, or when gits does not wait on it
+}
+
+func startTimings() {
+	timings = &timingLog{start: time.Now(), byCmd: map[*exec.Cmd]*gitTiming{}, phases: map[string]time.Duration{}}
+}
+
+// phase adds the time since start to a phase of the run.
+func (t *timingLog) phase(name string, start time.Time) {
+	if t == nil {
+		return
+	}
+	d := time.Since(start)
+	t.mu.Lock()
+	t.phases[name] += d
+	t.mu.Unlock()
+}
+
+// gitStarted records a git command about to run.
+func (t *timingLog) gitStarted(cmd *exec.Cmd) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	args := cmd.Args[1:]
+	if cmd.Dir != "" && cmd.Dir != "." {
+		// Shown the way git spells it, so a line can be run as it is.
+		args = append([]string{"-C", cmd.Dir}, args...)
+	}
+	gt := &gitTiming{args: args, start: time.Now()}
+	t.calls = append(t.calls, gt)
+	t.byCmd[cmd] = gt
+}
+
+// gitDone records that cmd has finished, spawn after being started.
+func (t *timingLog) gitDone(cmd *exec.Cmd, spawn time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if gt, ok := t.byCmd[cmd]; ok {
+		gt.took, gt.spawn = time.Since(gt.start), spawn
+		t.phases["spawn"] += spawn
+		t.phases["git"] += gt.took - spawn
+		delete(t.byCmd, cmd)
+	}
+}
+
+// runTimed is cmd.Run, timing the start of the process apart from the wait
+// for it to finish.
+func runTimed(cmd *exec.Cmd) error {
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		timings.gitDone(cmd, time.Since(start))
+		return err
+	}
+	spawn := time.Since(start)
+	err := cmd.Wait()
+	timings.gitDone(cmd, spawn)
+	return err
+}
+
+// print writes the breakdown to stderr, so it never mixes with --json.
+func (t *timingLog) print() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var inGit time.Duration
+	for _, gt := range t.calls {
+		inGit += gt.took
+	}
+	fmt.Fprintf(os.Stderr, "%stimings: %s total, %d git invocation(s) taking %s%s\n", Dim,
+		time.Since(t.start).Round(time.Microsecond), len(t.calls), inGit.Round(time.Microsecond), Reset)
+	// Calls run side by side, so the phases can add up to more than the
+	// total.
+	var parts []string
+	for _, name := range timingPhases {
+		parts = append(parts, fmt.Sprintf("%s %s", name, t.phases[name].Round(time.Microsecond)))
+	}
+	fmt.Fprintf(os.Stderr, "%s  %s%s\n", Dim, strings.Join(parts, " · "), Reset)
+	for _, gt := range t.calls {
+		took := "       —"
+		if gt.took > 0 {
+			took = fmt.Sprintf("%8s", gt.took.Round(time.Microsecond))
+		}
+		fmt.Fprintf(os.Stderr, "%s  %s  +%-8s git %s%s\n", Dim, took, gt.start.Sub(t.start).Round(time.Microsecond),
+			strings.Join(gt.args, " "), Reset)
+	}
+}
+
+// startProfiles starts a CPU profile into cpuFile and returns a function
+// that stops it and writes a heap profile into memFile; either may be "".
+func startProfiles(cpuFile, memFile string) (stop func(), err error) {
+	var cpu *os.File
+	if cpuFile != "" {
+		if cpu, err = os.Create(cpuFile); err != nil {
+			return nil, err
+		}
+		if err = pprof.StartCPUProfile(cpu); err != nil {
+			cpu.Close()
+			return nil, err
+		}
+	}
+	return func() {
+		if cpu != nil {
+			pprof.StopCPUProfile()
+			cpu.Close()
+		}
+		if memFile != "" {
+			f, err := os.Create(memFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "memprofile: %v\n", err)
+				return
+			}
+			defer f.Close()
+			runtime.GC()
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				fmt.Fprintf(os.Stderr, "memprofile: %v\n", err)
+			}
+		}
+	}, nil
+}
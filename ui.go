@@ -0,0 +1,666 @@
+// File: ui.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: full-screen terminal UI over the status parser (`gits ui`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// uiRow is one line of the status list: a section heading or an entry.
+// An entry with both staged and unstaged changes has a row in each section.
+type uiRow struct {
+	heading string
+	entry   FileEntry
+	staged  bool // the row stands for the index side of the entry
+}
+
+// key identifies the row across refreshes.
+func (r uiRow) key() string {
+	if r.staged {
+		return "i:" + r.entry.Path
+	}
+	return "w:" + r.entry.Path
+}
+
+// statusUI is the state of `gits ui`.
+type statusUI struct {
+	c       ColorConfig
+	root    string
+	rs      *RepoStatus
+	rows    []uiRow
+	cursor  int // index into rows, never a heading; -1 when there is none
+	top     int
+	width   int
+	height  int
+	listH   int
+	details map[string][]string
+	message string
+	note    string // success message, shown like message
+
+	// The bottom pane: details and diff of the selected row.
+	paneKey   string // row the pane shows; scrolling restarts when it changes
+	scroll    int
+	paneH     int
+	paneFocus bool // the pane fills the screen and the keys scroll it
+
+	filter    string // fuzzy query narrowing the entries
+	filtering bool   // typing goes to the filter
+
+	split    int  // list height set by dragging the splitter; 0 = half
+	dragging bool // the splitter is being dragged
+
+	changes <-chan struct{} // worktree or refs changed; nil when not watching
+}
+
+// uiDiffMaxLines caps the preview of huge diffs.
+const uiDiffMaxLines = 5000
+
+// statusCodeNames spells out the porcelain status letters.
+var statusCodeNames = map[string]string{
+	"M": "modified",
+	"T": "type changed",
+	"A": "added",
+	"D": "deleted",
+	"R": "renamed",
+	"C": "copied",
+	"U": "unmerged",
+	"?": "untracked",
+}
+
+// clipANSI cuts s to width terminal columns, keeping its escape sequences
+// intact.  Tabs become four spaces.
+func clipANSI(s string, width int) string {
+	s = strings.ReplaceAll(s, "\t", "    ")
+	var sb strings.Builder
+	var cc cellCounter
+	cells := 0
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			j := i + 1
+			if j < len(s) && s[j] == '[' {
+				j++
+				for j < len(s) && (s[j] < 0x40 || s[j] > 0x7e) {
+					j++
+				}
+				j++
+			}
+			j = min(j, len(s))
+			sb.WriteString(s[i:j])
+			i = j
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := cc.next(r)
+		if cells+w > width {
+			break
+		}
+		cells += w
+		sb.WriteString(s[i : i+size])
+		i += size
+	}
+	sb.WriteString(Reset)
+	return sb.String()
+}
+
+// padANSI clips s to width columns and pads it with spaces to exactly
+// width, so a second column can follow it.
+func padANSI(s string, width int) string {
+	clipped := clipANSI(s, width)
+	var cc cellCounter
+	cells := 0
+	for i := 0; i < len(clipped); {
+		if clipped[i] == 0x1b {
+			j := i + 1
+			if j < len(clipped) && clipped[j] == '[' {
+				for j++; j < len(clipped) && (clipped[j] < 0x40 || clipped[j] > 0x7e); j++ {
+				}
+				j++
+			}
+			i = min(j, len(clipped))
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(clipped[i:])
+		cells += cc.next(r)
+		i += size
+	}
+	return clipped + strings.Repeat(" ", max(width-cells, 0))
+}
+
+// newStatusUI loads the status of the repository at root.
+func newStatusUI(root string, c ColorConfig) *statusUI {
+	u := &statusUI{c: c, root: root, cursor: -1}
+	u.refresh()
+	return u
+}
+
+// refresh re-reads the status, keeping the cursor on the same row when it
+// still exists.
+func (u *statusUI) refresh() {
+	rs := CollectStatus(u.root)
+	if rs.Err != "" {
+		u.message = rs.Err
+		if u.rs != nil {
+			return
+		}
+	}
+	u.rs = rs
+	u.details = map[string][]string{}
+	u.buildRows()
+}
+
+// buildRows groups the entries into sections, like `git status` does.
+func (u *statusUI) buildRows() {
+	selected := ""
+	if u.cursor >= 0 && u.cursor < len(u.rows) {
+		selected = u.rows[u.cursor].key()
+	}
+	var conflicts, staged, unstaged, untracked []uiRow
+	for _, e := range u.rs.Entries {
+		if _, _, ok := fuzzyMatch(u.filter, entryLabel(e)); !ok {
+			continue
+		}
+		switch {
+		case e.Kind == "unmerged":
+			conflicts = append(conflicts, uiRow{entry: e})
+		case e.Kind == "untracked":
+			untracked = append(untracked, uiRow{entry: e})
+		default:
+			if e.Staged() {
+				staged = append(staged, uiRow{entry: e, staged: true})
+			}
+			if e.Unstaged() {
+				unstaged = append(unstaged, uiRow{entry: e})
+			}
+		}
+	}
+	u.rows = u.rows[:0]
+	section := func(title string, rows []uiRow) {
+		if len(rows) == 0 {
+			return
+		}
+		u.rows = append(u.rows, uiRow{heading: fmt.Sprintf("%s (%d)", title, len(rows))})
+		u.rows = append(u.rows, rows...)
+	}
+	section("Unmerged paths", conflicts)
+	section("Changes to be committed", staged)
+	section("Changes not staged for commit", unstaged)
+	section("Untracked files", untracked)
+
+	old := u.cursor
+	u.cursor = -1
+	for i, r := range u.rows {
+		if r.heading == "" && r.key() == selected {
+			u.cursor = i
+			return
+		}
+	}
+	// The row went away: stay at the same height in the list.
+	u.jump(min(max(old, 0), len(u.rows)-1), 1)
+}
+
+// jump puts the cursor on row i, or the nearest entry in direction dir
+// (then the other way) when i is a heading.
+func (u *statusUI) jump(i, dir int) {
+	for _, d := range []int{dir, -dir} {
+		for j := i; j >= 0 && j < len(u.rows); j += d {
+			if u.rows[j].heading == "" {
+				u.cursor = j
+				return
+			}
+		}
+	}
+}
+
+// move shifts the cursor by delta entries' worth of rows.
+func (u *statusUI) move(delta int) {
+	if u.cursor < 0 {
+		return
+	}
+	dir := 1
+	if delta < 0 {
+		dir = -1
+	}
+	target := min(max(u.cursor+delta, 0), len(u.rows)-1)
+	if u.rows[target].heading != "" && target+dir >= 0 && target+dir < len(u.rows) {
+		target += dir
+	}
+	u.jump(target, dir)
+}
+
+// nextSection moves the cursor to the first entry of the next section,
+// wrapping around.
+func (u *statusUI) nextSection() {
+	if u.cursor < 0 {
+		return
+	}
+	for i := 1; i <= len(u.rows); i++ {
+		j := (u.cursor + i) % len(u.rows)
+		if u.rows[j].heading != "" {
+			u.jump(j, 1)
+			return
+		}
+	}
+}
+
+// selected returns the row under the cursor.
+func (u *statusUI) selected() (uiRow, bool) {
+	if u.cursor < 0 || u.cursor >= len(u.rows) {
+		return uiRow{}, false
+	}
+	return u.rows[u.cursor], true
+}
+
+// entryDiff is the patch for row r: its staged or unstaged side, or the
+// whole file when it is untracked.
+func entryDiff(root string, r uiRow) string {
+	e := r.entry
+	var cmd *exec.Cmd
+	switch {
+	case e.Kind == "untracked":
+		if strings.HasSuffix(e.Path, "/") {
+			return ""
+		}
+		cmd = gitCmd(root, "diff", "--no-color", "--no-ext-diff", "--no-index", "--", os.DevNull, e.Path)
+	case r.staged:
+		args := []string{"diff", "--cached", "--no-color", "--no-ext-diff", "-M", "--"}
+		if e.OrigPath != "" {
+			args = append(args, e.OrigPath)
+		}
+		cmd = gitCmd(root, append(args, e.Path)...)
+	default:
+		cmd = gitCmd(root, "diff", "--no-color", "--no-ext-diff", "--", e.Path)
+	}
+	// --no-index exits 1 when the files differ; the output is what counts.
+	out, _ := cmd.Output()
+	return string(out)
+}
+
+// entryDetails describes the selected row for the details pane.
+func (u *statusUI) entryDetails(r uiRow) []string {
+	c, e := u.c, r.entry
+	lines := []string{Bold + e.Path + Reset}
+	if e.OrigPath != "" {
+		lines = append(lines, fmt.Sprintf("%srenamed from%s %s", Dim, Reset, e.OrigPath))
+	}
+	state := func(label, code string) string {
+		name := statusCodeNames[code]
+		if name == "" {
+			name = "unchanged"
+		}
+		return fmt.Sprintf("%s%-9s%s %s", Dim, label, Reset, name)
+	}
+	switch e.Kind {
+	case "untracked":
+		lines = append(lines, fmt.Sprintf("%s%-9s%s not tracked, %s", Dim, "state", Reset, humanSize(pathSize(filepath.Join(u.root, e.Path)))))
+	case "unmerged":
+		lines = append(lines, fmt.Sprintf("%s%-9s%s %sconflict (%s)%s %s· m resolves it%s", Dim, "state", Reset, resolveColor(c.Deleted),
+			entryCode(e), Reset, Dim, Reset))
+	default:
+		lines = append(lines, state("index", e.Index), state("worktree", e.Worktree))
+		args := []string{"diff", "--numstat"}
+		if r.staged {
+			args = append(args, "--cached")
+		}
+		if out, err := gitOutput(u.root, append(args, "--", e.Path)...); err == nil && out != "" {
+			if f := strings.Fields(out); len(f) >= 2 {
+				if f[0] == "-" {
+					lines = append(lines, fmt.Sprintf("%s%-9s%s binary", Dim, "changes", Reset))
+				} else {
+					lines = append(lines, fmt.Sprintf("%s%-9s%s %s+%s%s %s-%s%s", Dim, "changes", Reset,
+						resolveColor(c.Added), f[0], Reset, resolveColor(c.Deleted), f[1], Reset))
+				}
+			}
+		}
+	}
+	if last, err := gitOutput(u.root, "log", "-1", "--format=%h %s (%ar)", "--", e.Path); err == nil && last != "" {
+		lines = append(lines, fmt.Sprintf("%s%-9s%s %s", Dim, "last", Reset, last))
+	}
+	if patch := entryDiff(u.root, r); patch != "" {
+		diff := strings.Split(strings.Trim(renderDiff(patch, c), "\n"), "\n")
+		if len(diff) > uiDiffMaxLines {
+			diff = append(diff[:uiDiffMaxLines], fmt.Sprintf("%s… %d more line(s)%s", Dim, len(diff)-uiDiffMaxLines, Reset))
+		}
+		lines = append(append(lines, ""), diff...)
+	}
+	return lines
+}
+
+// draw renders the whole screen.
+func (u *statusUI) draw() {
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		u.width, u.height = w, h
+	}
+	c := u.c
+	// Header, pane title and footer take a line each; the list and the pane
+	// share the rest, or the pane takes it all when focused.
+	u.paneH = max((u.height-3)/2, 3)
+	if u.split > 0 {
+		u.paneH = max(u.height-3-u.split, 1)
+	}
+	u.listH = max(u.height-u.paneH-3, 1)
+	if u.paneFocus {
+		u.paneH, u.listH = max(u.height-3, 1), 0
+	}
+	if u.cursor >= 0 && u.listH > 0 {
+		if u.cursor < u.top {
+			u.top = u.cursor
+		}
+		if u.cursor >= u.top+u.listH {
+			u.top = u.cursor - u.listH + 1
+		}
+		// Show the heading of the first section when it fits.
+		if u.top == 1 && u.cursor < u.listH {
+			u.top = 0
+		}
+	}
+
+	var lines []string
+	rs := u.rs
+	branch := rs.Branch
+	if rs.Detached {
+		branch = "(detached " + shortOid(rs.Oid) + ")"
+	}
+	header := fmt.Sprintf("%s %s%s%s", Icons.GIT, Bold+resolveColor(c.Branch), branch, Reset)
+	if rs.Upstream != "" {
+		header += fmt.Sprintf(" %s→ %s%s", Dim, rs.Upstream, Reset)
+		if rs.Ahead+rs.Behind > 0 {
+			header += fmt.Sprintf(" %s↑%d ↓%d%s", resolveColor(c.AheadBehind), rs.Ahead, rs.Behind, Reset)
+		}
+	}
+	header += fmt.Sprintf("  %s%d staged · %d modified · %d untracked", Dim, rs.Staged, rs.Modified, rs.Untracked)
+	if rs.Conflicts > 0 {
+		header += fmt.Sprintf(" · %d conflicted", rs.Conflicts)
+	}
+	if u.filter != "" {
+		header += fmt.Sprintf("%s  %sfilter: %s", Reset, resolveColor(c.Arrow), u.filter)
+	}
+	lines = append(lines, header+Reset)
+
+	for i := u.top; i < u.top+u.listH; i++ {
+		switch {
+		case len(u.rows) == 0 && i == 0 && u.filter != "":
+			lines = append(lines, fmt.Sprintf("%s No file matches %s\"%s\"%s", Icons.INFO, Bold, u.filter, Reset))
+		case len(u.rows) == 0 && i == 0:
+			lines = append(lines, fmt.Sprintf("%s %sNothing to commit, working tree clean%s", Icons.SUCCESS, resolveColor(c.UpToDate), Reset))
+		case i >= len(u.rows):
+			lines = append(lines, "")
+		case u.rows[i].heading != "":
+			lines = append(lines, Bold+resolveColor(c.Header)+u.rows[i].heading+Reset)
+		default:
+			r := u.rows[i]
+			style := entryStyle(r.entry, c)
+			if r.staged {
+				style = Bold + resolveColor(c.Staged)
+			}
+			pointer, label := "  ", entryLabel(r.entry)
+			if u.filter != "" {
+				_, positions, _ := fuzzyMatch(u.filter, label)
+				label = highlightMatch(label, positions, Bold+resolveColor(c.Arrow))
+			}
+			if i == u.cursor {
+				pointer, label = Bold+resolveColor(c.Arrow)+"❯ "+Reset, Bold+label+Reset
+			}
+			lines = append(lines, fmt.Sprintf("%s%s%s%s %s", pointer, style, entryCode(r.entry), Reset, label))
+		}
+	}
+
+	title := " details "
+	var details []string
+	if r, ok := u.selected(); ok {
+		if _, cached := u.details[r.key()]; !cached {
+			u.details[r.key()] = u.entryDetails(r)
+		}
+		details = u.details[r.key()]
+		if r.key() != u.paneKey {
+			u.paneKey, u.scroll = r.key(), 0
+		}
+		u.scroll = max(min(u.scroll, len(details)-u.paneH), 0)
+		if len(details) > u.paneH {
+			title = fmt.Sprintf(" %s · %d–%d of %d ", entryLabel(r.entry), u.scroll+1, min(u.scroll+u.paneH, len(details)), len(details))
+		}
+	}
+	lines = append(lines, Dim+"──"+title+strings.Repeat("─", max(u.width-stringCells(title)-2, 0))+Reset)
+	for i := u.scroll; i < u.scroll+u.paneH; i++ {
+		if i < len(details) {
+			lines = append(lines, " "+details[i])
+		} else {
+			lines = append(lines, "")
+		}
+	}
+
+	footer := Dim + "↑↓ move · tab section · space stage/unstage · a/u stage/unstage all · h lines · c commit · b branches · s stashes · / filter · d diff · J/K scroll · r refresh · q quit" + Reset
+	if u.paneFocus {
+		footer = Dim + "↑↓ scroll · PgUp/PgDn page · g/G top/bottom · d/q back" + Reset
+	}
+	if u.filtering {
+		footer = fmt.Sprintf("%s/%s%s%s█%s  %senter keep · esc clear%s", Bold+resolveColor(c.Arrow), Reset, u.filter,
+			resolveColor(c.Arrow), Reset, Dim, Reset)
+	}
+	if u.note != "" {
+		footer = resolveColor(c.UpToDate) + u.note + Reset
+	}
+	if u.message != "" {
+		footer = resolveColor(c.AheadBehind) + u.message + Reset
+	}
+	lines = append(lines, footer)
+
+	var sb strings.Builder
+	sb.WriteString("\x1b[H")
+	for i, l := range lines[:min(len(lines), u.height)] {
+		if i > 0 {
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString(clipANSI(l, u.width) + "\x1b[K")
+	}
+	sb.WriteString("\x1b[J")
+	fmt.Print(sb.String())
+}
+
+// loop reads keys until the user quits, refreshing whenever the watcher
+// reports a change.
+func (u *statusUI) loop() error {
+	for {
+		u.draw()
+		var kp keyPress
+		select {
+		case <-u.changes:
+			u.refresh()
+			continue
+		case kp = <-keyInput:
+		}
+		key, err := kp.key, kp.err
+		if err != nil {
+			return err
+		}
+		u.message, u.note = "", ""
+		if key == keyCtrlC {
+			return nil
+		}
+		if ev, ok := parseMouse(key); ok {
+			u.mouse(ev)
+			continue
+		}
+		if u.paneFocus {
+			u.scrollKey(key)
+			continue
+		}
+		if u.filtering && u.filterKey(key) {
+			continue
+		}
+		switch boundAction("ui", key) {
+		case "up":
+			u.move(-1)
+		case "down":
+			u.move(1)
+		case "pgup":
+			u.move(-u.listH)
+		case "pgdn":
+			u.move(u.listH)
+		case "top":
+			u.jump(0, 1)
+		case "bottom":
+			u.jump(len(u.rows)-1, -1)
+		case "section":
+			u.nextSection()
+		case "toggle":
+			u.toggle()
+		case "stage-all":
+			u.stage(u.rows)
+		case "unstage-all":
+			u.unstage(u.rows)
+		case "filter":
+			u.filtering = true
+		case "lines":
+			u.hunks()
+		case "commit":
+			u.commitForm()
+		case "branches":
+			u.branches()
+		case "stashes":
+			u.stashes()
+		case "resolve":
+			u.resolve()
+		case "diff":
+			u.paneFocus = u.cursor >= 0
+		case "scroll-down":
+			u.scroll++
+		case "scroll-up":
+			u.scroll = max(u.scroll-1, 0)
+		case "refresh":
+			u.refresh()
+		case "quit":
+			return nil
+		}
+	}
+}
+
+// filterKey handles a key while the filter is being typed.  It reports
+// false for keys it leaves to the list, such as the arrows.
+func (u *statusUI) filterKey(key string) bool {
+	switch boundAction("filter", key) {
+	case "accept":
+		u.filtering = false
+	case "cancel":
+		u.filter, u.filtering = "", false
+		u.buildRows()
+	case "erase":
+		if u.filter != "" {
+			_, size := utf8.DecodeLastRuneInString(u.filter)
+			u.filter = u.filter[:len(u.filter)-size]
+			u.buildRows()
+		}
+	case "clear":
+		u.filter = ""
+		u.buildRows()
+	default:
+		if key == "" || strings.IndexFunc(key, func(r rune) bool { return !unicode.IsPrint(r) }) >= 0 {
+			return false
+		}
+		u.filter += key
+		u.buildRows()
+	}
+	return true
+}
+
+// scrollKey handles a key while the pane is focused.
+func (u *statusUI) scrollKey(key string) {
+	switch boundAction("pane", key) {
+	case "up":
+		u.scroll = max(u.scroll-1, 0)
+	case "down":
+		u.scroll++
+	case "pgup":
+		u.scroll = max(u.scroll-u.paneH, 0)
+	case "pgdn":
+		u.scroll += u.paneH
+	case "top":
+		u.scroll = 0
+	case "bottom":
+		u.scroll = len(u.details[u.paneKey])
+	case "back":
+		u.paneFocus = false
+	}
+}
+
+// uiWatch reports changes to the worktree and refs of root, debounced,
+// using the [watch] settings of `gits --watch`.
+func uiWatch(root string, wc WatchConfig) <-chan struct{} {
+	// Our own `git status` runs must not refresh the index, otherwise each
+	// refresh would trigger the next one.
+	primeStatusAccel(root)
+	os.Setenv("GIT_OPTIONAL_LOCKS", "0")
+	if !wc.Poll {
+		if ch, err := watchNotify(root, parseDurationOr(wc.Debounce, 300*time.Millisecond)); err == nil {
+			return ch
+		}
+	}
+	return watchPoll(root, parseDurationOr(wc.PollInterval, 2*time.Second))
+}
+
+// runUI implements `gits ui [--no-mouse] [--no-watch] [DIR]`: a full-screen
+// view of the status with a details pane for the selected file.
+func runUI(status *Status, args []string) {
+	c := status.cfg.Colors
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+	dir, mouse, watch := ".", true, true
+	for _, a := range args {
+		switch a {
+		case "--no-mouse":
+			mouse = false
+		case "--no-watch":
+			watch = false
+		default:
+			dir = a
+		}
+	}
+	root, err := repoRoot(dir)
+	if err != nil {
+		fail(err)
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		fail(errNotTerminal)
+	}
+	u := newStatusUI(root, c)
+	if u.rs.Err != "" {
+		fail(fmt.Errorf("%s", u.rs.Err))
+	}
+	if watch {
+		u.changes = uiWatch(root, status.cfg.Watch)
+	}
+
+	// Alternate screen, hidden cursor; both undone on the way out.
+	leave := "\x1b[?25h\x1b[?1049l"
+	if mouse {
+		leave = mouseOff + leave
+	}
+	restore, err := rawTerminal(fd, leave)
+	if err != nil {
+		fail(err)
+	}
+	startKeyReader()
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	if mouse {
+		fmt.Print(mouseOn)
+	}
+	err = u.loop()
+	restore()
+	if err != nil {
+		fail(err)
+	}
+}
@@ -0,0 +1,321 @@
+// File: uibranch.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: branches panel of `gits ui`
+// License: MIT
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// uiPrompt is a one-line text input shown in place of a footer.
+type uiPrompt struct {
+	label  string
+	text   string
+	active bool
+}
+
+// key edits the input; it reports true when the text is submitted.
+func (p *uiPrompt) key(key string) bool {
+	switch boundAction("prompt", key) {
+	case "accept":
+		p.active = false
+		return true
+	case "cancel":
+		p.active = false
+	case "erase":
+		if p.text != "" {
+			_, size := utf8.DecodeLastRuneInString(p.text)
+			p.text = p.text[:len(p.text)-size]
+		}
+	case "clear":
+		p.text = ""
+	default:
+		if strings.IndexFunc(key, func(r rune) bool { return !unicode.IsPrint(r) }) < 0 {
+			p.text += key
+		}
+	}
+	return false
+}
+
+// line renders the input with a caret.
+func (p *uiPrompt) line(c ColorConfig) string {
+	return fmt.Sprintf("%s%s:%s %s%s█%s", Bold+resolveColor(c.Arrow), p.label, Reset, p.text, resolveColor(c.Arrow), Reset)
+}
+
+// uiGit runs a git command for the UI, turning a failure into one line:
+// git's first error, or else its last line of output (hints follow errors).
+func uiGit(root string, args ...string) error {
+	cmd := gitCmd(root, args...)
+	var buf bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &buf, &buf
+	err := runTimed(cmd)
+	out := buf.Bytes()
+	if err == nil {
+		return nil
+	}
+	msg := strings.TrimSpace(string(out))
+	if i := strings.LastIndex(msg, "\n"); i >= 0 {
+		msg = msg[i+1:]
+	}
+	for _, l := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(l, "error: ") || strings.HasPrefix(l, "fatal: ") {
+			msg = l
+			break
+		}
+	}
+	if msg == "" {
+		msg = err.Error()
+	}
+	return fmt.Errorf("git %s: %s", args[0], msg)
+}
+
+// branchPanel lists the local branches over the status view.
+type branchPanel struct {
+	c        ColorConfig
+	root     string
+	branches []branchInfo
+	cursor   int
+	top      int
+	message  string
+	note     string
+	prompt   uiPrompt
+	question string // a y/N question; confirm runs on y
+	confirm  func()
+}
+
+// load reads the local branches again, keeping the cursor on the same name.
+func (bp *branchPanel) load() {
+	name := ""
+	if bp.cursor < len(bp.branches) {
+		name = bp.branches[bp.cursor].Name
+	}
+	all, err := listBranches(bp.root, mergeBase(bp.root))
+	if err != nil {
+		bp.message = err.Error()
+		return
+	}
+	bp.branches = bp.branches[:0]
+	for _, b := range all {
+		if !b.Remote {
+			bp.branches = append(bp.branches, b)
+		}
+	}
+	bp.cursor = min(bp.cursor, max(len(bp.branches)-1, 0))
+	for i, b := range bp.branches {
+		if b.Name == name {
+			bp.cursor = i
+		}
+	}
+}
+
+// draw renders the panel over the whole screen.
+func (bp *branchPanel) draw() {
+	width, height := 80, 24
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		width, height = w, h
+	}
+	c := bp.c
+	listH := max(height-2, 1)
+	if bp.cursor < bp.top {
+		bp.top = bp.cursor
+	}
+	if bp.cursor >= bp.top+listH {
+		bp.top = bp.cursor - listH + 1
+	}
+
+	nameW, upW := 0, 0
+	for _, b := range bp.branches {
+		nameW = max(nameW, stringCells(b.Name))
+		upW = max(upW, stringCells(b.Upstream))
+	}
+	lines := []string{fmt.Sprintf("%s %sBranches of %s%s %s(%d local)%s", Icons.GIT, Bold+resolveColor(c.Header),
+		displayPath(bp.root), Reset, Dim, len(bp.branches), Reset)}
+	for i := bp.top; i < bp.top+listH && i < len(bp.branches); i++ {
+		b := bp.branches[i]
+		pointer := "  "
+		if i == bp.cursor {
+			pointer = Bold + resolveColor(c.Arrow) + "❯ " + Reset
+		}
+		mark, nameStyle := "  ", Bold+resolveColor(c.Branch)
+		if b.Current {
+			mark = Bold + resolveColor(c.Staged) + "● " + Reset
+		}
+		ab, abStyle := "", Bold+resolveColor(c.AheadBehind)
+		switch {
+		case b.Gone:
+			ab, abStyle = "gone", resolveColor(c.Deleted)
+		case b.Ahead > 0 || b.Behind > 0:
+			if b.Ahead > 0 {
+				ab += "↑" + strconv.Itoa(b.Ahead)
+			}
+			if b.Behind > 0 {
+				ab += "↓" + strconv.Itoa(b.Behind)
+			}
+		case b.Upstream != "":
+			ab, abStyle = "=", Dim
+		}
+		lines = append(lines, fmt.Sprintf("%s%s%s%s%s  %s%s%s  %s%-6s%s %s%4s%s  %s", pointer, mark,
+			nameStyle, padCells(b.Name, nameW, false), Reset, Dim, padCells(b.Upstream, upW, false), Reset, abStyle, ab, Reset,
+			Dim, shortAge(b.LastCommit), Reset, b.Subject))
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+
+	footer := Dim + "↑↓ move · enter checkout · n new · d delete · D force delete · r refresh · b back" + Reset
+	switch {
+	case bp.prompt.active:
+		footer = bp.prompt.line(c)
+	case bp.question != "":
+		footer = fmt.Sprintf("%s %s%s [y/N]%s", Icons.WARNING, Bold, bp.question, Reset)
+	case bp.note != "":
+		footer = resolveColor(c.UpToDate) + bp.note + Reset
+	case bp.message != "":
+		footer = resolveColor(c.AheadBehind) + bp.message + Reset
+	}
+	lines = append(lines[:height-1], footer)
+
+	var sb strings.Builder
+	sb.WriteString("\x1b[H")
+	for i, l := range lines {
+		if i > 0 {
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString(clipANSI(l, width) + "\x1b[K")
+	}
+	sb.WriteString("\x1b[J")
+	fmt.Print(sb.String())
+}
+
+// act runs a git command and reloads the branches, reporting done on
+// success.
+func (bp *branchPanel) act(done string, args ...string) {
+	if err := uiGit(bp.root, args...); err != nil {
+		bp.message = err.Error()
+		return
+	}
+	bp.note = Icons.SUCCESS + " " + done
+	bp.load()
+}
+
+// ask puts a y/N question in the footer; yes runs when it is answered y.
+func (bp *branchPanel) ask(question string, yes func()) {
+	bp.question, bp.confirm = question, yes
+}
+
+// run handles keys until the user goes back to the status.  The terminal
+// must already be in raw mode.
+func (bp *branchPanel) run() error {
+	bp.load()
+	for {
+		bp.draw()
+		key, err := readKey()
+		if err != nil {
+			return err
+		}
+		bp.message, bp.note = "", ""
+		if key == keyCtrlC {
+			return nil
+		}
+		if bp.question != "" {
+			yes := bp.confirm
+			bp.question, bp.confirm = "", nil
+			if key == "y" || key == "Y" {
+				yes()
+			}
+			continue
+		}
+		if bp.prompt.active {
+			if bp.prompt.key(key) {
+				if name := strings.TrimSpace(bp.prompt.text); name != "" {
+					bp.act("Created and switched to "+name, "switch", "-c", name)
+				}
+			}
+			continue
+		}
+		action := boundAction("branches", key)
+		if ev, ok := parseMouse(key); ok {
+			switch ev.Button {
+			case 64:
+				action = "up"
+			case 65:
+				action = "down"
+			case 0:
+				if i := bp.top + ev.Y - 2; !ev.Release && ev.Y >= 2 && i < len(bp.branches) {
+					bp.cursor = i
+				}
+			}
+		}
+
+		var b branchInfo
+		if bp.cursor < len(bp.branches) {
+			b = bp.branches[bp.cursor]
+		}
+		switch {
+		case action == "up":
+			bp.cursor = max(bp.cursor-1, 0)
+		case action == "down":
+			bp.cursor = max(min(bp.cursor+1, len(bp.branches)-1), 0)
+		case action == "pgup":
+			bp.cursor = max(bp.cursor-10, 0)
+		case action == "pgdn":
+			bp.cursor = max(min(bp.cursor+10, len(bp.branches)-1), 0)
+		case action == "top":
+			bp.cursor = 0
+		case action == "bottom":
+			bp.cursor = max(len(bp.branches)-1, 0)
+		case action == "checkout":
+			switch {
+			case b.Name == "":
+			case b.Current:
+				bp.message = "Already on " + b.Name
+			default:
+				bp.act("Switched to "+b.Name, "switch", b.Name)
+			}
+		case action == "create":
+			bp.prompt = uiPrompt{label: "New branch from HEAD", active: true}
+		case action == "delete", action == "force-delete":
+			switch {
+			case b.Name == "":
+			case b.Current:
+				bp.message = "Can't delete the branch you are on; check out another one first"
+			case action == "delete":
+				bp.ask("Delete branch "+b.Name+"?", func() {
+					bp.act("Deleted "+b.Name, "branch", "-d", b.Name)
+					if bp.message != "" {
+						bp.message += " (D deletes it anyway)"
+					}
+				})
+			default:
+				bp.ask("Delete branch "+b.Name+" even if it is not merged?", func() {
+					bp.act("Deleted "+b.Name, "branch", "-D", b.Name)
+				})
+			}
+		case action == "refresh":
+			bp.load()
+		case action == "back":
+			return nil
+		}
+	}
+}
+
+// branches opens the branches panel; the status is read again afterwards
+// since a checkout changes it.
+func (u *statusUI) branches() {
+	bp := &branchPanel{c: u.c, root: u.root}
+	if err := bp.run(); err != nil {
+		u.message = err.Error()
+	}
+	u.refresh()
+}
@@ -0,0 +1,389 @@
+// File: uicommit.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: commit message form for `gits ui`
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// conventionalTypes are the Conventional Commits types offered by the form;
+// the first entry means no type.
+var conventionalTypes = []struct{ name, desc string }{
+	{"", "no type prefix"},
+	{"feat", "a new feature"},
+	{"fix", "a bug fix"},
+	{"docs", "documentation only"},
+	{"style", "formatting, no code change"},
+	{"refactor", "neither fixes a bug nor adds a feature"},
+	{"perf", "a performance improvement"},
+	{"test", "adding or correcting tests"},
+	{"build", "build system or dependencies"},
+	{"ci", "CI configuration and scripts"},
+	{"chore", "other changes that don't touch src or tests"},
+	{"revert", "reverts a previous commit"},
+}
+
+// Subject length limits: git tools truncate past 72, 50 is the usual aim.
+const (
+	subjectSoftLimit = 50
+	subjectHardLimit = 72
+)
+
+// Fields of the commit form, in tab order.
+const (
+	fieldType = iota
+	fieldScope
+	fieldSubject
+	fieldBody
+	fieldCount
+)
+
+// commitForm is the commit message editor of `gits ui`.
+type commitForm struct {
+	c          ColorConfig
+	root       string
+	rs         *RepoStatus
+	typeIdx    int
+	breaking   bool
+	scope      string
+	subject    string
+	body       []string
+	field      int
+	summary    []string
+	message    string
+	confirming bool
+}
+
+// newCommitForm prepares the form and the staged summary shown next to it.
+func newCommitForm(root string, rs *RepoStatus, c ColorConfig) *commitForm {
+	f := &commitForm{c: c, root: root, rs: rs, field: fieldSubject, body: []string{""}}
+	for _, e := range rs.Entries {
+		if e.Staged() {
+			f.summary = append(f.summary, fmt.Sprintf("%s%s%s %s", Bold+resolveColor(c.Staged), e.Index, Reset, entryLabel(e)))
+		}
+	}
+	if numstat, err := gitOutput(root, "diff", "--cached", "--numstat"); err == nil {
+		if stat := strings.TrimRight(renderNumstat(numstat, c, ""), "\n"); stat != "" {
+			f.summary = append(append(f.summary, ""), strings.Split(stat, "\n")...)
+		}
+	}
+	return f
+}
+
+// header is the first line of the message: "type(scope)!: subject".
+func (f *commitForm) header() string {
+	t := conventionalTypes[f.typeIdx].name
+	if t == "" {
+		return f.subject
+	}
+	if f.scope != "" {
+		t += "(" + f.scope + ")"
+	}
+	if f.breaking {
+		t += "!"
+	}
+	return t + ": " + f.subject
+}
+
+// text is the whole commit message.
+func (f *commitForm) text() string {
+	msg := f.header()
+	if body := strings.TrimSpace(strings.Join(f.body, "\n")); body != "" {
+		msg += "\n\n" + body
+	}
+	return msg + "\n"
+}
+
+// countStyle colors a length against the subject limits.
+func (f *commitForm) countStyle(n int) string {
+	switch {
+	case n > subjectHardLimit:
+		return Bold + resolveColor(f.c.Deleted)
+	case n > subjectSoftLimit:
+		return resolveColor(f.c.AheadBehind)
+	}
+	return resolveColor(f.c.UpToDate)
+}
+
+// formLines renders the editor column.
+func (f *commitForm) formLines() []string {
+	c := f.c
+	label := func(field int, name string) string {
+		if f.field == field {
+			return Bold + resolveColor(c.Arrow) + "❯ " + fmt.Sprintf("%-8s", name) + Reset
+		}
+		return "  " + Dim + fmt.Sprintf("%-8s", name) + Reset
+	}
+	caret := func(field int) string {
+		if f.field == field && !f.confirming {
+			return resolveColor(c.Arrow) + "█" + Reset
+		}
+		return ""
+	}
+
+	var types []string
+	for i, t := range conventionalTypes {
+		name := t.name
+		if name == "" {
+			name = "none"
+		}
+		if i == f.typeIdx {
+			name = "\x1b[7m" + Bold + " " + name + " " + Reset
+		} else {
+			name = " " + Dim + name + Reset + " "
+		}
+		types = append(types, name)
+	}
+	breaking := Dim + "[ ] breaking (!)" + Reset
+	if f.breaking {
+		breaking = Bold + resolveColor(c.Deleted) + "[x] breaking (!)" + Reset
+	}
+	lines := []string{
+		label(fieldType, "type") + strings.Join(types, ""),
+		"          " + Dim + conventionalTypes[f.typeIdx].desc + Reset + "  " + breaking,
+		label(fieldScope, "scope") + f.scope + caret(fieldScope),
+	}
+
+	n := utf8.RuneCountInString(f.header())
+	lines = append(lines, label(fieldSubject, "subject")+f.subject+caret(fieldSubject),
+		fmt.Sprintf("          %s%d/%d%s %s%s%s", f.countStyle(n), n, subjectHardLimit, Reset, Dim, f.header(), Reset))
+
+	longest := 0
+	for _, l := range f.body {
+		longest = max(longest, utf8.RuneCountInString(l))
+	}
+	for i, l := range f.body {
+		prefix := "          "
+		if i == 0 {
+			prefix = label(fieldBody, "body")
+		}
+		if i == len(f.body)-1 {
+			l += caret(fieldBody)
+		}
+		lines = append(lines, prefix+l)
+	}
+	if longest > subjectHardLimit {
+		lines = append(lines, fmt.Sprintf("          %slongest body line %d/%d: wrap it%s",
+			resolveColor(c.AheadBehind), longest, subjectHardLimit, Reset))
+	}
+	return lines
+}
+
+// draw renders the form with the staged summary beside it, or below it on
+// narrow terminals.
+func (f *commitForm) draw() {
+	width, height := 80, 24
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		width, height = w, h
+	}
+	c := f.c
+	branch := f.rs.Branch
+	if f.rs.Detached {
+		branch = "(detached " + shortOid(f.rs.Oid) + ")"
+	}
+	lines := []string{fmt.Sprintf("%s %sCommit %d staged file(s) on%s %s%s%s", Icons.GIT, Bold+resolveColor(c.Header),
+		f.rs.Staged, Reset, Bold+resolveColor(c.Branch), branch, Reset), ""}
+	form := f.formLines()
+	summary := append([]string{Bold + resolveColor(c.Header) + "To be committed" + Reset}, f.summary...)
+	if left := f.rs.Modified + f.rs.Untracked + f.rs.Conflicts; left > 0 {
+		summary = append(summary, "", fmt.Sprintf("%sNot included: %d unstaged, %d untracked, %d conflicted%s",
+			resolveColor(c.AheadBehind), f.rs.Modified, f.rs.Untracked, f.rs.Conflicts, Reset))
+	}
+	if width >= 110 {
+		leftW := width * 3 / 5
+		for i := 0; i < max(len(form), len(summary)); i++ {
+			l, r := "", ""
+			if i < len(form) {
+				l = form[i]
+			}
+			if i < len(summary) {
+				r = summary[i]
+			}
+			lines = append(lines, padANSI(l, leftW)+Dim+"│ "+Reset+r)
+		}
+	} else {
+		lines = append(lines, form...)
+		lines = append(append(lines, Dim+strings.Repeat("─", width)+Reset), summary...)
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+
+	footer := Dim + "tab/↑↓ field · ←→ type · ! breaking · enter next line · ctrl-s commit · esc cancel" + Reset
+	switch {
+	case f.confirming:
+		footer = fmt.Sprintf("%s %sCommit as \"%s\"? [y/N]%s", Icons.WARNING, Bold, f.header(), Reset)
+	case f.message != "":
+		footer = resolveColor(c.AheadBehind) + f.message + Reset
+	}
+	lines = append(lines[:height-1], footer)
+
+	var sb strings.Builder
+	sb.WriteString("\x1b[H")
+	for i, l := range lines {
+		if i > 0 {
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString(clipANSI(l, width) + "\x1b[K")
+	}
+	sb.WriteString("\x1b[J")
+	fmt.Print(sb.String())
+}
+
+// focused returns the text field in focus, or nil for the type picker.
+func (f *commitForm) focused() *string {
+	switch f.field {
+	case fieldScope:
+		return &f.scope
+	case fieldSubject:
+		return &f.subject
+	case fieldBody:
+		return &f.body[len(f.body)-1]
+	}
+	return nil
+}
+
+// commit runs git commit with the message.  Hooks run as usual; their
+// output only matters when they fail.
+func (f *commitForm) commit() (string, error) {
+	cmd := gitCmd(f.root, "commit", "--quiet", "-F", "-")
+	cmd.Stdin = strings.NewReader(f.text())
+	if out, err := cmd.CombinedOutput(); err != nil {
+		msg := strings.TrimSpace(string(out))
+		if i := strings.LastIndex(msg, "\n"); i >= 0 {
+			msg = msg[i+1:]
+		}
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git commit: %s", msg)
+	}
+	return gitOutput(f.root, "log", "-1", "--format=%h %s")
+}
+
+// run edits the message until it is committed (returning the new commit's
+// "sha subject") or the user cancels (returning "").  The terminal must
+// already be in raw mode.
+func (f *commitForm) run() (string, error) {
+	for {
+		f.draw()
+		key, err := readKey()
+		if err != nil {
+			return "", err
+		}
+		f.message = ""
+		if f.confirming {
+			f.confirming = false
+			if key != "y" && key != "Y" {
+				continue
+			}
+			done, err := f.commit()
+			if err != nil {
+				f.message = err.Error()
+				continue
+			}
+			return done, nil
+		}
+		field := f.focused()
+		action := boundAction("commit", key)
+		switch action {
+		case "prev-type", "next-type", "breaking":
+			// Only the type field has a use for these; text fields take
+			// them as typed characters.
+			if field != nil {
+				action = ""
+			}
+		}
+		switch action {
+		case "cancel":
+			return "", nil
+		case "commit":
+			if strings.TrimSpace(f.subject) == "" {
+				f.message = "The subject is empty"
+				f.field = fieldSubject
+				continue
+			}
+			f.confirming = true
+		case "next-field":
+			f.field = (f.field + 1) % fieldCount
+		case "prev-field":
+			f.field = (f.field + fieldCount - 1) % fieldCount
+		case "prev-type":
+			f.typeIdx = (f.typeIdx + len(conventionalTypes) - 1) % len(conventionalTypes)
+		case "next-type":
+			f.typeIdx = (f.typeIdx + 1) % len(conventionalTypes)
+		case "breaking":
+			f.breaking = !f.breaking
+		case "newline":
+			if f.field == fieldBody {
+				f.body = append(f.body, "")
+			} else {
+				f.field++
+			}
+		case "erase":
+			switch {
+			case field == nil:
+			case *field != "":
+				_, size := utf8.DecodeLastRuneInString(*field)
+				*field = (*field)[:len(*field)-size]
+			case f.field == fieldBody && len(f.body) > 1:
+				f.body = f.body[:len(f.body)-1]
+			}
+		case "clear":
+			if field != nil {
+				*field = ""
+			}
+		default:
+			if field != nil {
+				f.insert(field, key)
+			}
+		}
+	}
+}
+
+// insert types key into field.  Pasted text may hold several lines, which
+// only the body keeps.
+func (f *commitForm) insert(field *string, key string) {
+	if key == keyLeft || key == keyRight {
+		return
+	}
+	key = strings.ReplaceAll(key, "\r\n", "\n")
+	key = strings.ReplaceAll(key, "\r", "\n")
+	if strings.IndexFunc(key, func(r rune) bool { return r != '\n' && !unicode.IsPrint(r) }) >= 0 {
+		return
+	}
+	parts := strings.Split(key, "\n")
+	if f.field != fieldBody {
+		*field += strings.Join(parts, " ")
+		return
+	}
+	*field += parts[0]
+	f.body = append(f.body, parts[1:]...)
+}
+
+// commitForm opens the commit form over the UI; after a commit the status
+// is read again.
+func (u *statusUI) commitForm() {
+	if u.rs.Staged == 0 {
+		u.message = "Nothing staged to commit: stage files with space, a or h first"
+		return
+	}
+	done, err := newCommitForm(u.root, u.rs, u.c).run()
+	switch {
+	case err != nil:
+		u.message = err.Error()
+	case done != "":
+		u.refresh()
+		u.note = Icons.SUCCESS + " Committed " + done
+	}
+}
@@ -0,0 +1,473 @@
+// File: uiconflict.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: conflict resolution view of `gits ui`
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Resolutions of a conflict hunk.
+const (
+	takeNone = iota
+	takeOurs
+	takeTheirs
+	takeBoth // ours, then theirs
+)
+
+// conflictHunk is one <<<<<<< … >>>>>>> region.  Lines keep their line
+// endings; markers are kept as written so an unresolved hunk is written
+// back unchanged.
+type conflictHunk struct {
+	ours, base, theirs []string
+	hasBase            bool // base is known, from a ||||||| section or fillBases
+	markers            []string
+	oursLabel          string
+	theirsLabel        string
+	take               int
+}
+
+// conflictSeg is a piece of a conflicted file: plain lines or a hunk.
+type conflictSeg struct {
+	lines []string
+	hunk  *conflictHunk
+}
+
+// markerLine reports whether line is the conflict marker made of seven c,
+// alone or followed by a label.
+func markerLine(line string, c byte) bool {
+	line = strings.TrimRight(line, "\r\n")
+	return len(line) >= 7 && strings.Count(line[:7], string(c)) == 7 && (len(line) == 7 || line[7] == ' ')
+}
+
+// parseConflicts splits data into plain segments and conflict hunks.  A
+// hunk that never ends is kept as plain text.
+func parseConflicts(data string) ([]conflictSeg, []*conflictHunk) {
+	var segs []conflictSeg
+	var hunks []*conflictHunk
+	var plain []string
+	var h *conflictHunk
+	part := 0 // 0 ours, 1 base, 2 theirs
+	for _, line := range strings.SplitAfter(data, "\n") {
+		if line == "" {
+			continue
+		}
+		switch {
+		case h == nil && markerLine(line, '<'):
+			h = &conflictHunk{markers: []string{line}, oursLabel: strings.TrimSpace(strings.TrimRight(line, "\r\n")[7:])}
+			part = 0
+		case h == nil:
+			plain = append(plain, line)
+		case part == 0 && markerLine(line, '|'):
+			h.markers = append(h.markers, line)
+			h.hasBase, part = true, 1
+		case part < 2 && markerLine(line, '='):
+			h.markers = append(h.markers, line)
+			part = 2
+		case part == 2 && markerLine(line, '>'):
+			h.markers = append(h.markers, line)
+			h.theirsLabel = strings.TrimSpace(strings.TrimRight(line, "\r\n")[7:])
+			if len(plain) > 0 {
+				segs = append(segs, conflictSeg{lines: plain})
+				plain = nil
+			}
+			segs = append(segs, conflictSeg{hunk: h})
+			hunks = append(hunks, h)
+			h = nil
+		case part == 0:
+			h.ours = append(h.ours, line)
+		case part == 1:
+			h.base = append(h.base, line)
+		default:
+			h.theirs = append(h.theirs, line)
+		}
+	}
+	if h != nil {
+		plain = append(plain, h.raw()...)
+	}
+	if len(plain) > 0 {
+		segs = append(segs, conflictSeg{lines: plain})
+	}
+	return segs, hunks
+}
+
+// raw is the hunk as it was written, markers included.
+func (h *conflictHunk) raw() []string {
+	out := append([]string{h.markers[0]}, h.ours...)
+	rest := h.markers[1:]
+	if len(rest) > 0 && markerLine(rest[0], '|') {
+		out = append(append(out, rest[0]), h.base...)
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		out = append(append(out, rest[0]), h.theirs...)
+		rest = rest[1:]
+	}
+	return append(out, rest...)
+}
+
+// result is what the hunk becomes once written.
+func (h *conflictHunk) result() []string {
+	switch h.take {
+	case takeOurs:
+		return h.ours
+	case takeTheirs:
+		return h.theirs
+	case takeBoth:
+		return append(slices.Clone(h.ours), h.theirs...)
+	}
+	return h.raw()
+}
+
+// fillBases recreates the merge with `git merge-file --diff3` from the
+// index stages to find the base of hunks written without one (the default
+// "merge" conflict style).  Hunks are matched by position and content, so
+// hand edits only cost their base.
+func fillBases(root, path string, hunks []*conflictHunk) {
+	if !slices.ContainsFunc(hunks, func(h *conflictHunk) bool { return !h.hasBase }) {
+		return
+	}
+	dir, err := os.MkdirTemp("", "gits-merge-")
+	if err != nil {
+		return
+	}
+	defer os.RemoveAll(dir)
+	var files []string
+	for _, stage := range []string{"2", "1", "3"} {
+		// An add/add conflict has no stage 1: the base is empty.
+		content, _ := gitRaw(root, "show", ":"+stage+":"+path)
+		f := filepath.Join(dir, stage)
+		if err := os.WriteFile(f, []byte(content), 0o600); err != nil {
+			return
+		}
+		files = append(files, f)
+	}
+	// merge-file exits with the number of conflicts; the output is what counts.
+	out, _ := gitCmd(root, append([]string{"merge-file", "-p", "--diff3"}, files...)...).Output()
+	_, merged := parseConflicts(string(out))
+	if len(merged) != len(hunks) {
+		return
+	}
+	for i, h := range hunks {
+		m := merged[i]
+		if !h.hasBase && slices.Equal(h.ours, m.ours) && slices.Equal(h.theirs, m.theirs) {
+			h.base, h.hasBase = m.base, true
+		}
+	}
+}
+
+// conflictView resolves the hunks of one unmerged file.
+type conflictView struct {
+	c       ColorConfig
+	root    string
+	path    string
+	segs    []conflictSeg
+	hunks   []*conflictHunk
+	current int
+	scroll  int
+	message string
+}
+
+// newConflictView reads path (root-relative) and its conflict hunks.
+func newConflictView(root, path string, c ColorConfig) (*conflictView, error) {
+	data, err := os.ReadFile(filepath.Join(root, path))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s was deleted on one side: stage it with space or remove it with git rm", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	segs, hunks := parseConflicts(string(data))
+	if len(hunks) == 0 {
+		return nil, fmt.Errorf("%s has no conflict markers left: space stages it as resolved", path)
+	}
+	fillBases(root, path, hunks)
+	return &conflictView{c: c, root: root, path: path, segs: segs, hunks: hunks}, nil
+}
+
+// resolved counts the hunks with a resolution.
+func (cv *conflictView) resolved() int {
+	n := 0
+	for _, h := range cv.hunks {
+		if h.take != takeNone {
+			n++
+		}
+	}
+	return n
+}
+
+// contextOf returns up to n plain lines before (or after) hunk i.
+func (cv *conflictView) contextOf(i, n int, after bool) []string {
+	seen := -1
+	for si, s := range cv.segs {
+		if s.hunk == nil {
+			continue
+		}
+		if seen++; seen != i {
+			continue
+		}
+		j := si - 1
+		if after {
+			j = si + 1
+		}
+		if j < 0 || j >= len(cv.segs) || cv.segs[j].hunk != nil {
+			return nil
+		}
+		lines := cv.segs[j].lines
+		if after {
+			return lines[:min(n, len(lines))]
+		}
+		return lines[max(len(lines)-n, 0):]
+	}
+	return nil
+}
+
+// draw shows the current hunk as ours, base and theirs side by side, or
+// stacked on narrow terminals, between a few lines of context.
+func (cv *conflictView) draw() {
+	width, height := 80, 24
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		width, height = w, h
+	}
+	c := cv.c
+	h := cv.hunks[cv.current]
+	clean := func(l string) string { return strings.TrimRight(l, "\r\n") }
+
+	type column struct {
+		title string
+		style string
+		lines []string
+		taken bool
+	}
+	ours := h.oursLabel
+	if ours == "" {
+		ours = "ours"
+	}
+	theirs := h.theirsLabel
+	if theirs == "" {
+		theirs = "theirs"
+	}
+	base := column{"base", Dim, h.base, false}
+	if !h.hasBase {
+		base.lines = []string{Dim + "(not available)" + Reset}
+	}
+	cols := []column{
+		{"ours · " + ours, Bold + resolveColor(c.Branch), h.ours, h.take == takeOurs || h.take == takeBoth},
+		base,
+		{"theirs · " + theirs, Bold + resolveColor(c.RemoteURL), h.theirs, h.take == takeTheirs || h.take == takeBoth},
+	}
+	render := func(col column, l string) string {
+		switch {
+		case col.taken:
+			return Bold + resolveColor(c.Added) + clean(l) + Reset
+		case h.take != takeNone:
+			return Dim + clean(l) + Reset
+		}
+		return clean(l)
+	}
+	heading := func(col column) string {
+		if col.taken {
+			return resolveColor(c.Added) + "✓ " + Reset + col.style + col.title + Reset
+		}
+		return "  " + col.style + col.title + Reset
+	}
+
+	var body []string
+	for _, l := range cv.contextOf(cv.current, 3, false) {
+		body = append(body, Dim+"  "+clean(l)+Reset)
+	}
+	if width >= 90 {
+		colW := (width - 2) / 3
+		row := ""
+		for i, col := range cols {
+			if i > 0 {
+				row += Dim + "│" + Reset
+			}
+			row += padANSI(heading(col), colW-1)
+		}
+		body = append(body, row)
+		n := max(len(h.ours), len(base.lines), len(h.theirs))
+		for li := 0; li < n; li++ {
+			row := ""
+			for i, col := range cols {
+				l := ""
+				if li < len(col.lines) {
+					l = render(col, col.lines[li])
+				}
+				if i > 0 {
+					row += Dim + "│" + Reset
+				}
+				row += padANSI("  "+l, colW-1)
+			}
+			body = append(body, row)
+		}
+	} else {
+		for _, col := range cols {
+			body = append(body, heading(col))
+			for _, l := range col.lines {
+				body = append(body, "  "+render(col, l))
+			}
+		}
+	}
+	for _, l := range cv.contextOf(cv.current, 3, true) {
+		body = append(body, Dim+"  "+clean(l)+Reset)
+	}
+
+	viewH := max(height-2, 1)
+	cv.scroll = max(min(cv.scroll, len(body)-viewH), 0)
+	state := fmt.Sprintf("%d of %d resolved", cv.resolved(), len(cv.hunks))
+	lines := []string{fmt.Sprintf("%s %sResolve %s%s · hunk %d/%d · %s%s%s", Icons.GIT, Bold+resolveColor(c.Header), cv.path, Reset,
+		cv.current+1, len(cv.hunks), resolveColor(c.AheadBehind), state, Reset)}
+	lines = append(lines, body[cv.scroll:min(cv.scroll+viewH, len(body))]...)
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	footer := Dim + "n/p hunk · o ours · t theirs · b both · u undo · J/K scroll · enter write & stage · q back" + Reset
+	if cv.message != "" {
+		footer = resolveColor(c.AheadBehind) + cv.message + Reset
+	}
+	lines = append(lines[:height-1], footer)
+
+	var sb strings.Builder
+	sb.WriteString("\x1b[H")
+	for i, l := range lines {
+		if i > 0 {
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString(clipANSI(l, width) + "\x1b[K")
+	}
+	sb.WriteString("\x1b[J")
+	fmt.Print(sb.String())
+}
+
+// write saves the file with the chosen sides.  Once every hunk is resolved
+// the file is staged, which marks the conflict resolved; otherwise the
+// unresolved hunks keep their markers.
+func (cv *conflictView) write() (staged bool, err error) {
+	var sb strings.Builder
+	for _, s := range cv.segs {
+		lines := s.lines
+		if s.hunk != nil {
+			lines = s.hunk.result()
+		}
+		for _, l := range lines {
+			sb.WriteString(l)
+		}
+	}
+	full := filepath.Join(cv.root, cv.path)
+	info, err := os.Stat(full)
+	if err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(full, []byte(sb.String()), info.Mode().Perm()); err != nil {
+		return false, err
+	}
+	if cv.resolved() < len(cv.hunks) {
+		return false, nil
+	}
+	return true, stagePaths(cv.root, []string{cv.path})
+}
+
+// run handles keys until the file is written (done) or the user goes back
+// without writing.  The terminal must already be in raw mode.
+func (cv *conflictView) run() (done string, err error) {
+	for {
+		cv.draw()
+		key, err := readKey()
+		if err != nil {
+			return "", err
+		}
+		cv.message = ""
+		if key == keyCtrlC {
+			return "", nil
+		}
+		action := boundAction("conflicts", key)
+		if ev, ok := parseMouse(key); ok {
+			switch ev.Button {
+			case 64:
+				action = "scroll-up"
+			case 65:
+				action = "scroll-down"
+			}
+		}
+		h := cv.hunks[cv.current]
+		advance := func(take int) {
+			h.take = take
+			if cv.current < len(cv.hunks)-1 {
+				cv.current, cv.scroll = cv.current+1, 0
+			}
+		}
+		switch action {
+		case "next-hunk":
+			if cv.current < len(cv.hunks)-1 {
+				cv.current, cv.scroll = cv.current+1, 0
+			}
+		case "prev-hunk":
+			if cv.current > 0 {
+				cv.current, cv.scroll = cv.current-1, 0
+			}
+		case "ours":
+			advance(takeOurs)
+		case "theirs":
+			advance(takeTheirs)
+		case "both":
+			advance(takeBoth)
+		case "undo":
+			h.take = takeNone
+		case "scroll-down":
+			cv.scroll += 3
+		case "scroll-up":
+			cv.scroll = max(cv.scroll-3, 0)
+		case "write":
+			if cv.resolved() == 0 {
+				cv.message = "Nothing resolved yet: o takes ours, t theirs, b both"
+				continue
+			}
+			staged, err := cv.write()
+			switch {
+			case err != nil:
+				cv.message = err.Error()
+				continue
+			case staged:
+				return fmt.Sprintf("Resolved and staged %s", cv.path), nil
+			}
+			return fmt.Sprintf("Wrote %s; %d hunk(s) still have markers", cv.path, len(cv.hunks)-cv.resolved()), nil
+		case "back":
+			return "", nil
+		}
+	}
+}
+
+// resolve opens the conflict view for the selected unmerged file.
+func (u *statusUI) resolve() {
+	r, ok := u.selected()
+	if !ok {
+		return
+	}
+	if r.entry.Kind != "unmerged" {
+		u.message = "Only unmerged files have conflicts to resolve"
+		return
+	}
+	cv, err := newConflictView(u.root, r.entry.Path, u.c)
+	if err != nil {
+		u.message = err.Error()
+		return
+	}
+	done, err := cv.run()
+	switch {
+	case err != nil:
+		u.message = err.Error()
+	case done != "":
+		u.refresh()
+		u.note = Icons.SUCCESS + " " + done
+	}
+}
@@ -0,0 +1,82 @@
+// File: uimouse.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: mouse support for `gits ui`
+// License: MIT
+
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Terminal modes for mouse reporting: clicks (1000), drags (1002), in SGR
+// encoding (1006) so columns past 223 work.
+const (
+	mouseOn  = "\x1b[?1000h\x1b[?1002h\x1b[?1006h"
+	mouseOff = "\x1b[?1006l\x1b[?1002l\x1b[?1000l"
+)
+
+// mouseEvent is one decoded SGR mouse report.  X and Y are 1-based.
+type mouseEvent struct {
+	Button  int // 0 left, 1 middle, 2 right, 64 wheel up, 65 wheel down
+	X, Y    int
+	Motion  bool // moved with the button held
+	Release bool
+}
+
+// parseMouse decodes "\x1b[<b;x;yM" (press) or "...m" (release).  When
+// reports arrive back to back only the first is used.
+func parseMouse(key string) (mouseEvent, bool) {
+	rest, ok := strings.CutPrefix(key, "\x1b[<")
+	if !ok {
+		return mouseEvent{}, false
+	}
+	end := strings.IndexAny(rest, "Mm")
+	if end < 0 {
+		return mouseEvent{}, false
+	}
+	f := strings.Split(rest[:end], ";")
+	if len(f) != 3 {
+		return mouseEvent{}, false
+	}
+	b, err1 := strconv.Atoi(f[0])
+	x, err2 := strconv.Atoi(f[1])
+	y, err3 := strconv.Atoi(f[2])
+	if err1 != nil || err2 != nil || err3 != nil {
+		return mouseEvent{}, false
+	}
+	return mouseEvent{Button: b &^ 32, X: x, Y: y, Motion: b&32 != 0, Release: rest[end] == 'm'}, true
+}
+
+// mouse handles a mouse event: the wheel scrolls whatever is under the
+// pointer, a click selects a row, and dragging the pane's title line
+// resizes the list and the pane.
+func (u *statusUI) mouse(ev mouseEvent) {
+	// Screen lines (1-based): header, list, pane title, pane, footer.
+	listTop, title := 2, u.listH+2
+	inPane := u.paneFocus || ev.Y > title
+	switch {
+	case ev.Button == 64 && inPane:
+		u.scroll = max(u.scroll-3, 0)
+	case ev.Button == 65 && inPane:
+		u.scroll += 3
+	case ev.Button == 64:
+		u.move(-3)
+	case ev.Button == 65:
+		u.move(3)
+	case ev.Button != 0 || u.paneFocus:
+	case ev.Release:
+		u.dragging = false
+	case ev.Motion && u.dragging:
+		// Leave at least one line to each side.
+		u.split = min(max(ev.Y-listTop, 1), u.height-5)
+	case ev.Y == title:
+		u.dragging = true
+	case ev.Y >= listTop && ev.Y < title:
+		if i := u.top + ev.Y - listTop; i < len(u.rows) && u.rows[i].heading == "" {
+			u.cursor = i
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// File: uistage.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: staging and unstaging from `gits ui`
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// unstagePaths takes root-relative paths out of the index.  Before the first
+// commit there is no HEAD to restore from, so the paths are removed from the
+// index instead, which is what unstaging means there.
+func unstagePaths(root string, unborn bool, paths []string) error {
+	if !unborn {
+		return restorePaths(root, true, paths)
+	}
+	cmd := gitCmd(root, "rm", "--cached", "--quiet", "-r", "--pathspec-from-file=-", "--pathspec-file-nul")
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\x00"))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git rm --cached: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// stagedEntry is e after `git add`: the worktree side moves into the index.
+func stagedEntry(e FileEntry) FileEntry {
+	switch {
+	case e.Kind == "untracked":
+		return FileEntry{Path: e.Path, Index: "A", Worktree: ".", Kind: "changed"}
+	case e.Kind == "unmerged":
+		e.Kind, e.Index = "changed", "M"
+	case e.Worktree == "D":
+		e.Index = "D"
+	case e.Index == ".":
+		e.Index = e.Worktree
+	}
+	e.Worktree = "."
+	return e
+}
+
+// unstagedEntries is e after unstaging: the index side moves back to the
+// worktree.  A staged rename splits into a deleted and an untracked file.
+func unstagedEntries(e FileEntry) []FileEntry {
+	switch {
+	case e.Kind == "renamed":
+		return []FileEntry{
+			{Path: e.OrigPath, Index: ".", Worktree: "D", Kind: "changed"},
+			{Path: e.Path, Index: "?", Worktree: "?", Kind: "untracked"},
+		}
+	case e.Index == "A":
+		if e.Worktree == "D" {
+			return nil
+		}
+		return []FileEntry{{Path: e.Path, Index: "?", Worktree: "?", Kind: "untracked"}}
+	case e.Worktree == ".":
+		e.Worktree = e.Index
+	}
+	e.Index = "."
+	return []FileEntry{e}
+}
+
+// setEntries replaces the entries of the status, recounting them, without
+// asking git again.
+func (u *statusUI) setEntries(entries []FileEntry) {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	rs := u.rs
+	rs.Entries = nil
+	rs.Staged, rs.Modified, rs.Untracked, rs.Conflicts = 0, 0, 0, 0
+	for _, e := range entries {
+		rs.addEntry(e)
+	}
+	u.details = map[string][]string{}
+	u.buildRows()
+}
+
+// stage adds rows' files to the index and updates the view in place.
+// Untracked directories expand into files once added, so those need a real
+// refresh.
+func (u *statusUI) stage(rows []uiRow) {
+	var paths []string
+	want := map[string]bool{}
+	refresh := false
+	for _, r := range rows {
+		if r.heading != "" || r.staged || want[r.entry.Path] {
+			continue
+		}
+		paths = append(paths, r.entry.Path)
+		want[r.entry.Path] = true
+		refresh = refresh || strings.HasSuffix(r.entry.Path, "/")
+	}
+	if len(paths) == 0 {
+		return
+	}
+	if err := stagePaths(u.root, paths); err != nil {
+		u.message = err.Error()
+		return
+	}
+	if refresh {
+		u.refresh()
+		return
+	}
+	entries := make([]FileEntry, 0, len(u.rs.Entries))
+	for _, e := range u.rs.Entries {
+		if want[e.Path] {
+			e = stagedEntry(e)
+		}
+		entries = append(entries, e)
+	}
+	u.setEntries(entries)
+}
+
+// unstage takes rows' files out of the index and updates the view in place.
+func (u *statusUI) unstage(rows []uiRow) {
+	var paths []string
+	want := map[string]bool{}
+	for _, r := range rows {
+		if r.heading != "" || !r.staged || want[r.entry.Path] {
+			continue
+		}
+		// A rename is only undone when both of its sides are unstaged.
+		paths = append(paths, r.entry.Path)
+		if r.entry.OrigPath != "" {
+			paths = append(paths, r.entry.OrigPath)
+		}
+		want[r.entry.Path] = true
+	}
+	if len(paths) == 0 {
+		return
+	}
+	if err := unstagePaths(u.root, u.rs.Oid == "", paths); err != nil {
+		u.message = err.Error()
+		return
+	}
+	entries := make([]FileEntry, 0, len(u.rs.Entries))
+	for _, e := range u.rs.Entries {
+		if want[e.Path] {
+			entries = append(entries, unstagedEntries(e)...)
+		} else {
+			entries = append(entries, e)
+		}
+	}
+	u.setEntries(entries)
+}
+
+// hunks opens the line picker for the selected file.  Afterwards the file
+// is likely on both sides, so the status is read again.
+func (u *statusUI) hunks() {
+	r, ok := u.selected()
+	if !ok {
+		return
+	}
+	if r.entry.Kind != "changed" && r.entry.Kind != "renamed" {
+		u.message = "Only changes to tracked files can be staged line by line; space stages the whole file"
+		return
+	}
+	hp, err := newHunkPicker(u.root, r.entry.Path, r.staged, u.c)
+	if err != nil {
+		u.message = err.Error()
+		return
+	}
+	if applied, _, _ := hp.run(); applied {
+		u.refresh()
+	}
+}
+
+// toggle stages the selected row, or unstages it when it is staged.
+func (u *statusUI) toggle() {
+	r, ok := u.selected()
+	if !ok {
+		return
+	}
+	if r.staged {
+		u.unstage([]uiRow{r})
+	} else {
+		u.stage([]uiRow{r})
+	}
+}
@@ -0,0 +1,289 @@
+// File: uistash.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: stash panel of `gits ui`
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// stashPanel lists the stashes with a diff preview of the selected one.
+type stashPanel struct {
+	c        ColorConfig
+	root     string
+	stashes  []stashEntry
+	cursor   int
+	top      int
+	scroll   int
+	listH    int
+	paneH    int
+	previews map[string][]string
+	message  string
+	note     string
+	prompt   uiPrompt
+	question string // a y/N question; confirm runs on y
+	confirm  func()
+}
+
+// load reads the stashes again.  Refs shift when one is dropped, so the
+// previews are thrown away too.
+func (sp *stashPanel) load() {
+	stashes, err := listStashes(sp.root)
+	if err != nil {
+		sp.message = err.Error()
+		return
+	}
+	sp.stashes, sp.previews = stashes, map[string][]string{}
+	sp.cursor = max(min(sp.cursor, len(sp.stashes)-1), 0)
+}
+
+// preview renders the diffstat and the diff of ref, followed by the files
+// it holds that were untracked (stash -u keeps them in a third parent).
+func (sp *stashPanel) preview(ref string) []string {
+	var sb strings.Builder
+	sb.WriteString(stashDiffstat(sp.root, ref, sp.c))
+	if out, err := gitRaw(sp.root, "stash", "show", "-p", "--no-color", "--no-ext-diff", ref); err == nil {
+		sb.WriteString(renderDiff(out, sp.c))
+	}
+	if _, err := gitOutput(sp.root, "rev-parse", "--quiet", "--verify", ref+"^3"); err == nil {
+		if out, err := gitRaw(sp.root, "show", "--format=", "--no-color", "--no-ext-diff", ref+"^3"); err == nil {
+			fmt.Fprintf(&sb, "%sUntracked files%s\n", Bold+resolveColor(sp.c.Header), Reset)
+			sb.WriteString(renderDiff(out, sp.c))
+		}
+	}
+	lines := strings.Split(strings.TrimRight(sb.String(), "\n"), "\n")
+	if len(lines) > uiDiffMaxLines {
+		lines = append(lines[:uiDiffMaxLines], Dim+"… preview cut"+Reset)
+	}
+	return lines
+}
+
+// draw renders the list over the preview pane.
+func (sp *stashPanel) draw() {
+	width, height := 80, 24
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		width, height = w, h
+	}
+	c := sp.c
+	// Header, pane title and footer take a line each.  The list only needs
+	// as many lines as there are stashes, up to a third of the screen.
+	sp.listH = max(min(len(sp.stashes), (height-3)/3), 1)
+	sp.paneH = max(height-3-sp.listH, 1)
+	if sp.cursor < sp.top {
+		sp.top = sp.cursor
+	}
+	if sp.cursor >= sp.top+sp.listH {
+		sp.top = sp.cursor - sp.listH + 1
+	}
+
+	lines := []string{fmt.Sprintf("%s %sStashes of %s%s %s(%d)%s", Icons.GIT, Bold+resolveColor(c.Header),
+		displayPath(sp.root), Reset, Dim, len(sp.stashes), Reset)}
+	for i := sp.top; i < sp.top+sp.listH; i++ {
+		switch {
+		case len(sp.stashes) == 0 && i == 0:
+			lines = append(lines, fmt.Sprintf("%s %sNo stash entries%s", Icons.SUCCESS, resolveColor(c.UpToDate), Reset))
+		case i >= len(sp.stashes):
+			lines = append(lines, "")
+		default:
+			s := sp.stashes[i]
+			pointer, subject := "  ", s.Subject
+			if i == sp.cursor {
+				pointer, subject = Bold+resolveColor(c.Arrow)+"❯ "+Reset, Bold+subject+Reset
+			}
+			lines = append(lines, fmt.Sprintf("%s%s%-10s%s %s%4s%s  %s", pointer, Bold+resolveColor(c.AheadBehind), s.Ref, Reset,
+				Dim, shortAge(s.Time), Reset, subject))
+		}
+	}
+
+	title := " preview "
+	var preview []string
+	if sp.cursor < len(sp.stashes) {
+		ref := sp.stashes[sp.cursor].Ref
+		if _, cached := sp.previews[ref]; !cached {
+			sp.previews[ref] = sp.preview(ref)
+		}
+		preview = sp.previews[ref]
+		sp.scroll = max(min(sp.scroll, len(preview)-sp.paneH), 0)
+		title = fmt.Sprintf(" %s ", ref)
+		if len(preview) > sp.paneH {
+			title = fmt.Sprintf(" %s · %d–%d of %d ", ref, sp.scroll+1, min(sp.scroll+sp.paneH, len(preview)), len(preview))
+		}
+	}
+	lines = append(lines, Dim+"──"+title+strings.Repeat("─", max(width-stringCells(title)-2, 0))+Reset)
+	for i := sp.scroll; i < sp.scroll+sp.paneH; i++ {
+		if i < len(preview) {
+			lines = append(lines, " "+preview[i])
+		} else {
+			lines = append(lines, "")
+		}
+	}
+
+	footer := Dim + "↑↓ move · J/K scroll · a apply · p pop · d drop · n new stash · r refresh · s back" + Reset
+	switch {
+	case sp.prompt.active:
+		footer = sp.prompt.line(c)
+	case sp.question != "":
+		footer = fmt.Sprintf("%s %s%s [y/N]%s", Icons.WARNING, Bold, sp.question, Reset)
+	case sp.note != "":
+		footer = resolveColor(c.UpToDate) + sp.note + Reset
+	case sp.message != "":
+		footer = resolveColor(c.AheadBehind) + sp.message + Reset
+	}
+	lines = append(lines[:min(len(lines), height-1)], footer)
+
+	var sb strings.Builder
+	sb.WriteString("\x1b[H")
+	for i, l := range lines {
+		if i > 0 {
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString(clipANSI(l, width) + "\x1b[K")
+	}
+	sb.WriteString("\x1b[J")
+	fmt.Print(sb.String())
+}
+
+// act runs `git stash ...` and reloads the list, reporting done on success.
+func (sp *stashPanel) act(done string, args ...string) {
+	if err := uiGit(sp.root, append([]string{"stash"}, args...)...); err != nil {
+		sp.message = err.Error()
+		// A pop that conflicts applies the changes but keeps the stash.
+		sp.load()
+		return
+	}
+	sp.note = Icons.SUCCESS + " " + done
+	sp.load()
+}
+
+// push stashes the worktree changes under message.
+func (sp *stashPanel) push(message string) {
+	before := len(sp.stashes)
+	args := []string{"push"}
+	if message != "" {
+		args = append(args, "-m", message)
+	}
+	sp.act("Stashed the local changes", args...)
+	if sp.message == "" && len(sp.stashes) == before {
+		sp.note, sp.message = "", "No local changes to save"
+	}
+	sp.cursor, sp.scroll = 0, 0
+}
+
+// run handles keys until the user goes back to the status.  The terminal
+// must already be in raw mode.
+func (sp *stashPanel) run() error {
+	sp.load()
+	for {
+		sp.draw()
+		key, err := readKey()
+		if err != nil {
+			return err
+		}
+		sp.message, sp.note = "", ""
+		if key == keyCtrlC {
+			return nil
+		}
+		if sp.question != "" {
+			yes := sp.confirm
+			sp.question, sp.confirm = "", nil
+			if key == "y" || key == "Y" {
+				yes()
+			}
+			continue
+		}
+		if sp.prompt.active {
+			if sp.prompt.key(key) {
+				sp.push(strings.TrimSpace(sp.prompt.text))
+			}
+			continue
+		}
+
+		action := boundAction("stashes", key)
+		if ev, ok := parseMouse(key); ok {
+			inPane := ev.Y > sp.listH+2
+			switch {
+			case ev.Button == 64 && inPane:
+				action = "scroll-up"
+			case ev.Button == 65 && inPane:
+				action = "scroll-down"
+			case ev.Button == 64:
+				action = "up"
+			case ev.Button == 65:
+				action = "down"
+			case ev.Button == 0 && !ev.Release && ev.Y >= 2 && !inPane:
+				if i := sp.top + ev.Y - 2; i < len(sp.stashes) {
+					sp.cursor = i
+				}
+			}
+		}
+
+		old := sp.cursor
+		ref := ""
+		if sp.cursor < len(sp.stashes) {
+			ref = sp.stashes[sp.cursor].Ref
+		}
+		switch action {
+		case "up":
+			sp.cursor = max(sp.cursor-1, 0)
+		case "down":
+			sp.cursor = max(min(sp.cursor+1, len(sp.stashes)-1), 0)
+		case "top":
+			sp.cursor = 0
+		case "bottom":
+			sp.cursor = max(len(sp.stashes)-1, 0)
+		case "scroll-down":
+			sp.scroll += 3
+		case "scroll-up":
+			sp.scroll = max(sp.scroll-3, 0)
+		case "pgdn":
+			sp.scroll += sp.paneH
+		case "pgup":
+			sp.scroll = max(sp.scroll-sp.paneH, 0)
+		case "apply":
+			if ref != "" {
+				sp.act("Applied "+ref, "apply", ref)
+			}
+		case "pop":
+			if ref != "" {
+				sp.act("Popped "+ref, "pop", ref)
+			}
+		case "drop":
+			if ref != "" {
+				sp.ask("Drop "+ref+"? Its changes will be lost.", func() {
+					sp.act("Dropped "+ref, "drop", ref)
+				})
+			}
+		case "new":
+			sp.prompt = uiPrompt{label: "Stash message (optional)", active: true}
+		case "refresh":
+			sp.load()
+		case "back":
+			return nil
+		}
+		if sp.cursor != old {
+			sp.scroll = 0
+		}
+	}
+}
+
+// ask puts a y/N question in the footer; yes runs when it is answered y.
+func (sp *stashPanel) ask(question string, yes func()) {
+	sp.question, sp.confirm = question, yes
+}
+
+// stashes opens the stash panel; the status is read again afterwards since
+// applying or pushing a stash changes it.
+func (u *statusUI) stashes() {
+	sp := &stashPanel{c: u.c, root: u.root}
+	if err := sp.run(); err != nil {
+		u.message = err.Error()
+	}
+	u.refresh()
+}
@@ -0,0 +1,229 @@
+// File: undo.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: guided undo operations with safety snapshots (`gits undo`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// undoOp is one prepared undo: what it does and the git command it runs.
+type undoOp struct {
+	Title   string
+	Command []string
+}
+
+// commitPushed reports whether sha is reachable from any remote-tracking
+// branch, i.e. rewriting it would diverge from what others may have.
+func commitPushed(dir, sha string) bool {
+	out, err := gitOutput(dir, "branch", "-r", "--contains", sha)
+	return err == nil && out != ""
+}
+
+// safetySnapshot records the current index and worktree changes as a stash
+// entry without touching the worktree (`git stash create` + `store`).  It
+// returns the stash commit, or "" when there was nothing to save.
+func safetySnapshot(dir, reason string) (string, error) {
+	sha, err := gitOutput(dir, "stash", "create")
+	if err != nil || sha == "" {
+		return "", err
+	}
+	head, _ := gitOutput(dir, "rev-parse", "--short", "HEAD")
+	msg := fmt.Sprintf("gits undo safety: before %s (HEAD %s, %s)", reason, head, time.Now().Format("2006-01-02 15:04"))
+	if _, err := gitOutput(dir, "stash", "store", "-m", msg, sha); err != nil {
+		return "", err
+	}
+	return sha, nil
+}
+
+// undoCommitOp prepares "undo last commit, keep its changes staged".
+func undoCommitOp(dir string, c ColorConfig) (undoOp, error) {
+	if _, err := gitOutput(dir, "rev-parse", "--verify", "--quiet", "HEAD~1"); err != nil {
+		return undoOp{}, fmt.Errorf("HEAD has no parent commit to go back to")
+	}
+	out, _ := gitOutput(dir, "log", "-1", "--format=%h%x00%s%x00%H")
+	f := strings.SplitN(out, "\x00", 3)
+	if len(f) == 3 {
+		fmt.Printf("%s Last commit %s%s%s %s\n", Icons.INFO, Bold+resolveColor(c.AheadBehind), f[0], Reset, f[1])
+		if numstat, err := gitOutput(dir, "show", "--numstat", "--format=", "HEAD"); err == nil {
+			fmt.Print(renderNumstat(numstat, c, "    "))
+		}
+		if commitPushed(dir, f[2]) {
+			fmt.Printf("%s %sThis commit is already on a remote; undoing it means a force push later%s\n",
+				Icons.WARNING, resolveColor(c.AheadBehind), Reset)
+		}
+	}
+	return undoOp{"Undo the last commit, keeping its changes staged", []string{"reset", "--soft", "HEAD~1"}}, nil
+}
+
+// undoDiscardOp prepares discarding every change to one tracked file.
+func undoDiscardOp(dir, file string, c ColorConfig) (undoOp, error) {
+	if file == "" {
+		rs := CollectStatus(dir)
+		var entries []FileEntry
+		var items []pickItem
+		for _, e := range rs.Entries {
+			if e.Staged() || e.Unstaged() {
+				entries = append(entries, e)
+				items = append(items, pickItem{Tag: entryCode(e), TagStyle: entryStyle(e, c), Label: entryLabel(e)})
+			}
+		}
+		if len(items) == 0 {
+			return undoOp{}, fmt.Errorf("no changed tracked files")
+		}
+		i, ok, err := pickOne("Discard all changes to which file?", items, c)
+		if err != nil || !ok {
+			return undoOp{}, err
+		}
+		file = entries[i].Path
+	} else {
+		prefix, _ := gitOutput(".", "rev-parse", "--show-prefix")
+		file = prefix + file
+	}
+	patch, err := gitRaw(dir, "diff", "HEAD", "--no-color", "--no-ext-diff", "--", file)
+	if err != nil {
+		return undoOp{}, err
+	}
+	if patch == "" {
+		return undoOp{}, fmt.Errorf("%s has no changes", file)
+	}
+	fmt.Print(renderDiff(patch, c))
+	return undoOp{"Discard all staged and unstaged changes to " + file,
+		[]string{"restore", "--source=HEAD", "--staged", "--worktree", "--", file}}, nil
+}
+
+// undoReflogOp prepares moving the branch back to an earlier reflog state.
+func undoReflogOp(dir string, c ColorConfig) (undoOp, error) {
+	out, err := gitOutput(dir, "reflog", "-n", "40", "--format=%h%x00%gs%x00%ct")
+	if err != nil || out == "" {
+		return undoOp{}, fmt.Errorf("the reflog is empty")
+	}
+	var shas []string
+	var items []pickItem
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.SplitN(line, "\x00", 3)
+		if len(f) < 3 {
+			continue
+		}
+		age := ""
+		if ts, err := strconv.ParseInt(f[2], 10, 64); err == nil {
+			age = shortAge(time.Unix(ts, 0))
+		}
+		shas = append(shas, f[0])
+		items = append(items, pickItem{
+			Tag:      fmt.Sprintf("%s %4s", f[0], age),
+			TagStyle: resolveColor(c.AheadBehind),
+			Label:    f[1],
+		})
+	}
+	i, ok, err := pickOne("Move HEAD back to which state?", items, c)
+	if err != nil || !ok {
+		return undoOp{}, err
+	}
+	return undoOp{"Reset the current branch, index and worktree to " + shas[i] + " (" + items[i].Label + ")",
+		[]string{"reset", "--hard", shas[i]}}, nil
+}
+
+// runUndo implements `gits undo [commit|unstage|discard [FILE]|reflog] [--yes]`.
+func runUndo(status *Status, args []string) {
+	c := status.cfg.Colors
+	yes := false
+	var rest []string
+	for _, a := range args {
+		switch a {
+		case "-y", "--yes":
+			yes = true
+		default:
+			rest = append(rest, a)
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	actions := []string{"commit", "unstage", "discard", "reflog"}
+	action := ""
+	if len(rest) > 0 {
+		action = rest[0]
+	} else {
+		items := []pickItem{
+			{Tag: "commit ", Label: "Undo the last commit (keep its changes staged)"},
+			{Tag: "unstage", Label: "Unstage everything (keep the changes in the worktree)"},
+			{Tag: "discard", Label: "Discard all changes to one file"},
+			{Tag: "reflog ", Label: "Go back to an earlier state from the reflog"},
+		}
+		for i := range items {
+			items[i].TagStyle = Bold + resolveColor(c.Branch)
+		}
+		i, ok, err := pickOne("What do you want to undo?", items, c)
+		if err != nil {
+			fail(err)
+		}
+		if !ok {
+			return
+		}
+		action = actions[i]
+	}
+
+	var op undoOp
+	switch action {
+	case "commit":
+		op, err = undoCommitOp(root, c)
+	case "unstage":
+		op = undoOp{"Unstage everything, keeping the changes in the worktree", []string{"reset", "--quiet"}}
+	case "discard":
+		file := ""
+		if len(rest) > 1 {
+			file = rest[1]
+		}
+		op, err = undoDiscardOp(root, file, c)
+	case "reflog":
+		op, err = undoReflogOp(root, c)
+	default:
+		fail(fmt.Errorf("unknown undo action %q (%s)", action, strings.Join(actions, ", ")))
+	}
+	if err != nil {
+		fail(err)
+	}
+	if op.Command == nil {
+		return // picker cancelled
+	}
+
+	fmt.Printf("\n%s %s%s%s\n", Icons.INFO, Bold, op.Title, Reset)
+	fmt.Printf("   %swill run:%s %sgit %s%s\n", Dim, Reset, resolveColor(c.Hint), strings.Join(op.Command, " "), Reset)
+	fmt.Printf("   %suncommitted changes are saved to a safety stash first%s\n", Dim, Reset)
+	if !yes {
+		ok, err := confirm("Go ahead?")
+		if err != nil {
+			fail(fmt.Errorf("%v (use --yes)", err))
+		}
+		if !ok {
+			return
+		}
+	}
+
+	snapshot, err := safetySnapshot(root, action)
+	if err != nil {
+		fail(fmt.Errorf("safety stash failed, nothing changed: %v", err))
+	}
+	if out, err := gitCmd(root, op.Command...).CombinedOutput(); err != nil {
+		fail(fmt.Errorf("git %s: %s", strings.Join(op.Command, " "), strings.TrimSpace(string(out))))
+	}
+	fmt.Printf("%s %sDone%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+	if snapshot != "" {
+		fmt.Printf("   %ssafety stash %s — bring it back with `gits stash apply stash@{0}`%s\n", Dim, shortOid(snapshot), Reset)
+	}
+	if action == "commit" || action == "reflog" {
+		fmt.Printf("   %sthe previous HEAD stays in the reflog (`gits undo reflog`)%s\n", Dim, Reset)
+	}
+}
@@ -0,0 +1,174 @@
+// File: unpushed.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: detection of local branches with commits on no remote (`gits unpushed`)
+// License: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UnpushedBranch is a local branch holding commits that no remote has.
+type UnpushedBranch struct {
+	Name     string    `json:"name"`
+	Upstream string    `json:"upstream,omitempty"`
+	Commits  int       `json:"commits"`
+	Oldest   time.Time `json:"oldest"` // commit time of the oldest unpushed commit
+
+	// Equivalent counts unpushed commits whose patch already exists
+	// upstream (rebased or cherry-picked there), as reported by git cherry.
+	Equivalent int `json:"patch_equivalent,omitempty"`
+}
+
+// collectUnpushed lists every local branch in dir with commits not reachable
+// from any remote-tracking ref.  Repositories without remotes report all of
+// their branches, since none of that work exists anywhere else.
+func collectUnpushed(dir string) ([]UnpushedBranch, error) {
+	refs, err := gitOutput(dir, "for-each-ref", "--format=%(refname:short)%00%(upstream:short)", "refs/heads")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []UnpushedBranch
+	for _, line := range strings.Split(refs, "\n") {
+		branch, upstream, _ := strings.Cut(line, "\x00")
+		if branch == "" {
+			continue
+		}
+		out, err := gitOutput(dir, "log", "--format=%ct", "refs/heads/"+branch, "--not", "--remotes")
+		if err != nil || out == "" {
+			continue
+		}
+		lines := strings.Split(out, "\n")
+		ub := UnpushedBranch{Name: branch, Upstream: upstream, Commits: len(lines)}
+		// git log lists newest first, so the last line is the oldest commit.
+		if ts, err := strconv.ParseInt(lines[len(lines)-1], 10, 64); err == nil {
+			ub.Oldest = time.Unix(ts, 0)
+		}
+		if upstream != "" {
+			// "-" lines are commits whose change is already upstream.
+			if cherry, err := gitOutput(dir, "cherry", upstream, "refs/heads/"+branch); err == nil {
+				for _, l := range strings.Split(cherry, "\n") {
+					if strings.HasPrefix(l, "- ") {
+						ub.Equivalent++
+					}
+				}
+			}
+		}
+		result = append(result, ub)
+	}
+	return result, nil
+}
+
+// ---------------------------------------------------------------------------
+// `gits unpushed` subcommand
+// ---------------------------------------------------------------------------
+
+// runUnpushed implements `gits unpushed [targets...] [--json]`: an audit of
+// every local branch holding work that exists on no remote.
+func runUnpushed(status *Status, args []string) {
+	c := status.cfg.Colors
+	asJSON := false
+	var targets []string
+	for _, a := range args {
+		switch a {
+		case "--json":
+			asJSON = true
+		default:
+			targets = append(targets, a)
+		}
+	}
+
+	repos, err := resolveScanTargets(status.cfg.Groups, targets, 6)
+	if err != nil {
+		if asJSON {
+			fmt.Fprintf(os.Stderr, "gits unpushed: %v\n", err)
+			exit(1)
+		}
+		exitWithError(err, c)
+	}
+
+	type repoUnpushed struct {
+		Path     string           `json:"path"`
+		Branches []UnpushedBranch `json:"branches"`
+		Err      string           `json:"error,omitempty"`
+	}
+	results := make([]repoUnpushed, len(repos))
+	parallelEach(len(repos), func(i int) {
+		results[i].Path = repos[i]
+		branches, err := collectUnpushed(repos[i])
+		if err != nil {
+			results[i].Err = err.Error()
+		}
+		results[i].Branches = branches
+	})
+
+	var report []repoUnpushed
+	for _, r := range results {
+		if r.Err != "" || len(r.Branches) > 0 {
+			report = append(report, r)
+		}
+	}
+
+	if asJSON {
+		if report == nil {
+			report = []repoUnpushed{}
+		}
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(report) == 0 {
+		fmt.Printf("%s %sEvery local branch is on a remote%s (%d repositories checked)\n",
+			Icons.SUCCESS, resolveColor(c.UpToDate), Reset, len(repos))
+		return
+	}
+
+	total := 0
+	for i, r := range report {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s %s%s%s\n", Icons.FOLDER, Bold+resolveColor(c.CwdPath), displayPath(r.Path), Reset)
+		if r.Err != "" {
+			fmt.Printf("   %s %s%s%s\n", Icons.ERROR, resolveColor(c.Deleted), firstLine(r.Err), Reset)
+			continue
+		}
+
+		width := 0
+		for _, b := range r.Branches {
+			if len(b.Name) > width {
+				width = len(b.Name)
+			}
+		}
+		for _, b := range r.Branches {
+			total += b.Commits
+			noun := "commits"
+			if b.Commits == 1 {
+				noun = "commit"
+			}
+			fmt.Printf("   %s%s %-*s%s  %s%3d %-7s%s %soldest %s%s",
+				Bold+resolveColor(c.Branch), Icons.GIT, width, b.Name, Reset,
+				Bold+resolveColor(c.AheadBehind), b.Commits, noun, Reset,
+				Dim, shortAge(b.Oldest), Reset)
+			if b.Upstream != "" {
+				fmt.Printf("  %s→ %s%s", Dim, b.Upstream, Reset)
+			} else {
+				fmt.Printf("  %s(no upstream)%s", resolveColor(c.Deleted), Reset)
+			}
+			if b.Equivalent > 0 {
+				fmt.Printf("  %s%d already upstream as equivalent patches%s", Dim, b.Equivalent, Reset)
+			}
+			fmt.Println()
+		}
+	}
+	fmt.Printf("\n%s %d unpushed commits in %d of %d repositories\n", Icons.WARNING, total, len(report), len(repos))
+}
@@ -0,0 +1,120 @@
+// File: untracked.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: untracked directories collapsed with their size, or expanded
+// License: MIT
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// expandUntracked lists the files inside untracked directories, where git
+// only names the directory.  It is set by --expand-untracked or
+// expand_untracked = true in the config.
+var expandUntracked bool
+
+// untrackedDir is what an untracked directory holds, .gitignore applied.
+type untrackedDir struct {
+	files []string // relative to the current directory, like the status
+	sizes []int64
+	size  int64
+}
+
+// add records the file f of n bytes.
+func (d *untrackedDir) add(f string, n int64) {
+	d.files = append(d.files, f)
+	d.sizes = append(d.sizes, n)
+	d.size += n
+}
+
+// summary reads "312 files, 48.0 MiB".
+func (d *untrackedDir) summary() string {
+	return untrackedSummary(len(d.files), d.size)
+}
+
+// untrackedSummary is the summary of n files of size bytes.
+func untrackedSummary(n int, size int64) string {
+	noun := "files"
+	if n == 1 {
+		noun = "file"
+	}
+	return fmt.Sprintf("%d %s, %s", n, noun, humanSize(size))
+}
+
+// untrackedContents asks git once for the untracked files below dirs,
+// which end in "/" and are relative to cwd as the long status prints
+// them, and returns them by directory.  Without git, or should it fail, the map is empty and the
+// directories are shown as git named them.
+func untrackedContents(cwd string, dirs []string) map[string]*untrackedDir {
+	found := map[string]*untrackedDir{}
+	if len(dirs) == 0 || !gitAvailable() {
+		return found
+	}
+	args := []string{"ls-files", "--others", "--exclude-standard", "-z", "--"}
+	for _, d := range dirs {
+		found[d] = &untrackedDir{}
+		args = append(args, ":(literal)"+d)
+	}
+	out, err := gitRaw(cwd, args...)
+	if err != nil {
+		if debugMode {
+			fmt.Fprintf(os.Stderr, "untracked directories: %v\n", err)
+		}
+		return map[string]*untrackedDir{}
+	}
+	for _, f := range strings.Split(out, "\x00") {
+		if f == "" {
+			continue
+		}
+		// Untracked directories never nest, so the first one found
+		// walking up is the one; "./" is the current directory itself.
+		for d := path.Dir(f); ; d = path.Dir(d) {
+			if ud, ok := found[d+"/"]; ok {
+				var n int64
+				if fi, err := os.Lstat(filepath.Join(cwd, filepath.FromSlash(f))); err == nil {
+					n = fi.Size()
+				}
+				ud.add(f, n)
+				break
+			}
+			if d == "." || d == "/" {
+				break
+			}
+		}
+	}
+	return found
+}
+
+// flushUntracked renders the untracked lines collected from the status as
+// a flat list: each directory with its file count and size, and under
+// --expand-untracked its files below it.
+func (r *ansiRenderer) flushUntracked(w *bufio.Writer, lines []StatusLine) {
+	st := r.styles
+	for _, l := range lines {
+		ct := NewColoredText()
+		ct.Append("\t", "")
+		ct.AppendPrefixed("      ", visiblePath(l.Path), st.untracked)
+		if l.Dir == nil {
+			ct.WriteLine(w)
+			continue
+		}
+		ct.Append(" ("+l.Dir.summary()+")", Dim)
+		ct.WriteLine(w)
+		if !expandUntracked {
+			continue
+		}
+		for _, f := range l.Dir.files {
+			ct := NewColoredText()
+			ct.Append("\t", "")
+			ct.AppendPrefixed("        ", visiblePath(f), st.untracked)
+			ct.WriteLine(w)
+		}
+	}
+}
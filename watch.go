@@ -0,0 +1,199 @@
+// File: watch.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: `gits --watch` — live-refreshing status view
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// parseDurationOr parses a Go duration string, returning def when the value
+// is empty or invalid.
+func parseDurationOr(s string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d
+	}
+	return def
+}
+
+// gitDirOf returns the absolute .git directory for the worktree at root
+// (which may live elsewhere for linked worktrees and submodules).
+func gitDirOf(root string) string {
+	if dir, err := gitOutput(root, "rev-parse", "--absolute-git-dir"); err == nil {
+		return filepath.FromSlash(dir)
+	}
+	return filepath.Join(root, ".git")
+}
+
+// watchNotify watches the worktree and the repository's refs with fsnotify
+// and emits one value per debounced burst of changes.
+func watchNotify(root string, debounce time.Duration) (<-chan struct{}, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	addTree := func(dir string) error {
+		return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return nil
+			}
+			if path != dir && (d.Name() == ".git" || skipScanDirs[d.Name()]) {
+				return filepath.SkipDir
+			}
+			return w.Add(path)
+		})
+	}
+
+	gitDir := gitDirOf(root)
+	if err := addTree(root); err != nil {
+		w.Close()
+		return nil, err
+	}
+	// HEAD, index and packed-refs live directly in the git dir; branch and
+	// remote-tracking refs live below refs/.
+	if err := w.Add(gitDir); err != nil {
+		w.Close()
+		return nil, err
+	}
+	addTree(filepath.Join(gitDir, "refs"))
+
+	out := make(chan struct{}, 1)
+	go func() {
+		defer w.Close()
+		var timer *time.Timer
+		fire := func() {
+			select {
+			case out <- struct{}{}:
+			default:
+			}
+		}
+		for {
+			select {
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if strings.HasSuffix(ev.Name, ".lock") {
+					continue
+				}
+				if ev.Has(fsnotify.Create) && IsDir(ev.Name) {
+					addTree(ev.Name)
+				}
+				if timer == nil {
+					timer = time.AfterFunc(debounce, fire)
+				} else {
+					timer.Reset(debounce)
+				}
+			case _, ok := <-w.Errors:
+				// An overflow means events were dropped: refresh to be safe.
+				if !ok {
+					return
+				}
+				fire()
+			}
+		}
+	}()
+	return out, nil
+}
+
+// watchPoll re-checks the porcelain status every interval and emits a value
+// whenever it differs from the previous run.  Used on network filesystems
+// where change notifications are unreliable.
+func watchPoll(root string, interval time.Duration) <-chan struct{} {
+	out := make(chan struct{}, 1)
+	snapshot := func() string {
+		s, _ := gitRaw(root, "status", "--porcelain=v2", "--branch", "--show-stash", "-z")
+		return s
+	}
+	go func() {
+		last := snapshot()
+		for range time.Tick(interval) {
+			if cur := snapshot(); cur != last {
+				last = cur
+				select {
+				case out <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// runWatch implements `gits --watch [--poll [interval]] [--desktop] [path]`.
+func runWatch(status *Status, args []string) {
+	c := status.cfg.Colors
+	wc := status.cfg.Watch
+	target := "."
+	poll := wc.Poll
+	interval := parseDurationOr(wc.PollInterval, 2*time.Second)
+	debounce := parseDurationOr(wc.Debounce, 300*time.Millisecond)
+	desktop := status.cfg.Desktop
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--poll":
+			poll = true
+			if i+1 < len(args) {
+				if d, err := time.ParseDuration(args[i+1]); err == nil && d > 0 {
+					interval = d
+					i++
+				}
+			}
+		case "--desktop":
+			desktop.Enabled = true
+		default:
+			target = args[i]
+		}
+	}
+
+	root, err := repoRoot(target)
+	if err != nil {
+		exitWithError(err, c)
+	}
+
+	// Our own `git status` runs must not refresh the index, otherwise each
+	// refresh would trigger the next one.
+	primeStatusAccel(root)
+	os.Setenv("GIT_OPTIONAL_LOCKS", "0")
+
+	var changes <-chan struct{}
+	mode := "fs events"
+	if !poll {
+		ch, err := watchNotify(root, debounce)
+		if err != nil {
+			fmt.Printf("%s %sfile notifications unavailable (%v), falling back to polling%s\n",
+				Icons.WARNING, Bold+resolveColor(c.AheadBehind), err, Reset)
+			poll = true
+		} else {
+			changes = ch
+		}
+	}
+	if poll {
+		changes = watchPoll(root, interval)
+		mode = "polling every " + interval.String()
+	}
+
+	dn := newDesktopNotifier(desktop)
+	for {
+		fmt.Print("\033[H\033[2J")
+		status.ColorizeGitStatus(root, "")
+		if dn != nil {
+			dn.observe(CollectStatus(root))
+		}
+		fmt.Printf("\n%s%s watching (%s) — updated %s — Ctrl+C to quit%s\n",
+			Dim, Icons.INFO, mode, time.Now().Format("15:04:05"), Reset)
+		<-changes
+	}
+}
@@ -0,0 +1,119 @@
+// File: width.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: terminal column widths, for aligning CJK and emoji text
+// License: MIT
+
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// zeroWidthJoiner glues emoji into one glyph (👨‍👩‍👧): what follows it
+// takes no columns of its own.
+const zeroWidthJoiner = '‍'
+
+// wideSymbols are the emoji below U+1F000 shown wide by default (Unicode's
+// Emoji_Presentation), as ranges.
+var wideSymbols = [][2]rune{
+	{0x231A, 0x231B}, {0x23E9, 0x23EC}, {0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE},
+	{0x2614, 0x2615}, {0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE}, {0x26D4, 0x26D4},
+	{0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5}, {0x26FA, 0x26FA}, {0x26FD, 0x26FD},
+	{0x2705, 0x2705}, {0x270A, 0x270B}, {0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E},
+	{0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55},
+}
+
+// emojiPresentation (VS16) asks for the emoji form of the symbol before
+// it, which terminals draw two columns wide.
+const emojiPresentation = '\uFE0F'
+
+// runeCells is how many terminal columns r takes: 2 for wide East Asian
+// characters and emoji, 0 for combining marks, joiners and variation
+// selectors, 1 otherwise.
+func runeCells(r rune) int {
+	if r >= 0x231A && r <= 0x2B55 {
+		for _, w := range wideSymbols {
+			if r >= w[0] && r <= w[1] {
+				return 2
+			}
+		}
+	}
+	switch {
+	case r == zeroWidthJoiner, r == '​', r >= 0xFE00 && r <= 0xFE0F, r >= 0xE0100 && r <= 0xE01EF,
+		unicode.Is(unicode.Mn, r), unicode.Is(unicode.Me, r):
+		return 0
+	case r >= 0x1100 && r <= 0x115F, r >= 0x2E80 && r <= 0x303E, r >= 0x3041 && r <= 0xA4CF,
+		r >= 0xAC00 && r <= 0xD7A3, r >= 0xF900 && r <= 0xFAFF, r >= 0xFE30 && r <= 0xFE4F,
+		r >= 0xFF00 && r <= 0xFF60, r >= 0xFFE0 && r <= 0xFFE6, r >= 0x1F000 && r <= 0x1FAFF,
+		r >= 0x20000 && r <= 0x3FFFD:
+		return 2
+	}
+	return 1
+}
+
+// cellCounter adds up the columns of text read a rune at a time, for the
+// runes whose width depends on the one before: what follows a joiner, and
+// VS16 after a narrow symbol (⚙️), which is then drawn as a wide emoji.
+type cellCounter struct {
+	last   int
+	joined bool
+}
+
+// next is how many columns r adds.
+func (cc *cellCounter) next(r rune) int {
+	w := runeCells(r)
+	switch {
+	case cc.joined:
+		w = 0
+	case r == emojiPresentation && cc.last == 1:
+		w, cc.last = 1, 2
+	case w > 0:
+		cc.last = w
+	}
+	cc.joined = r == zeroWidthJoiner
+	return w
+}
+
+// stringCells is how many terminal columns s takes.  s holds no escape
+// sequences; see clipANSI for text that does.
+func stringCells(s string) int {
+	var cc cellCounter
+	cells := 0
+	for _, r := range s {
+		cells += cc.next(r)
+	}
+	return cells
+}
+
+// truncateCells shortens s to at most n columns, marking the cut with "…".
+func truncateCells(s string, n int) string {
+	if stringCells(s) <= n {
+		return s
+	}
+	var sb strings.Builder
+	var cc cellCounter
+	cells := 0
+	for _, r := range s {
+		w := cc.next(r)
+		if cells+w > n-1 {
+			break
+		}
+		cells += w
+		sb.WriteRune(r)
+	}
+	return sb.String() + "…"
+}
+
+// padCells pads s with spaces to n columns: on the right, or on the left
+// when right is set.
+func padCells(s string, n int, right bool) string {
+	pad := strings.Repeat(" ", max(n-stringCells(s), 0))
+	if right {
+		return pad + s
+	}
+	return s + pad
+}
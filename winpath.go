@@ -0,0 +1,48 @@
+// File: winpath.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: path and line-ending normalization for Windows
+// License: MIT
+
+package main
+
+import (
+	"runtime"
+	"strings"
+)
+
+// windowsPath writes a Windows path the way Explorer shows it: backslashes
+// only, an upper-case drive letter, and without the \\?\ prefix of
+// extended-length paths (\\?\UNC\server\share becomes \\server\share).
+func windowsPath(p string) string {
+	p = strings.ReplaceAll(p, "/", `\`)
+	switch {
+	case strings.HasPrefix(p, `\\?\UNC\`):
+		p = `\\` + p[len(`\\?\UNC\`):]
+	case strings.HasPrefix(p, `\\?\`):
+		p = p[len(`\\?\`):]
+	}
+	if len(p) >= 2 && p[1] == ':' && p[0] >= 'a' && p[0] <= 'z' {
+		p = string(p[0]-'a'+'A') + p[1:]
+	}
+	return p
+}
+
+// nativePath is p with the separators of the system gits runs on, so a
+// directory git reported (C:/src/app) and one from Windows (C:\src\app)
+// print alike.
+func nativePath(p string) string {
+	if runtime.GOOS == "windows" {
+		return windowsPath(p)
+	}
+	return p
+}
+
+// normalizeEOL turns the CRLF line endings some Windows builds and
+// wrappers of git print into LF, so lines parse the same everywhere.
+func normalizeEOL(s string) string {
+	if !strings.Contains(s, "\r\n") {
+		return s
+	}
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
@@ -0,0 +1,108 @@
+// File: wip.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: quick work-in-progress commits (`gits wip` / `gits unwip`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// wipPrefix starts the subject of every commit made by `gits wip`.
+const wipPrefix = "WIP "
+
+// runWip implements `gits wip [MESSAGE]`: stage everything, including
+// untracked files, and commit it as "WIP <timestamp> [message]".  Hooks are
+// skipped; the commit is a save point, not a finished change.
+func runWip(status *Status, args []string) {
+	c := status.cfg.Colors
+	root, err := repoRoot(".")
+	if err != nil {
+		exitWithError(err, c)
+	}
+	rs := CollectStatus(root)
+	if rs.Err != "" {
+		exitWithError(fmt.Errorf("%s", rs.Err), c)
+	}
+	if len(rs.Entries) == 0 {
+		fmt.Printf("%s %sNothing to save%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset)
+		return
+	}
+	if rs.Conflicts > 0 {
+		fmt.Printf("%s %sResolve the %d conflicted file(s) first%s\n", Icons.ERROR, Bold+resolveColor(c.Deleted), rs.Conflicts, Reset)
+		exit(1)
+	}
+
+	subject := wipPrefix + time.Now().Format("2006-01-02 15:04")
+	if len(args) > 0 {
+		subject += " " + strings.Join(args, " ")
+	}
+	if out, err := gitCmd(root, "add", "--all").CombinedOutput(); err != nil {
+		exitWithError(fmt.Errorf("git add: %s", strings.TrimSpace(string(out))), c)
+	}
+	if out, err := gitCmd(root, "commit", "--quiet", "--no-verify", "-m", subject).CombinedOutput(); err != nil {
+		exitWithError(fmt.Errorf("git commit: %s", strings.TrimSpace(string(out))), c)
+	}
+	short, _ := gitOutput(root, "rev-parse", "--short", "HEAD")
+	fmt.Printf("%s %sSaved%s %s%s%s %s\n", Icons.SUCCESS, Bold+resolveColor(c.UpToDate), Reset,
+		Bold+resolveColor(c.AheadBehind), short, Reset, subject)
+	if numstat, err := gitOutput(root, "show", "--numstat", "--format=", "HEAD"); err == nil {
+		fmt.Print(renderNumstat(numstat, c, "    "))
+	}
+	fmt.Printf("   %sbring it back with `gits unwip`%s\n", Dim, Reset)
+}
+
+// runUnwip implements `gits unwip [--yes]`: soft-reset the latest commit
+// when it is a WIP commit, leaving its changes staged in the worktree.
+func runUnwip(status *Status, args []string) {
+	c := status.cfg.Colors
+	yes := false
+	for _, a := range args {
+		if a == "-y" || a == "--yes" {
+			yes = true
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	out, err := gitOutput(root, "log", "-1", "--format=%H%x00%h%x00%s")
+	if err != nil {
+		fail(fmt.Errorf("no commits"))
+	}
+	f := strings.SplitN(out, "\x00", 3)
+	if len(f) < 3 || !strings.HasPrefix(f[2], wipPrefix) {
+		fail(fmt.Errorf("HEAD is not a WIP commit (%s)", f[len(f)-1]))
+	}
+	if _, err := gitOutput(root, "rev-parse", "--verify", "--quiet", "HEAD~1"); err != nil {
+		fail(fmt.Errorf("the WIP commit is the root commit; nothing to reset to"))
+	}
+	if commitPushed(root, f[0]) {
+		fmt.Printf("%s %s%s is already on a remote; after unwip the branch needs a force push%s\n",
+			Icons.WARNING, resolveColor(c.AheadBehind), f[1], Reset)
+		if !yes {
+			ok, err := confirm("Unwip anyway?")
+			if err != nil {
+				fail(fmt.Errorf("%v (use --yes)", err))
+			}
+			if !ok {
+				return
+			}
+		}
+	}
+
+	if out, err := gitCmd(root, "reset", "--soft", "HEAD~1").CombinedOutput(); err != nil {
+		fail(fmt.Errorf("git reset: %s", strings.TrimSpace(string(out))))
+	}
+	fmt.Printf("%s %sRestored%s %s%s%s %s\n\n", Icons.SUCCESS, Bold+resolveColor(c.UpToDate), Reset,
+		Bold+resolveColor(c.AheadBehind), f[1], Reset, f[2])
+	status.ColorizeGitStatus(root, "")
+}
@@ -0,0 +1,245 @@
+// File: worktree.go
+// Author: Hadi Cahyadi <cumulus13@gmail.com>
+// Date: 2026-10-15
+// Description: worktree overview and management (`gits worktree`)
+// License: MIT
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// worktreeInfo is one entry of `git worktree list --porcelain`.
+type worktreeInfo struct {
+	Path     string
+	Head     string
+	Branch   string // short name; "" when detached or bare
+	Bare     bool
+	Locked   bool
+	Prunable bool
+	Dirty    int // changed + untracked entries
+}
+
+// listWorktrees returns the worktrees of the repository at dir, main first.
+func listWorktrees(dir string) ([]worktreeInfo, error) {
+	out, err := gitOutput(dir, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	var list []worktreeInfo
+	for _, block := range strings.Split(out, "\n\n") {
+		var w worktreeInfo
+		for _, line := range strings.Split(block, "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				w.Path = value
+			case "HEAD":
+				w.Head = value
+			case "branch":
+				w.Branch = strings.TrimPrefix(value, "refs/heads/")
+			case "bare":
+				w.Bare = true
+			case "locked":
+				w.Locked = true
+			case "prunable":
+				w.Prunable = true
+			}
+		}
+		if w.Path != "" {
+			list = append(list, w)
+		}
+	}
+	parallelEach(len(list), func(i int) {
+		if list[i].Bare || list[i].Prunable {
+			return
+		}
+		if st, err := gitOutput(list[i].Path, "status", "--porcelain"); err == nil && st != "" {
+			list[i].Dirty = len(strings.Split(st, "\n"))
+		}
+	})
+	return list, nil
+}
+
+// worktreePathFor is the conventional location of a worktree for branch:
+// a sibling of the main worktree named "<repo>-<branch>", with slashes in
+// the branch name turned into dashes (feature/login → gits-go-feature-login).
+func worktreePathFor(mainPath, branch string) string {
+	slug := strings.NewReplacer("/", "-", "\\", "-", " ", "-").Replace(branch)
+	return filepath.Join(filepath.Dir(mainPath), filepath.Base(mainPath)+"-"+slug)
+}
+
+// findWorktree matches arg against worktree paths and branch names.
+func findWorktree(list []worktreeInfo, arg string) (worktreeInfo, bool) {
+	abs, _ := filepath.Abs(expandHome(arg))
+	for _, w := range list {
+		if w.Path == abs || w.Branch == arg || filepath.Base(w.Path) == arg {
+			return w, true
+		}
+	}
+	return worktreeInfo{}, false
+}
+
+// runWorktree implements `gits worktree [list|add|remove]`.
+func runWorktree(status *Status, args []string) {
+	c := status.cfg.Colors
+	action := "list"
+	if len(args) > 0 {
+		action = args[0]
+		args = args[1:]
+	}
+	yes, force := false, false
+	from := ""
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-y", "--yes":
+			yes = true
+		case "-f", "--force":
+			force = true
+		case "--from":
+			if i+1 < len(args) {
+				i++
+				from = args[i]
+			}
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+	fail := func(err error) {
+		exitWithError(err, c)
+	}
+
+	root, err := repoRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	list, err := listWorktrees(root)
+	if err != nil {
+		fail(err)
+	}
+
+	switch action {
+	case "list", "ls":
+		for i, w := range list {
+			marker := "  "
+			if samePath(w.Path, root) {
+				marker = Bold + resolveColor(c.Arrow) + "❯ " + Reset
+			}
+			branch := Bold + resolveColor(c.Branch) + Icons.GIT + " " + w.Branch + Reset
+			switch {
+			case w.Bare:
+				branch = Dim + "(bare)" + Reset
+			case w.Branch == "":
+				branch = Bold + resolveColor(c.AheadBehind) + "(detached " + shortOid(w.Head) + ")" + Reset
+			}
+			state := resolveColor(c.UpToDate) + "clean" + Reset
+			switch {
+			case w.Prunable:
+				state = Bold + resolveColor(c.Deleted) + "missing (git worktree prune)" + Reset
+			case w.Bare:
+				state = ""
+			case w.Dirty > 0:
+				state = Bold + resolveColor(c.Modified) + fmt.Sprintf("%d change(s)", w.Dirty) + Reset
+			}
+			if w.Locked {
+				state += " " + Dim + "🔒 locked" + Reset
+			}
+			label := ""
+			if i == 0 {
+				label = Dim + " (main)" + Reset
+			}
+			fmt.Printf("%s%s %s%s%s%s  %s\n", marker, branch, resolveColor(c.CwdPath), displayPath(w.Path), Reset, label, state)
+		}
+
+	case "add":
+		if len(positional) == 0 {
+			fail(fmt.Errorf("usage: gits worktree add BRANCH [PATH] [--from REF]"))
+		}
+		branch := positional[0]
+		path := worktreePathFor(list[0].Path, branch)
+		if len(positional) > 1 {
+			path, _ = filepath.Abs(expandHome(positional[1]))
+		}
+		if Exists(path) {
+			fail(fmt.Errorf("%s already exists", displayPath(path)))
+		}
+		// An existing local branch is checked out; a remote one gets a
+		// tracking branch; anything else is a new branch from --from or HEAD.
+		gitArgs := []string{"worktree", "add", path, branch}
+		what := "existing branch"
+		if _, err := gitOutput(root, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err != nil {
+			remote := pushRemoteFor(root, branch)
+			if _, err := gitOutput(root, "rev-parse", "--verify", "--quiet", "refs/remotes/"+remote+"/"+branch); err == nil && from == "" {
+				gitArgs = []string{"worktree", "add", "--track", "-b", branch, path, remote + "/" + branch}
+				what = "tracking " + remote + "/" + branch
+			} else {
+				if from == "" {
+					from = "HEAD"
+				}
+				gitArgs = []string{"worktree", "add", "-b", branch, path, from}
+				what = "new branch from " + from
+			}
+		}
+		if out, err := gitCmd(root, gitArgs...).CombinedOutput(); err != nil {
+			fail(fmt.Errorf("git worktree add: %s", strings.TrimSpace(string(out))))
+		}
+		fmt.Printf("%s %sAdded worktree%s %s%s%s %s%s %s%s %s(%s)%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), Reset,
+			resolveColor(c.CwdPath), displayPath(path), Reset, Bold+resolveColor(c.Branch), Icons.GIT, branch, Reset, Dim, what, Reset)
+
+	case "remove", "rm":
+		var target worktreeInfo
+		if len(positional) > 0 {
+			w, ok := findWorktree(list, positional[0])
+			if !ok {
+				fail(fmt.Errorf("no worktree matches %q", positional[0]))
+			}
+			target = w
+		} else {
+			var items []pickItem
+			for _, w := range list[1:] {
+				items = append(items, pickItem{Tag: w.Branch, TagStyle: Bold + resolveColor(c.Branch), Label: displayPath(w.Path)})
+			}
+			if len(items) == 0 {
+				fail(fmt.Errorf("there are no linked worktrees to remove"))
+			}
+			i, ok, err := pickOne("Remove which worktree?", items, c)
+			if err != nil {
+				fail(err)
+			}
+			if !ok {
+				return
+			}
+			target = list[i+1]
+		}
+		if target.Path == list[0].Path {
+			fail(fmt.Errorf("the main worktree cannot be removed"))
+		}
+		if target.Dirty > 0 && !force {
+			fail(fmt.Errorf("%s has %d uncommitted change(s); commit them or use --force to discard", displayPath(target.Path), target.Dirty))
+		}
+		if !yes {
+			ok, err := confirm(fmt.Sprintf("Remove worktree %s? The branch %s is kept.", displayPath(target.Path), target.Branch))
+			if err != nil {
+				fail(fmt.Errorf("%v (use --yes)", err))
+			}
+			if !ok {
+				return
+			}
+		}
+		gitArgs := []string{"worktree", "remove", target.Path}
+		if force {
+			gitArgs = []string{"worktree", "remove", "--force", target.Path}
+		}
+		if out, err := gitCmd(root, gitArgs...).CombinedOutput(); err != nil {
+			fail(fmt.Errorf("git worktree remove: %s", strings.TrimSpace(string(out))))
+		}
+		fmt.Printf("%s %sRemoved worktree %s%s\n", Icons.SUCCESS, resolveColor(c.UpToDate), displayPath(target.Path), Reset)
+
+	default:
+		fail(fmt.Errorf("unknown worktree action %q (list, add, remove)", action))
+	}
+}